@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/Shaheen-AlQaraghuli/wallet-go/config"
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+	}
+
+	cmd.AddCommand(newConfigPrintCmd(a))
+
+	return cmd
+}
+
+func newConfigPrintCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "print",
+		Short: "Show the effective configuration, with secrets redacted, and validate it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := config.Read(a.configPath)
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Print(cmd.OutOrStdout()); err != nil {
+				return err
+			}
+
+			return cfg.Validate()
+		},
+	}
+}
@@ -1,7 +1,8 @@
 package main
 
 import (
-	"github.com/Shaheen-AlQaraghuli/wallet-go/cmd/server"
+	"fmt"
+	"os"
 )
 
 // @title Wallet Service API.
@@ -10,5 +11,8 @@ import (
 // @contact.name Wallet Service Owners
 // @BasePath    /api/
 func main() {
-	server.StartServer()
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/config"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/database/migrations"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/pressly/goose/v3"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func newMigrateCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply or roll back the database migrations built into the binary",
+	}
+
+	cmd.AddCommand(
+		newMigrateUpCmd(a),
+		newMigrateDownCmd(a),
+		newMigrateStatusCmd(a),
+		newMigrateVersionCmd(a),
+	)
+
+	return cmd
+}
+
+func newMigrateUpCmd(a *app) *cobra.Command {
+	var to int64
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return a.withMigrations(func(provider *goose.Provider) error {
+				var (
+					results []*goose.MigrationResult
+					err     error
+				)
+
+				if cmd.Flags().Changed("to") {
+					results, err = provider.UpTo(cmd.Context(), to)
+				} else {
+					results, err = provider.Up(cmd.Context())
+				}
+
+				printResults(cmd, results)
+
+				if err == nil && len(results) == 0 {
+					cmd.Println("No pending migrations")
+				}
+
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().Int64Var(&to, "to", 0, "migrate up to this version instead of the latest")
+
+	return cmd
+}
+
+func newMigrateDownCmd(a *app) *cobra.Command {
+	var to int64
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the last migration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return a.withMigrations(func(provider *goose.Provider) error {
+				if cmd.Flags().Changed("to") {
+					results, err := provider.DownTo(cmd.Context(), to)
+					printResults(cmd, results)
+
+					return err
+				}
+
+				result, err := provider.Down(cmd.Context())
+				if errors.Is(err, goose.ErrNoNextVersion) {
+					cmd.Println("No migration to roll back")
+
+					return nil
+				}
+
+				if result != nil {
+					printResults(cmd, []*goose.MigrationResult{result})
+				}
+
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().Int64Var(&to, "to", 0, "roll back every migration after this version, 0 for all")
+
+	return cmd
+}
+
+func newMigrateStatusCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "List the migrations and whether they are applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return a.withMigrations(func(provider *goose.Provider) error {
+				statuses, err := provider.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "VERSION\tSTATE\tAPPLIED AT\tFILE")
+
+				for _, status := range statuses {
+					appliedAt := ""
+					if !status.AppliedAt.IsZero() {
+						appliedAt = status.AppliedAt.Format(time.RFC3339)
+					}
+
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
+						status.Source.Version, status.State, appliedAt, status.Source.Path)
+				}
+
+				return w.Flush()
+			})
+		},
+	}
+}
+
+func newMigrateVersionCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show the database version and the latest version built into the binary",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return a.withMigrations(func(provider *goose.Provider) error {
+				current, target, err := provider.GetVersions(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf("database: %d\nlatest:   %d\n", current, target)
+
+				return nil
+			})
+		},
+	}
+}
+
+// withMigrations connects to the configured database. Only the database settings are needed to migrate.
+func (a *app) withMigrations(do func(provider *goose.Provider) error) error {
+	cfg, err := config.Read(a.configPath)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Database.DSN == "" {
+		return errors.New("database.dsn (DATABASE_DSN) is required")
+	}
+
+	driver := dblib.Driver(cfg.Database.Driver)
+
+	gormDB, err := dblib.Open(driver, cfg.Database.DSN, &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db, err := gormDB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	provider, err := migrations.NewProvider(db, driver)
+	if err != nil {
+		return err
+	}
+
+	return do(provider)
+}
+
+func printResults(cmd *cobra.Command, results []*goose.MigrationResult) {
+	for _, result := range results {
+		cmd.Println(result.String())
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"github.com/Shaheen-AlQaraghuli/wallet-go/cmd/server"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/config"
+	"github.com/spf13/cobra"
+)
+
+// app holds the global flags shared by every command.
+type app struct {
+	configPath  string
+	autoMigrate bool
+	storage     string
+}
+
+func newRootCmd() *cobra.Command {
+	a := &app{}
+
+	cmd := &cobra.Command{
+		Use:           "wallet-service",
+		Short:         "Wallet service API server",
+		Long:          "Serves the wallet API. Without a command it starts the server, like serve.",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          a.serve,
+	}
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP and gRPC servers",
+		Args:  cobra.NoArgs,
+		RunE:  a.serve,
+	}
+
+	for _, c := range []*cobra.Command{cmd, serveCmd} {
+		c.Flags().BoolVar(&a.autoMigrate, "auto-migrate", false,
+			"apply pending migrations before serving, overrides database.auto_migrate")
+		c.Flags().StringVar(&a.storage, "storage", "",
+			"storage backend, database (Postgres or SQLite per database.driver) or memory, overrides storage.backend")
+	}
+
+	cmd.PersistentFlags().StringVar(&a.configPath, "config", "",
+		"path of the YAML config file, defaults to $"+config.EnvConfigFile)
+
+	cmd.AddCommand(
+		serveCmd,
+		newConfigCmd(a),
+		newMigrateCmd(a),
+	)
+
+	return cmd
+}
+
+func (a *app) serve(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Read(a.configPath)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("auto-migrate") {
+		cfg.Database.AutoMigrate = a.autoMigrate
+	}
+
+	if cmd.Flags().Changed("storage") {
+		cfg.Storage.Backend = a.storage
+	}
+
+	// Validated once the flags are applied, as --storage=memory lifts the need for a database and Redis.
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	server.StartServer(cfg)
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,48 +13,120 @@ import (
 
 	"github.com/Shaheen-AlQaraghuli/wallet-go/config"
 	_ "github.com/Shaheen-AlQaraghuli/wallet-go/docs"
-	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/cache"
+	healthCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/health"
+	streamCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/streams"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/grpcapi"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/router"
+	transactionSvc "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/logging"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/middleware"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
-func StartServer() {
-	cfg := config.Config()
+func StartServer(cfg *config.AppConfig) {
+	logger, err := logging.New(cfg.Log.Level, cfg.App.Debug)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+	defer func() { _ = logger.Sync() }()
 
 	if err := types.RegisterValidations(); err != nil {
-		log.Fatalf("Failed to register validations: %v", err)
+		logger.Fatal("Failed to register validations", zap.Error(err))
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    tracing.Exporter(cfg.Tracing.Exporter),
+		ServiceName: cfg.App.Name,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.Fatal("Failed to set up tracing", zap.Error(err))
+	}
+
+	backend, err := setupStorage(context.Background(), cfg, logger)
+	if err != nil {
+		logger.Fatal("Failed to set up storage", zap.Error(err), zap.String("backend", cfg.Storage.Backend))
+	}
+
+	backend.deps.Logger = logger
+	backend.deps.Now = time.Now
+	backend.deps.ApprovalPolicy = transactionSvc.ApprovalPolicy{
+		Threshold: cfg.Approvals.Threshold,
+		Approvers: cfg.Approvals.Approvers,
 	}
+	backend.deps.FeeSchedule = cfg.FeeSchedule()
+	backend.deps.InterestSchedule = cfg.InterestSchedule()
+	backend.deps.DisputeResponseWindow = cfg.Disputes.ResponseWindow
 
-	db, err := setupDatabase(cfg)
+	riskRules, err := cfg.Risk.Rules(backend.deps.Transactions)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		logger.Fatal("Failed to load risk rules", zap.Error(err))
 	}
 
-	cache := cache.New(cfg.Redis.URL, cfg.App.Name)
+	backend.deps.RiskRules = riskRules
+	backend.deps.BucketPolicy = transactionSvc.BucketPolicy{
+		Priority: cfg.Buckets.Priority,
+		PromoTTL: cfg.Buckets.PromoTTL,
+	}
+	services := router.NewServices(backend.deps)
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	go backend.run(backgroundCtx)
+	go expireCredits(backgroundCtx, services.Transactions, cfg.Buckets.ExpiryInterval, logger)
+	go runInterest(backgroundCtx, services.Transactions, cfg.Interest.RunInterval, logger)
 
-	router := setupRouter(cfg)
+	healthController := healthCtrl.New(backend.checks...)
 
-	setupRoutes(db, cache, router)
+	shutdown := make(chan struct{})
+	engine := setupRouter(cfg, logger)
+
+	setupRoutes(services, engine, healthController, streamCtrl.Config{
+		HeartbeatInterval:       cfg.Streams.HeartbeatInterval,
+		MaxConnections:          cfg.Streams.MaxConnections,
+		MaxConnectionsPerWallet: cfg.Streams.MaxConnectionsPerWallet,
+		Done:                    shutdown,
+	})
 
 	server := &http.Server{
 		Addr:              fmt.Sprintf(":%d", cfg.App.Port),
-		Handler:           router,
-		ReadTimeout:       15 * time.Second,
-		WriteTimeout:      15 * time.Second,
-		IdleTimeout:       60 * time.Second,
-		ReadHeaderTimeout: 5 * time.Second,
+		Handler:           engine,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
 	}
 
 	go func() {
-		log.Printf("Starting server on port %d", cfg.App.Port)
+		logger.Info("Starting server", zap.Uint16("port", cfg.App.Port))
 
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			logger.Fatal("Failed to start server", zap.Error(err))
+		}
+	}()
+
+	grpcServer := grpcapi.NewServer(services.Wallets, services.Transactions, services.Events,
+		grpcapi.EndStreams(shutdown))
+
+	go func() {
+		logger.Info("Starting gRPC server", zap.Uint16("port", cfg.GRPC.Port))
+
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+		if err != nil {
+			logger.Fatal("Failed to listen for gRPC", zap.Error(err))
+		}
+
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Fatal("Failed to start gRPC server", zap.Error(err))
 		}
 	}()
 
@@ -61,63 +134,150 @@ func StartServer() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("Shutting down server...")
+
+	// Fail readiness first and keep serving while load balancers notice, so no request hits a closed port.
+	healthController.Drain()
+	time.Sleep(cfg.App.ShutdownDelay)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Open streams of both APIs end right away; clients reconnect to another replica and resume.
+	close(shutdown)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer cancel()
 
+	grpcStopped := make(chan struct{})
+
+	go func() {
+		defer close(grpcStopped)
+		stopGRPC(ctx, grpcServer)
+	}()
+
+	// A forced shutdown is only logged, so that the storage is still closed and the traces flushed.
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		logger.Error("Server forced to shutdown", zap.Error(err))
 	}
 
-	log.Println("Server exited")
-}
+	<-grpcStopped
+
+	stopBackground()
 
-func setupDatabase(cfg *config.AppConfig) (*gorm.DB, error) {
-	gormLogger := logger.Default
-	if cfg.App.Debug {
-		gormLogger = logger.Default.LogMode(logger.Info)
+	if err := backend.close(); err != nil {
+		logger.Error("Failed to close storage", zap.Error(err))
 	}
 
-	db, err := gorm.Open(postgres.Open(cfg.Database.DSN), &gorm.Config{
-		Logger: gormLogger,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancelFlush()
+
+	if err := shutdownTracing(flushCtx); err != nil {
+		logger.Error("Failed to flush traces", zap.Error(err))
 	}
 
-	sqlDB, err := db.DB()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	logger.Info("Server exited")
+}
+
+// stopGRPC waits for the open RPCs of server to finish, and stops it forcibly once ctx is done.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+		<-stopped
 	}
+}
+
+// expireCredits writes off expired credits every interval until ctx is done. Every replica runs it, which
+// is safe as each wallet is expired under its lock.
+func expireCredits(ctx context.Context, service *transactionSvc.Service, interval time.Duration,
+	logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := service.ExpireCredits(ctx)
+			if err != nil {
+				logger.Error("Failed to expire credits", zap.Error(err))
+			}
+
+			if expired > 0 {
+				logger.Info("Expired credits", zap.Int("count", expired))
+			}
+		}
+	}
+}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+// runInterest accrues the interest of the last day over and pays out that of the last month over every
+// interval until ctx is done. Both only ever happen once, so every replica runs it. Days missed while no
+// replica ran are accrued through the API.
+func runInterest(ctx context.Context, service *transactionSvc.Service, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	return db, nil
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			year, month, day := time.Now().UTC().Date()
+
+			accrued, err := service.AccrueInterest(ctx, time.Date(year, month, day-1, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				logger.Error("Failed to accrue interest", zap.Error(err))
+			}
+
+			if accrued > 0 {
+				logger.Info("Accrued interest", zap.Int("wallets", accrued))
+			}
+
+			posted, err := service.PostInterest(ctx, time.Date(year, month-1, 1, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				logger.Error("Failed to post interest", zap.Error(err))
+			}
+
+			if posted > 0 {
+				logger.Info("Posted interest", zap.Int("wallets", posted))
+			}
+		}
+	}
 }
 
-func setupRouter(cfg *config.AppConfig) *gin.Engine {
+func setupRouter(cfg *config.AppConfig, logger *zap.Logger) *gin.Engine {
 	if !cfg.App.Debug {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
+	// Services receive the gin context; let it expose the request ID and span of the request context.
+	router.ContextWithFallback = true
 
-	router.Use(gin.Logger())
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Actor)
+	router.Use(middleware.AccessLog(logger))
 	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware(cfg.App.Name))
+	router.Use(middleware.Metrics)
 
 	return router
 }
 
-func setupRoutes(db *gorm.DB, cache *cache.Cache, router *gin.Engine) {
-	addSwaggerRoutes(router)
-	grp := router.Group("api/v1")
-	{
-		addWalletRoutes(db, cache, grp)
-		addTransactionRoutes(db, cache, grp)
-	}
+func setupRoutes(services router.Services, engine *gin.Engine, health *healthCtrl.Controller,
+	streams streamCtrl.Config) {
+	addSwaggerRoutes(engine)
+	engine.GET("/healthz", health.Healthz)
+	engine.GET("/readyz", health.Readyz)
+	engine.GET("/metrics", gin.WrapH(metrics.Handler()))
+	router.Register(engine.Group("api/v1"), services, router.WithStreamConfig(streams))
 }
 
 func addSwaggerRoutes(router *gin.Engine) {
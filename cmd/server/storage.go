@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/config"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/database/migrations"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/cache"
+	healthCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/health"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/locks"
+	approvalsRepo "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/approvals"
+	disputeRepo "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/disputes"
+	interestRepo "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/interest"
+	riskRepo "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/risk"
+	transactionsRepo "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/transactions"
+	walletRepo "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/router"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/logging"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
+)
+
+// backend is the storage the services run on, along with the checks telling whether it is reachable.
+type backend struct {
+	deps   router.Dependencies
+	checks []healthCtrl.Check
+	// run does the background work of the backend until ctx is done.
+	run func(ctx context.Context)
+	// close flushes the backend once the servers have stopped.
+	close func() error
+}
+
+func setupStorage(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (backend, error) {
+	if cfg.StorageBackend() == storage.BackendMemory {
+		return setupMemoryStorage(cfg, logger)
+	}
+
+	return setupDatabaseStorage(ctx, cfg, logger)
+}
+
+func setupDatabaseStorage(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (backend, error) {
+	db, err := setupDatabase(cfg, logger)
+	if err != nil {
+		return backend{}, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return backend{}, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	driver := dblib.Driver(cfg.Database.Driver)
+
+	migrationProvider, err := migrations.NewProvider(sqlDB, driver)
+	if err != nil {
+		return backend{}, err
+	}
+
+	if cfg.Database.AutoMigrate {
+		if err := migrate(ctx, migrationProvider, logger); err != nil {
+			return backend{}, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	b := backend{
+		deps: router.Dependencies{
+			Wallets:      walletRepo.New(db),
+			Transactions: transactionsRepo.New(db),
+			Approvals:    approvalsRepo.New(db),
+			Interest:     interestRepo.New(db),
+			Disputes:     disputeRepo.New(db),
+			Risk:         riskRepo.New(db),
+		},
+		checks: []healthCtrl.Check{
+			{Name: string(driver), Check: sqlDB.PingContext},
+			{Name: "migrations", Check: func(ctx context.Context) error {
+				return migrations.CheckVersion(ctx, migrationProvider)
+			}},
+		},
+		run:   func(context.Context) {},
+		close: sqlDB.Close,
+	}
+
+	// Without Redis, which only SQLite deployments may leave out, balances and idempotency keys are cached
+	// and events are streamed within the process.
+	if cfg.Redis.URL == "" {
+		b.deps.Cache = cache.NewInProcess(cfg.Redis.BalanceTTL, cfg.Redis.IdempotencyTTL, time.Now)
+		b.deps.Locker = newLocker(cfg, nil, sqlDB)
+
+		return b, nil
+	}
+
+	redis := cache.New(cfg.Redis.URL, cfg.App.Name,
+		cache.WithMutex(cfg.Locks.TTL, cfg.Locks.RetryDelay),
+		cache.WithBalanceTTL(cfg.Redis.BalanceTTL),
+		cache.WithIdempotencyTTL(cfg.Redis.IdempotencyTTL),
+	)
+	eventBus := redis.EventBus(logger)
+
+	b.deps.Cache = redis
+	b.deps.Locker = newLocker(cfg, redis, sqlDB)
+	b.deps.Events = eventBus
+	b.checks = append(b.checks, healthCtrl.Check{Name: "redis", Check: redis.Ping})
+	b.run = eventBus.Run
+
+	return b, nil
+}
+
+// setupMemoryStorage keeps the data in the process, restored from and saved to the snapshot file when
+// one is configured. Locks and events only span this instance.
+func setupMemoryStorage(cfg *config.AppConfig, logger *zap.Logger) (backend, error) {
+	store := memory.New(time.Now)
+	path := cfg.Storage.SnapshotFile
+
+	if path != "" {
+		if err := store.LoadFile(path); err != nil {
+			return backend{}, err
+		}
+
+		logger.Info("Loaded storage snapshot", zap.String("path", path))
+	}
+
+	b := backend{
+		deps: router.Dependencies{
+			Wallets:      store.Wallets(),
+			Transactions: store.Transactions(),
+			Approvals:    store.Approvals(),
+			Interest:     store.Interest(),
+			Disputes:     store.Disputes(),
+			Risk:         store.Risk(),
+			Cache:        store.Cache(),
+			Locker:       locks.Instrument(store.Locker(), cfg.Locks.Wait),
+		},
+		run:   func(context.Context) {},
+		close: func() error { return nil },
+	}
+
+	if path == "" {
+		return b, nil
+	}
+
+	b.run = func(ctx context.Context) {
+		ticker := time.NewTicker(cfg.Storage.SnapshotInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := store.SaveFile(path); err != nil {
+					logger.Error("Failed to save storage snapshot", zap.Error(err), zap.String("path", path))
+				}
+			}
+		}
+	}
+	b.close = func() error {
+		return store.SaveFile(path)
+	}
+
+	return b, nil
+}
+
+// newLocker returns the configured lock backend. The Postgres backend keeps transactions flowing while
+// Redis is down. redis is nil when no Redis is configured, in which case the config asks for no redis locks.
+func newLocker(cfg *config.AppConfig, redis locks.Locker, db *sql.DB) locks.Locker {
+	var locker locks.Locker
+
+	switch cfg.LockBackend() {
+	case locks.BackendPostgres:
+		locker = locks.NewPostgres(db, cfg.App.Name, cfg.Locks.RetryDelay)
+	case locks.BackendMemory:
+		locker = locks.NewInProcess()
+	default:
+		locker = redis
+	}
+
+	return locks.Instrument(locker, cfg.Locks.Wait)
+}
+
+// migrate applies the pending migrations. Replicas starting together wait for each other on the
+// migration lock, and those that get it last find nothing left to apply.
+func migrate(ctx context.Context, provider *goose.Provider, logger *zap.Logger) error {
+	results, err := provider.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		logger.Info("Applied migration",
+			zap.Int64("version", result.Source.Version), zap.Duration("duration", result.Duration))
+	}
+
+	return nil
+}
+
+func setupDatabase(cfg *config.AppConfig, logger *zap.Logger) (*gorm.DB, error) {
+	gormLogger := logging.NewGormLogger(logger).LogMode(gormlogger.Warn)
+	if logger.Core().Enabled(zap.DebugLevel) {
+		gormLogger = gormLogger.LogMode(gormlogger.Info)
+	}
+
+	driver := dblib.Driver(cfg.Database.Driver)
+
+	db, err := dblib.Open(driver, cfg.Database.DSN, &gorm.Config{
+		Logger:         gormLogger,
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := db.Use(dblib.MetricsPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics())); err != nil {
+		return nil, fmt.Errorf("failed to register database tracing: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	if err := metrics.RegisterDBStats(sqlDB); err != nil {
+		return nil, fmt.Errorf("failed to register connection pool metrics: %w", err)
+	}
+
+	// SQLite keeps the single connection it was opened with.
+	if driver == dblib.DriverPostgres {
+		sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultProfile = "default"
+	defaultBaseURL = "http://localhost:8080/api"
+)
+
+// Profile holds the connection settings of one wallet service environment.
+type Profile struct {
+	BaseURL string `yaml:"base_url"`
+	APIKey  string `yaml:"api_key,omitempty"`
+}
+
+// Config is the walletctl configuration file, by default ~/.config/walletctl/config.yaml.
+type Config struct {
+	CurrentProfile string             `yaml:"current_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join(".walletctl", "config.yaml")
+	}
+
+	return filepath.Join(dir, "walletctl", "config.yaml")
+}
+
+// loadConfig reads the configuration file. A missing file yields an empty configuration.
+func loadConfig(path string) (*Config, error) {
+	cfg := &Config{Profiles: map[string]Profile{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Profile{}
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// The file may hold API keys.
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// profile resolves the named profile, falling back to the current one. Without any configuration
+// the default profile points at a local service.
+func (c *Config) profile(name string) (Profile, error) {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+
+	if name == "" {
+		name = defaultProfile
+	}
+
+	profile, found := c.Profiles[name]
+	if !found && name != defaultProfile {
+		return Profile{}, fmt.Errorf("unknown profile %q, known profiles: %s", name, strings.Join(c.profileNames(), ", "))
+	}
+
+	if profile.BaseURL == "" {
+		profile.BaseURL = defaultBaseURL
+	}
+
+	return profile, nil
+}
+
+func (c *Config) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return "********"
+}
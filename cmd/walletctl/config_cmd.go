@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage connection profiles",
+	}
+
+	cmd.AddCommand(
+		newConfigViewCmd(a),
+		newConfigSetProfileCmd(a),
+		newConfigUseProfileCmd(a),
+	)
+
+	return cmd
+}
+
+func newConfigViewCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "view",
+		Short: "Show the profiles, with API keys redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(a.configPath)
+			if err != nil {
+				return err
+			}
+
+			tbl := table{header: []string{"CURRENT", "PROFILE", "BASE URL", "API KEY"}}
+
+			for _, name := range cfg.profileNames() {
+				profile := cfg.Profiles[name]
+				profile.APIKey = redact(profile.APIKey)
+				cfg.Profiles[name] = profile
+
+				current := ""
+				if name == cfg.CurrentProfile {
+					current = "*"
+				}
+
+				tbl.rows = append(tbl.rows, []string{current, name, profile.BaseURL, profile.APIKey})
+			}
+
+			return a.render(cmd, cfg, tbl)
+		},
+	}
+}
+
+func newConfigSetProfileCmd(a *app) *cobra.Command {
+	var profile Profile
+
+	cmd := &cobra.Command{
+		Use:   "set-profile NAME",
+		Short: "Create or update a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(a.configPath)
+			if err != nil {
+				return err
+			}
+
+			existing := cfg.Profiles[args[0]]
+			if cmd.Flags().Changed("base-url") {
+				existing.BaseURL = profile.BaseURL
+			}
+
+			if cmd.Flags().Changed("api-key") {
+				existing.APIKey = profile.APIKey
+			}
+
+			cfg.Profiles[args[0]] = existing
+
+			if cfg.CurrentProfile == "" {
+				cfg.CurrentProfile = args[0]
+			}
+
+			if err := cfg.save(a.configPath); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "profile %q saved to %s\n", args[0], a.configPath)
+
+			return nil
+		},
+	}
+
+	// Local flags shadow the global --base-url and --api-key overrides.
+	cmd.Flags().StringVar(&profile.BaseURL, "base-url", "", "wallet service URL, e.g. "+defaultBaseURL)
+	cmd.Flags().StringVar(&profile.APIKey, "api-key", "", "API key sent with every request")
+
+	return cmd
+}
+
+func newConfigUseProfileCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "use-profile NAME",
+		Short:             "Select the profile used by default",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(a.configPath)
+			if err != nil {
+				return err
+			}
+
+			if _, found := cfg.Profiles[args[0]]; !found {
+				return fmt.Errorf("unknown profile %q, create it with set-profile first", args[0])
+			}
+
+			cfg.CurrentProfile = args[0]
+
+			if err := cfg.save(a.configPath); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "switched to profile %q\n", args[0])
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+const (
+	exportCSV   = "csv"
+	exportJSONL = "jsonl"
+)
+
+var exportFormats = []string{exportCSV, exportJSONL}
+
+func newExportCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export wallets or transactions as CSV or JSON lines",
+		Long: "Export every matching wallet or transaction, following cursors page by page. " +
+			"Rows are written as they arrive, so large exports do not have to fit in memory.",
+	}
+
+	cmd.AddCommand(newExportWalletsCmd(a), newExportTransactionsCmd(a))
+
+	return cmd
+}
+
+// exportOptions are the flags shared by the export commands.
+type exportOptions struct {
+	format string
+	file   string
+}
+
+func (o *exportOptions) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.format, "format", exportCSV, "csv or jsonl")
+	cmd.Flags().StringVarP(&o.file, "file", "f", "", "file to write to instead of stdout")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", fixedCompletions(exportFormats...))
+}
+
+func newExportWalletsCmd(a *app) *cobra.Command {
+	var (
+		opts  exportOptions
+		query wallet.ListWalletsRequest
+	)
+
+	cmd := &cobra.Command{
+		Use:   "wallets",
+		Short: "Export wallets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			return export(cmd, opts, cl.AllWallets(cmd.Context(), query), walletsTable)
+		},
+	}
+
+	opts.register(cmd)
+	cmd.Flags().StringSliceVar(&query.OwnerIDs, "owner-id", nil, "only wallets of these owners")
+
+	return cmd
+}
+
+func newExportTransactionsCmd(a *app) *cobra.Command {
+	var (
+		opts    exportOptions
+		filters transactionFilters
+	)
+
+	cmd := &cobra.Command{
+		Use:   "transactions",
+		Short: "Export transactions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			return export(cmd, opts, cl.AllTransactions(cmd.Context(), filters.query()), transactionsTable)
+		},
+	}
+
+	opts.register(cmd)
+	filters.register(cmd)
+
+	return cmd
+}
+
+// export writes every item of items, as CSV rows built by toTable or as one JSON object per line.
+func export[T any](cmd *cobra.Command, opts exportOptions, items iter.Seq2[T, error], toTable func(...T) table) error {
+	if !slices.Contains(exportFormats, opts.format) {
+		return fmt.Errorf("invalid export format %q, expected one of %v", opts.format, exportFormats)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if opts.file != "" {
+		file, err := os.Create(opts.file)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	write, flush := exportWriter(out, opts.format, toTable)
+
+	count := 0
+
+	for item, err := range items {
+		if err != nil {
+			return fmt.Errorf("export stopped after %d rows: %w", count, err)
+		}
+
+		if err := write(item); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+
+		count++
+	}
+
+	if err := flush(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if opts.file != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "exported %d rows to %s\n", count, opts.file)
+	}
+
+	return nil
+}
+
+func exportWriter[T any](out io.Writer, format string, toTable func(...T) table) (func(T) error, func() error) {
+	if format == exportJSONL {
+		encoder := json.NewEncoder(out)
+
+		return func(item T) error { return encoder.Encode(item) }, func() error { return nil }
+	}
+
+	writer := csv.NewWriter(out)
+	headerWritten := false
+
+	write := func(item T) error {
+		tbl := toTable(item)
+
+		if !headerWritten {
+			headerWritten = true
+
+			if err := writer.Write(tbl.header); err != nil {
+				return err
+			}
+		}
+
+		return writer.WriteAll(tbl.rows)
+	}
+
+	flush := func() error {
+		if !headerWritten {
+			if err := writer.Write(toTable().header); err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+
+		return writer.Error()
+	}
+
+	return write, flush
+}
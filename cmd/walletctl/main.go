@@ -0,0 +1,40 @@
+// Command walletctl is an operator CLI for the wallet service, built on the pkg/wallet client.
+//
+// Enable shell completion with e.g. `source <(walletctl completion bash)`.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := newRootCmd().ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", describe(err))
+		stop()
+		os.Exit(1)
+	}
+}
+
+// describe prefers the server's error code and field errors over the wrapped client error.
+func describe(err error) string {
+	var apiErr *wallet.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	message := fmt.Sprintf("%s (%s, HTTP %d)", apiErr.Message, apiErr.Code, apiErr.StatusCode)
+	for _, fieldErr := range apiErr.Errors {
+		message += fmt.Sprintf("\n  %s: %s", fieldErr.Source, fieldErr.Message)
+	}
+
+	return message
+}
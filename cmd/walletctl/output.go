@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var outputFormats = []string{outputTable, outputJSON}
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// render writes value as indented JSON, or tbl as an aligned table.
+func render(out io.Writer, format string, value any, tbl table) error {
+	if format == outputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(tbl.header, "\t"))
+
+	for _, row := range tbl.rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+func walletsTable(wallets ...wallet.Wallet) table {
+	tbl := table{header: []string{"ID", "OWNER", "CURRENCY", "STATUS", "BALANCE", "VERSION", "CREATED"}}
+
+	for _, w := range wallets {
+		balance := "-"
+		if w.Balance != nil {
+			balance = strconv.Itoa(*w.Balance)
+		}
+
+		tbl.rows = append(tbl.rows, []string{
+			w.ID,
+			w.OwnerID,
+			w.Currency.String(),
+			w.Status.String(),
+			balance,
+			strconv.Itoa(w.Version),
+			w.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return tbl
+}
+
+func transactionsTable(transactions ...wallet.Transaction) table {
+	tbl := table{header: []string{"ID", "WALLET", "TYPE", "AMOUNT", "STATUS", "VERSION", "CREATED", "NOTE"}}
+
+	for _, t := range transactions {
+		note := ""
+		if t.Note != nil {
+			note = *t.Note
+		}
+
+		tbl.rows = append(tbl.rows, []string{
+			t.ID,
+			t.WalletID,
+			t.Type.String(),
+			strconv.Itoa(t.Amount),
+			t.Status.String(),
+			strconv.Itoa(t.Version),
+			t.CreatedAt.Format(time.RFC3339),
+			note,
+		})
+	}
+
+	return tbl
+}
+
+// printNextCursor tells table readers how to fetch the next page. JSON output already carries the cursor.
+func (a *app) printNextCursor(cmd *cobra.Command, metadata wallet.Metadata) error {
+	if a.output != outputTable || metadata.Pagination.NextCursor == nil {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(cmd.ErrOrStderr(), "\nmore results: --after %s\n", *metadata.Pagination.NextCursor)
+
+	return err
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+// app holds the global flags shared by every command.
+type app struct {
+	configPath string
+	profile    string
+	baseURL    string
+	apiKey     string
+	output     string
+	timeout    time.Duration
+}
+
+func newRootCmd() *cobra.Command {
+	a := &app{}
+
+	cmd := &cobra.Command{
+		Use:           "walletctl",
+		Short:         "Manage wallets and transactions of the wallet service",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			if !slices.Contains(outputFormats, a.output) {
+				return fmt.Errorf("invalid output format %q, expected one of %v", a.output, outputFormats)
+			}
+
+			return nil
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&a.configPath, "config", defaultConfigPath(), "path of the config file")
+	flags.StringVarP(&a.profile, "profile", "p", "", "config profile to use instead of the current one")
+	flags.StringVar(&a.baseURL, "base-url", "", "wallet service URL, overrides the profile")
+	flags.StringVar(&a.apiKey, "api-key", "", "API key, overrides the profile")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "output format: table or json")
+	flags.DurationVar(&a.timeout, "timeout", 30*time.Second, "timeout of each HTTP request")
+
+	_ = cmd.RegisterFlagCompletionFunc("output", fixedCompletions(outputFormats...))
+	_ = cmd.RegisterFlagCompletionFunc("profile", a.completeProfiles)
+
+	cmd.AddCommand(
+		newWalletsCmd(a),
+		newTransactionsCmd(a),
+		newExportCmd(a),
+		newConfigCmd(a),
+	)
+
+	return cmd
+}
+
+// client builds a wallet client from the selected profile and flag overrides.
+func (a *app) client() (*wallet.Client, error) {
+	cfg, err := loadConfig(a.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := cfg.profile(a.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.baseURL != "" {
+		profile.BaseURL = a.baseURL
+	}
+
+	if a.apiKey != "" {
+		profile.APIKey = a.apiKey
+	}
+
+	opts := []wallet.Option{
+		wallet.WithTimeout(a.timeout),
+		wallet.WithRetry(2, 200*time.Millisecond, 2*time.Second),
+	}
+
+	if profile.APIKey != "" {
+		opts = append(opts, wallet.WithAPIKey(profile.APIKey))
+	}
+
+	return wallet.NewClient(profile.BaseURL, opts...), nil
+}
+
+func (a *app) render(cmd *cobra.Command, value any, tbl table) error {
+	return render(cmd.OutOrStdout(), a.output, value, tbl)
+}
+
+func (a *app) completeProfiles(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := loadConfig(a.configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return cfg.profileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func fixedCompletions[T ~string](values ...T) cobra.CompletionFunc {
+	completions := make([]string, 0, len(values))
+	for _, value := range values {
+		completions = append(completions, string(value))
+	}
+
+	return cobra.FixedCompletions(completions, cobra.ShellCompDirectiveNoFileComp)
+}
+
+// completeArg completes the positional argument at index with values.
+func completeArg[T ~string](index int, values ...T) cobra.CompletionFunc {
+	complete := fixedCompletions(values...)
+
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != index {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return complete(cmd, args, toComplete)
+	}
+}
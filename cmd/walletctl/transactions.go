@@ -0,0 +1,240 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+func newTransactionsCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "transactions",
+		Aliases: []string{"transaction", "tx"},
+		Short:   "Manage transactions",
+	}
+
+	cmd.AddCommand(
+		newTransactionsListCmd(a),
+		newTransactionsGetCmd(a),
+		newTransactionsCreateCmd(a),
+		newTransactionsStatusCmd(a),
+	)
+
+	return cmd
+}
+
+// transactionFilters are the listing filters shared by the list and export commands.
+type transactionFilters struct {
+	walletIDs []string
+	statuses  []string
+	types     []string
+	note      string
+	sort      string
+}
+
+func (f *transactionFilters) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringSliceVar(&f.walletIDs, "wallet-id", nil, "only transactions of these wallets")
+	flags.StringSliceVar(&f.statuses, "status", nil, "only transactions with these statuses")
+	flags.StringSliceVar(&f.types, "type", nil, "only transactions of these types")
+	flags.StringVar(&f.note, "note", "", "only transactions whose note contains this text")
+	flags.StringVar(&f.sort, "sort", "", "order by amount, created_at or updated_at, prefix with - for descending")
+
+	_ = cmd.RegisterFlagCompletionFunc("status", fixedCompletions(types.GetTransactionStatuses()...))
+	_ = cmd.RegisterFlagCompletionFunc("type", fixedCompletions(types.GetTransactionTypes()...))
+	_ = cmd.RegisterFlagCompletionFunc("sort", fixedCompletions(types.GetTransactionSorts()...))
+}
+
+func (f *transactionFilters) query() wallet.ListTransactionsRequest {
+	query := wallet.ListTransactionsRequest{WalletIDs: f.walletIDs}
+
+	for _, status := range f.statuses {
+		query.Statuses = append(query.Statuses, types.TransactionStatus(status))
+	}
+
+	for _, transactionType := range f.types {
+		query.Types = append(query.Types, types.TransactionType(transactionType))
+	}
+
+	if f.note != "" {
+		query.Note = &f.note
+	}
+
+	if f.sort != "" {
+		sort := types.TransactionSort(f.sort)
+		query.Sort = &sort
+	}
+
+	return query
+}
+
+func newTransactionsListCmd(a *app) *cobra.Command {
+	var (
+		filters transactionFilters
+		limit   int
+		after   string
+		all     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List transactions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			query := filters.query()
+			query.Limit = &limit
+
+			if after != "" {
+				query.After = &after
+			}
+
+			if all {
+				var transactions []wallet.Transaction
+
+				for transaction, err := range cl.AllTransactions(cmd.Context(), query) {
+					if err != nil {
+						return err
+					}
+
+					transactions = append(transactions, transaction)
+				}
+
+				return a.render(cmd, transactions, transactionsTable(transactions...))
+			}
+
+			res, err := cl.GetTransactions(cmd.Context(), query)
+			if err != nil {
+				return err
+			}
+
+			if err := a.render(cmd, res, transactionsTable(res.Transactions...)); err != nil {
+				return err
+			}
+
+			return a.printNextCursor(cmd, res.Metadata)
+		},
+	}
+
+	filters.register(cmd)
+
+	flags := cmd.Flags()
+	flags.IntVar(&limit, "limit", 20, "transactions per page, at most 100")
+	flags.StringVar(&after, "after", "", "cursor to continue listing from")
+	flags.BoolVar(&all, "all", false, "follow cursors and list every matching transaction")
+
+	return cmd
+}
+
+func newTransactionsGetCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			res, err := cl.GetTransactionByID(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.render(cmd, res.Transaction, transactionsTable(res.Transaction))
+		},
+	}
+}
+
+func newTransactionsCreateCmd(a *app) *cobra.Command {
+	var (
+		req             wallet.CreateTransactionRequest
+		transactionType string
+		note            string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a pending credit or debit transaction",
+		Long: "Create a pending credit or debit transaction. Without --idempotency-key a new key is generated, " +
+			"so repeat the key explicitly when retrying a create whose outcome is unknown.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			req.Type = types.TransactionType(transactionType)
+			if note != "" {
+				req.Note = &note
+			}
+
+			if req.IdempotencyKey == "" {
+				req.IdempotencyKey = ulid.GenerateID(time.Now())
+			}
+
+			res, err := cl.CreateTransaction(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return a.render(cmd, res.Transaction, transactionsTable(res.Transaction))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&req.WalletID, "wallet-id", "", "wallet to credit or debit")
+	flags.IntVar(&req.Amount, "amount", 0, "amount in minor units")
+	flags.StringVar(&transactionType, "type", "", "credit or debit")
+	flags.StringVar(&note, "note", "", "free-text note")
+	flags.StringVar(&req.IdempotencyKey, "idempotency-key", "", "key deduplicating retried creates")
+
+	_ = cmd.MarkFlagRequired("wallet-id")
+	_ = cmd.MarkFlagRequired("amount")
+	_ = cmd.MarkFlagRequired("type")
+	_ = cmd.RegisterFlagCompletionFunc("type", fixedCompletions(types.GetTransactionTypes()...))
+
+	return cmd
+}
+
+func newTransactionsStatusCmd(a *app) *cobra.Command {
+	var ifMatch int
+
+	cmd := &cobra.Command{
+		Use:               "status ID STATUS",
+		Short:             "Complete or fail a pending transaction",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArg(1, types.GetTransactionStatuses()...),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			req := wallet.UpdateTransactionStatusRequest{Status: types.TransactionStatus(args[1])}
+			if cmd.Flags().Changed("if-match") {
+				req.Version = &ifMatch
+			}
+
+			res, err := cl.UpdateTransactionStatus(cmd.Context(), args[0], req)
+			if err != nil {
+				return err
+			}
+
+			return a.render(cmd, res.Transaction, transactionsTable(res.Transaction))
+		},
+	}
+
+	cmd.Flags().IntVar(&ifMatch, "if-match", 0, "only update if the transaction is still at this version")
+
+	return cmd
+}
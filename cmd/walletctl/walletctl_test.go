@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet/wallettest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletctl(t *testing.T) {
+	server := wallettest.NewServer(t)
+	seeded := server.SeedWallet(wallettest.SeedWallet{OwnerID: "owner-1", Balance: 300})
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+
+		var out bytes.Buffer
+
+		cmd := newRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"--config", configPath}, args...))
+
+		err := cmd.ExecuteContext(context.Background())
+
+		return out.String(), err
+	}
+
+	_, err := run(t, "config", "set-profile", "fake", "--base-url", server.BaseURL(), "--api-key", "secret")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		args             []string
+		expectedErr      string
+		expectedContains []string
+		expectedMissing  []string
+	}{
+		{
+			name:             "wallet balance as table",
+			args:             []string{"wallets", "balance", seeded.ID},
+			expectedContains: []string{"BALANCE", seeded.ID, "owner-1", "300"},
+		},
+		{
+			name:             "wallet as json",
+			args:             []string{"wallets", "get", seeded.ID, "-o", "json"},
+			expectedContains: []string{`"id": "` + seeded.ID + `"`, `"status": "active"`},
+		},
+		{
+			name:             "freeze wallet",
+			args:             []string{"wallets", "status", seeded.ID, "frozen", "--if-match", "1"},
+			expectedContains: []string{"frozen"},
+		},
+		{
+			name:        "stale version",
+			args:        []string{"wallets", "status", seeded.ID, "active", "--if-match", "1"},
+			expectedErr: "version_conflict",
+		},
+		{
+			name:             "export transactions as csv",
+			args:             []string{"export", "transactions", "--wallet-id", seeded.ID},
+			expectedContains: []string{"ID,WALLET,TYPE,AMOUNT", seeded.ID + ",credit,300,completed"},
+		},
+		{
+			name:             "config hides api keys",
+			args:             []string{"config", "view"},
+			expectedContains: []string{"fake", server.BaseURL(), "********"},
+			expectedMissing:  []string{"secret"},
+		},
+		{
+			name:        "unknown profile",
+			args:        []string{"wallets", "list", "--profile", "prod"},
+			expectedErr: `unknown profile "prod"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := run(t, tt.args...)
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, describe(err), tt.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, out, expected)
+			}
+
+			for _, missing := range tt.expectedMissing {
+				assert.NotContains(t, out, missing)
+			}
+		})
+	}
+
+	t.Run("create transaction", func(t *testing.T) {
+		_, err := run(t, "wallets", "status", seeded.ID, "active")
+		require.NoError(t, err)
+
+		out, err := run(t, "transactions", "create", "--wallet-id", seeded.ID, "--amount", "120", "--type", "debit",
+			"-o", "json")
+		require.NoError(t, err)
+
+		var created wallet.Transaction
+		require.NoError(t, json.NewDecoder(strings.NewReader(out)).Decode(&created))
+		assert.Equal(t, 120, created.Amount)
+		assert.Equal(t, 180, server.Balance(seeded.ID))
+	})
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/spf13/cobra"
+)
+
+func newWalletsCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "wallets",
+		Aliases: []string{"wallet", "w"},
+		Short:   "Manage wallets",
+	}
+
+	cmd.AddCommand(
+		newWalletsListCmd(a),
+		newWalletsGetCmd(a),
+		newWalletsCreateCmd(a),
+		newWalletsStatusCmd(a),
+		newWalletsBalanceCmd(a),
+	)
+
+	return cmd
+}
+
+func newWalletsListCmd(a *app) *cobra.Command {
+	var (
+		query      wallet.ListWalletsRequest
+		currencies []string
+		limit      int
+		after      string
+		all        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List wallets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			for _, currency := range currencies {
+				query.Currencies = append(query.Currencies, types.Currency(currency))
+			}
+
+			query.Limit = &limit
+			if after != "" {
+				query.After = &after
+			}
+
+			if all {
+				var wallets []wallet.Wallet
+
+				for w, err := range cl.AllWallets(cmd.Context(), query) {
+					if err != nil {
+						return err
+					}
+
+					wallets = append(wallets, w)
+				}
+
+				return a.render(cmd, wallets, walletsTable(wallets...))
+			}
+
+			res, err := cl.ListWallets(cmd.Context(), query)
+			if err != nil {
+				return err
+			}
+
+			if err := a.render(cmd, res, walletsTable(res.Wallets...)); err != nil {
+				return err
+			}
+
+			return a.printNextCursor(cmd, res.Metadata)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&query.IDs, "id", nil, "only wallets with these IDs")
+	flags.StringSliceVar(&query.OwnerIDs, "owner-id", nil, "only wallets of these owners")
+	flags.StringSliceVar(&currencies, "currency", nil, "only wallets in these currencies")
+	flags.StringSliceVar(&query.Products, "product", nil, "only wallets of these products")
+	flags.IntVar(&limit, "limit", 20, "wallets per page, at most 100")
+	flags.StringVar(&after, "after", "", "cursor to continue listing from")
+	flags.BoolVar(&all, "all", false, "follow cursors and list every matching wallet")
+
+	_ = cmd.RegisterFlagCompletionFunc("currency", fixedCompletions(types.GetCurrencies()...))
+
+	return cmd
+}
+
+func newWalletsGetCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a wallet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			res, err := cl.GetWalletByID(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.render(cmd, res.Wallet, walletsTable(res.Wallet))
+		},
+	}
+}
+
+func newWalletsCreateCmd(a *app) *cobra.Command {
+	var (
+		req      wallet.CreateWalletRequest
+		currency string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a wallet",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			req.Currency = types.Currency(currency)
+
+			res, err := cl.CreateWallet(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return a.render(cmd, res.Wallet, walletsTable(res.Wallet))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&req.OwnerID, "owner-id", "", "owner of the wallet")
+	flags.StringVar(&currency, "currency", "", "currency of the wallet")
+	flags.StringVar(&req.Product, "product", "", "product of the wallet, such as savings")
+
+	_ = cmd.MarkFlagRequired("owner-id")
+	_ = cmd.MarkFlagRequired("currency")
+	_ = cmd.RegisterFlagCompletionFunc("currency", fixedCompletions(types.GetCurrencies()...))
+
+	return cmd
+}
+
+func newWalletsStatusCmd(a *app) *cobra.Command {
+	var ifMatch int
+
+	cmd := &cobra.Command{
+		Use:               "status ID STATUS",
+		Short:             "Change the status of a wallet, e.g. to freeze it",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArg(1, types.GetWalletStatuses()...),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			req := wallet.UpdateWalletStatusRequest{Status: types.WalletStatus(args[1])}
+			if cmd.Flags().Changed("if-match") {
+				req.Version = &ifMatch
+			}
+
+			res, err := cl.UpdateWalletStatus(cmd.Context(), args[0], req)
+			if err != nil {
+				return err
+			}
+
+			return a.render(cmd, res.Wallet, walletsTable(res.Wallet))
+		},
+	}
+
+	cmd.Flags().IntVar(&ifMatch, "if-match", 0, "only update if the wallet is still at this version")
+
+	return cmd
+}
+
+func newWalletsBalanceCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "balance ID",
+		Short: "Show a wallet with its balance",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			res, err := cl.GetWalletWithBalance(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return a.render(cmd, res.Wallet, walletsTable(res.Wallet))
+		},
+	}
+}
@@ -1,55 +1,321 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/interest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/locks"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/risk"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage"
+	"github.com/spf13/viper"
+)
+
+// EnvConfigFile names the YAML config file when no path is given on the command line.
+const EnvConfigFile = "CONFIG_FILE"
 
 type AppConfig struct {
 	App struct {
-		Name  string
-		Debug bool
-		Port  uint16
-	}
+		Name  string `mapstructure:"name"`
+		Debug bool   `mapstructure:"debug"`
+		Port  uint16 `mapstructure:"port"`
+		// ShutdownDelay is how long readiness fails before the server stops accepting connections.
+		ShutdownDelay time.Duration `mapstructure:"shutdown_delay"`
+	} `mapstructure:"app"`
+
+	HTTP struct {
+		ReadTimeout       time.Duration `mapstructure:"read_timeout"`
+		ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+		WriteTimeout      time.Duration `mapstructure:"write_timeout"`
+		IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+		// ShutdownTimeout bounds how long in-flight requests may take to complete on shutdown.
+		ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	} `mapstructure:"http"`
+
+	GRPC struct {
+		Port uint16 `mapstructure:"port"`
+	} `mapstructure:"grpc"`
+
+	Log struct {
+		Level string `mapstructure:"level"`
+	} `mapstructure:"log"`
+
+	Tracing struct {
+		Exporter    string  `mapstructure:"exporter"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+	} `mapstructure:"tracing"`
+
+	Streams struct {
+		HeartbeatInterval       time.Duration `mapstructure:"heartbeat_interval"`
+		MaxConnections          int           `mapstructure:"max_connections"`
+		MaxConnectionsPerWallet int           `mapstructure:"max_connections_per_wallet"`
+	} `mapstructure:"streams"`
+
+	Storage struct {
+		// Backend is database or memory; postgres is accepted for database. The database backend stores data
+		// in the database of database.driver, needing Redis with Postgres only. The memory backend needs
+		// neither a database nor Redis, and locks within the process whatever locks.backend says.
+		Backend string `mapstructure:"backend"`
+		// SnapshotFile persists the memory backend. It is loaded on startup and written on shutdown and
+		// every SnapshotInterval. Empty keeps the data in memory only.
+		SnapshotFile     string        `mapstructure:"snapshot_file"`
+		SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
+	} `mapstructure:"storage"`
 
 	Database struct {
-		DSN string
-	}
+		// Driver is postgres or sqlite. SQLite takes a file DSN such as file:wallet.db and is used through
+		// a single connection, so the pool settings do not apply to it.
+		Driver          string        `mapstructure:"driver"`
+		DSN             string        `mapstructure:"dsn" secret:"true"`
+		MaxOpenConns    int           `mapstructure:"max_open_conns"`
+		MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+		ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+		// AutoMigrate applies pending migrations on startup.
+		AutoMigrate bool `mapstructure:"auto_migrate"`
+	} `mapstructure:"database"`
 
 	Redis struct {
-		URL string
+		URL            string        `mapstructure:"url" secret:"true"`
+		BalanceTTL     time.Duration `mapstructure:"balance_ttl"`
+		IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+	} `mapstructure:"redis"`
+
+	Approvals struct {
+		// Threshold is the amount, in minor units, above which transactions await approval. Zero only
+		// holds adjustments.
+		Threshold int `mapstructure:"threshold"`
+		// Approvers are the actor IDs, as sent in X-Actor-ID, allowed to approve and reject transactions.
+		Approvers []string `mapstructure:"approvers"`
+	} `mapstructure:"approvals"`
+
+	Fees struct {
+		// IncomeWallets maps each currency to the ID of the wallet its fees are credited to.
+		IncomeWallets map[string]string `mapstructure:"income_wallets"`
+		// Rules are evaluated on every transaction; each matching rule charges its own fee. They can only
+		// be set in the config file.
+		Rules []fees.Rule `mapstructure:"rules"`
+	} `mapstructure:"fees"`
+
+	Buckets struct {
+		// Priority is the order debits spend the balance buckets in. Buckets left out are spent last, and
+		// an empty list spends promotional credit first.
+		Priority []string `mapstructure:"priority"`
+		// PromoTTL is how long promotional credits created without an expiry last. Zero keeps them forever.
+		PromoTTL time.Duration `mapstructure:"promo_ttl"`
+		// ExpiryInterval is how often expired credits are written off.
+		ExpiryInterval time.Duration `mapstructure:"expiry_interval"`
+	} `mapstructure:"buckets"`
+
+	Interest struct {
+		// Rates are the annual interest rates wallets earn by currency and product. They can only be set
+		// in the config file.
+		Rates []interest.Rate `mapstructure:"rates"`
+		// RunInterval is how often the last day over is accrued and the last month over is paid out.
+		RunInterval time.Duration `mapstructure:"run_interval"`
+	} `mapstructure:"interest"`
+
+	Disputes struct {
+		// ResponseWindow is how long disputes opened without a deadline stay due.
+		ResponseWindow time.Duration `mapstructure:"response_window"`
+	} `mapstructure:"disputes"`
+
+	// Risk sets the rules run before every transaction is created. Every rule is off by default.
+	Risk risk.Policy `mapstructure:"risk"`
+
+	Locks struct {
+		// Backend is redis, postgres or memory. Left empty it is redis when redis.url is set and memory
+		// otherwise, so a single SQLite instance runs without Redis.
+		Backend string `mapstructure:"backend"`
+		// Wait bounds how long a lock is waited for by callers without a deadline of their own.
+		Wait time.Duration `mapstructure:"wait"`
+		// TTL applies to the redis backend. RetryDelay is how often the redis and postgres backends try a
+		// lock held by another instance again.
+		TTL        time.Duration `mapstructure:"ttl"`
+		RetryDelay time.Duration `mapstructure:"retry_delay"`
+	} `mapstructure:"locks"`
+}
+
+// FeeSchedule returns the fee rules along with the wallets collecting the fees.
+func (c *AppConfig) FeeSchedule() fees.Schedule {
+	return fees.NewSchedule(c.Fees.Rules, c.Fees.IncomeWallets)
+}
+
+// InterestSchedule returns the interest rates wallets earn.
+func (c *AppConfig) InterestSchedule() interest.Schedule {
+	return interest.NewSchedule(c.Interest.Rates)
+}
+
+// StorageBackend returns the backend data is stored in, resolving the postgres alias of the database one.
+func (c *AppConfig) StorageBackend() storage.Backend {
+	return storage.Backend(c.Storage.Backend).Resolve()
+}
+
+// LockBackend returns the lock backend wallets are locked with, resolving an empty locks.backend.
+func (c *AppConfig) LockBackend() locks.Backend {
+	if c.Locks.Backend != "" {
+		return locks.Backend(c.Locks.Backend)
+	}
+
+	if c.Redis.URL != "" {
+		return locks.BackendRedis
 	}
+
+	return locks.BackendMemory
 }
 
-var cfg *AppConfig
+// defaults lists every setting, so each one can also be set through its environment variable.
+var defaults = map[string]any{
+	"app.name":           "wallet-service",
+	"app.debug":          false,
+	"app.port":           8080,
+	"app.shutdown_delay": 5 * time.Second,
+
+	"http.read_timeout":        15 * time.Second,
+	"http.read_header_timeout": 5 * time.Second,
+	"http.write_timeout":       15 * time.Second,
+	"http.idle_timeout":        60 * time.Second,
+	"http.shutdown_timeout":    30 * time.Second,
+
+	"grpc.port": 9090,
+
+	"log.level": "info",
+
+	"tracing.exporter":     "none",
+	"tracing.sample_ratio": 1,
 
-func Config() *AppConfig {
-	if cfg == nil {
-		loadConfig()
+	"streams.heartbeat_interval":         15 * time.Second,
+	"streams.max_connections":            1000,
+	"streams.max_connections_per_wallet": 5,
+
+	"storage.backend":           "database",
+	"storage.snapshot_file":     "",
+	"storage.snapshot_interval": time.Minute,
+
+	"database.driver":            "postgres",
+	"database.dsn":               "",
+	"database.max_open_conns":    100,
+	"database.max_idle_conns":    10,
+	"database.conn_max_lifetime": time.Hour,
+	"database.auto_migrate":      false,
+
+	"redis.url":             "",
+	"redis.balance_ttl":     24 * time.Hour,
+	"redis.idempotency_ttl": 24 * time.Hour,
+
+	"approvals.threshold": 0,
+	"approvals.approvers": []string{},
+
+	"fees.income_wallets": map[string]string{},
+	"fees.rules":          []fees.Rule{},
+
+	"buckets.priority":        []string{"promo", "cash"},
+	"buckets.promo_ttl":       30 * 24 * time.Hour,
+	"buckets.expiry_interval": 5 * time.Minute,
+
+	"interest.rates":        []interest.Rate{},
+	"interest.run_interval": time.Hour,
+
+	"disputes.response_window": 30 * 24 * time.Hour,
+
+	"risk.amount_threshold.amount":   0,
+	"risk.amount_threshold.decision": "review",
+	"risk.velocity.limit":            0,
+	"risk.velocity.window":           time.Minute,
+	"risk.velocity.decision":         "deny",
+	"risk.new_wallet.cooling_period": 0,
+	"risk.new_wallet.amount":         0,
+	"risk.new_wallet.decision":       "review",
+	"risk.blocklist.file":            "",
+	"risk.blocklist.decision":        "deny",
+
+	"locks.backend":     "",
+	"locks.wait":        10 * time.Second,
+	"locks.ttl":         15 * time.Second,
+	"locks.retry_delay": 100 * time.Millisecond,
+}
+
+// Load reads the configuration and validates it.
+func Load(path string) (*AppConfig, error) {
+	cfg, err := Read(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return cfg
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
-func loadConfig() {
-	readEnvVariables()
+// Read layers, from lowest to highest precedence, the defaults, the YAML file at path (or CONFIG_FILE),
+// a .env file in the working directory and the environment. Setting app.port is overridden by APP_PORT.
+func Read(path string) (*AppConfig, error) {
+	v := viper.New()
 
-	cfg = &AppConfig{}
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
 
-	// App.
-	cfg.App.Name = viper.GetString("APP_NAME")
-	cfg.App.Debug = viper.GetBool("APP_DEBUG")
-	cfg.App.Port = viper.GetUint16("APP_PORT")
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
 
-	// Database.
-	cfg.Database.DSN = viper.GetString("DATABASE_DSN")
+	if path != "" {
+		v.SetConfigFile(path)
+		v.SetConfigType("yaml")
 
-	// Redis.
-	cfg.Redis.URL = viper.GetString("REDIS_URL")
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	if err := readDotEnv(v); err != nil {
+		return nil, err
+	}
+
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	cfg := &AppConfig{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return cfg, nil
 }
 
-func readEnvVariables() {
-	viper.SetConfigName(".env")
-	viper.SetConfigType("env")
-	viper.AddConfigPath(".")
-	viper.AutomaticEnv()
+// readDotEnv applies the non-empty variables of a .env file that are not set in the environment.
+func readDotEnv(v *viper.Viper) error {
+	dotEnv := viper.New()
+	dotEnv.SetConfigFile(".env")
+	dotEnv.SetConfigType("env")
+
+	if err := dotEnv.ReadInConfig(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read .env: %w", err)
+	}
+
+	for _, key := range v.AllKeys() {
+		name := envName(key)
+		value := dotEnv.GetString(name)
+		if _, ok := os.LookupEnv(name); ok || value == "" {
+			continue
+		}
+
+		v.Set(key, value)
+	}
+
+	return nil
+}
 
-	_ = viper.ReadInConfig()
+func envName(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
 }
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/locks"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	path := writeFile(t, `
+app:
+  port: 8000
+database:
+  dsn: postgres://wallet:secret@db:5432/wallet
+  max_open_conns: 20
+redis:
+  url: redis://redis:6379
+locks:
+  ttl: 5s
+fees:
+  income_wallets:
+    USD: wallet-fees-usd
+  rules:
+    - name: withdrawal
+      currency: USD
+      type: debit
+      kind: percentage
+      basis_points: 150
+      min: 50
+interest:
+  rates:
+    - currency: usd
+      product: savings
+      annual_basis_points: 350
+`)
+
+	t.Setenv("DATABASE_MAX_OPEN_CONNS", "50")
+	t.Setenv("REDIS_BALANCE_TTL", "1h")
+	t.Setenv("APPROVALS_APPROVERS", "alice,bob")
+	t.Setenv("BUCKETS_PRIORITY", "cash,promo")
+	t.Setenv("INTEREST_RUN_INTERVAL", "30m")
+	t.Setenv("DISPUTES_RESPONSE_WINDOW", "336h")
+	t.Setenv("RISK_VELOCITY_LIMIT", "5")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(8000), cfg.App.Port, "file overrides the default")
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns, "environment overrides the file")
+	assert.Equal(t, 5*time.Second, cfg.Locks.TTL)
+	assert.Equal(t, time.Hour, cfg.Redis.BalanceTTL)
+	assert.Equal(t, 100*time.Millisecond, cfg.Locks.RetryDelay, "defaults fill the rest")
+	assert.Equal(t, locks.BackendRedis, cfg.LockBackend(), "locks default to redis once it is configured")
+	assert.Equal(t, 15*time.Second, cfg.HTTP.ReadTimeout)
+	assert.Equal(t, []string{"alice", "bob"}, cfg.Approvals.Approvers, "lists are comma-separated in the environment")
+	assert.Equal(t, []string{"cash", "promo"}, cfg.Buckets.Priority)
+	assert.Equal(t, 30*24*time.Hour, cfg.Buckets.PromoTTL)
+
+	schedule := cfg.FeeSchedule()
+	require.Len(t, schedule.Rules, 1)
+	assert.Equal(t, 150, schedule.Rules[0].BasisPoints)
+	walletID, found := schedule.IncomeWallet("USD")
+	assert.True(t, found)
+	assert.Equal(t, "wallet-fees-usd", walletID)
+
+	rate, found := cfg.InterestSchedule().Rate("USD", "savings")
+	assert.True(t, found)
+	assert.Equal(t, 350, rate)
+	assert.Equal(t, 30*time.Minute, cfg.Interest.RunInterval)
+	assert.Equal(t, 14*24*time.Hour, cfg.Disputes.ResponseWindow)
+	assert.Equal(t, 5, cfg.Risk.Velocity.Limit)
+	assert.Equal(t, time.Minute, cfg.Risk.Velocity.Window)
+	assert.Equal(t, types.RiskDecisionDeny, cfg.Risk.Velocity.Decision)
+	assert.Equal(t, types.RiskDecisionReview, cfg.Risk.AmountThreshold.Decision)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	path := writeFile(t, `
+grpc:
+  port: 8080
+log:
+  level: loud
+database:
+  driver: mysql
+  max_open_conns: 5
+  max_idle_conns: 10
+redis:
+  url: localhost:6379
+locks:
+  backend: zookeeper
+  ttl: -1s
+storage:
+  backend: s3
+approvals:
+  threshold: -1
+fees:
+  rules:
+    - name: transfer
+      kind: fixed
+      fixed: 10
+buckets:
+  priority: [promo, gift, promo]
+interest:
+  rates:
+    - currency: USD
+      annual_basis_points: 20000
+  run_interval: 0s
+disputes:
+  response_window: -24h
+risk:
+  velocity:
+    limit: 3
+    window: 0s
+  blocklist:
+    decision: allow
+`)
+
+	_, err := Load(path)
+	require.Error(t, err)
+
+	for _, problem := range []string{
+		"grpc.port (GRPC_PORT): must differ from app.port",
+		`log.level (LOG_LEVEL): must be one of debug, info, warn or error, got "loud"`,
+		`database.driver (DATABASE_DRIVER): must be one of [postgres sqlite], got "mysql"`,
+		"database.dsn (DATABASE_DSN): is required",
+		"database.max_idle_conns (DATABASE_MAX_IDLE_CONNS): must be between 0 and database.max_open_conns",
+		"redis.url (REDIS_URL): must be a redis:// or rediss:// URL",
+		`locks.backend (LOCKS_BACKEND): must be one of [redis postgres memory], got "zookeeper"`,
+		"locks.ttl (LOCKS_TTL): must be a positive duration, got -1s",
+		`storage.backend (STORAGE_BACKEND): must be one of [database memory], got "s3"`,
+		"approvals.threshold (APPROVALS_THRESHOLD): must not be negative",
+		"fees.rules (FEES_RULES): rule 0: needs an income wallet for USD",
+		`buckets.priority (BUCKETS_PRIORITY): must only list buckets of [cash promo], got "gift"`,
+		`buckets.priority (BUCKETS_PRIORITY): lists "promo" twice`,
+		"interest.rates (INTEREST_RATES): rate 0: annual_basis_points must be between 0 and 10000",
+		"interest.run_interval (INTEREST_RUN_INTERVAL): must be a positive duration, got 0s",
+		"disputes.response_window (DISPUTES_RESPONSE_WINDOW): must be a positive duration, got -24h0m0s",
+		"risk (RISK): velocity: window must be a positive duration",
+		`blocklist: decision must be review or deny, got "allow"`,
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+}
+
+func TestLoad_MemoryStorageNeedsNoExternalServices(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	path := writeFile(t, `
+storage:
+  backend: memory
+  snapshot_file: wallet.json
+`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "wallet.json", cfg.Storage.SnapshotFile)
+	assert.Equal(t, time.Minute, cfg.Storage.SnapshotInterval)
+}
+
+func TestLoad_SQLiteNeedsNoRedis(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	path := writeFile(t, `
+database:
+  driver: sqlite
+  dsn: file:wallet.db
+`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, storage.BackendDatabase, cfg.StorageBackend())
+	assert.Equal(t, locks.BackendMemory, cfg.LockBackend(), "locks default to the process without redis")
+
+	t.Setenv("STORAGE_BACKEND", "postgres")
+
+	cfg, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, storage.BackendDatabase, cfg.StorageBackend(), "postgres still names the database backend")
+
+	t.Setenv("LOCKS_BACKEND", "redis")
+
+	_, err = Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "locks.backend (LOCKS_BACKEND): redis locks need redis.url")
+	assert.NotContains(t, err.Error(), "redis.url (REDIS_URL): is required")
+}
+
+func TestLoad_PostgresNeedsRedis(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	path := writeFile(t, `
+database:
+  dsn: postgres://wallet:secret@db:5432/wallet
+`)
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "redis.url (REDIS_URL): is required")
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      string
+		expected string
+	}{
+		{
+			name:     "URL",
+			dsn:      "postgres://wallet:secret@db:5432/wallet?sslmode=disable",
+			expected: "postgres://wallet:REDACTED@db:5432/wallet?sslmode=disable",
+		},
+		{
+			name:     "key/value",
+			dsn:      "host=db user=wallet password=secret dbname=wallet",
+			expected: "host=db user=wallet password=REDACTED dbname=wallet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &AppConfig{}
+			cfg.Database.DSN = tt.dsn
+			cfg.Redis.URL = "redis://:hunter2@redis:6379/0"
+
+			var out bytes.Buffer
+			require.NoError(t, cfg.Print(&out))
+
+			assert.Contains(t, out.String(), "dsn: "+tt.expected)
+			assert.Contains(t, out.String(), "url: redis://:REDACTED@redis:6379/0")
+			assert.NotContains(t, out.String(), "secret")
+			assert.NotContains(t, out.String(), "hunter2")
+		})
+	}
+}
+
+func TestLoad_PostgresLocksNeedTwoConnections(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	path := writeFile(t, `
+database:
+  dsn: postgres://wallet:secret@db:5432/wallet
+  max_open_conns: 1
+  max_idle_conns: 1
+redis:
+  url: redis://redis:6379
+locks:
+  backend: postgres
+`)
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(),
+		"database.max_open_conns (DATABASE_MAX_OPEN_CONNS): must be at least 2 with postgres locks")
+
+	t.Setenv("DATABASE_MAX_OPEN_CONNS", "2")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, locks.BackendPostgres, cfg.LockBackend())
+}
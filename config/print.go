@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "REDACTED"
+
+// keyValuePassword matches the password of key/value DSNs such as "host=db password=secret".
+var keyValuePassword = regexp.MustCompile(`(password=)('[^']*'|\S+)`)
+
+// Print writes the configuration as YAML, in the layout of the config file, with the credentials of
+// secret settings redacted.
+func (c *AppConfig) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(toMap(reflect.ValueOf(*c))); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return encoder.Close()
+}
+
+func toMap(v reflect.Value) map[string]any {
+	res := make(map[string]any, v.NumField())
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		key := field.Tag.Get("mapstructure")
+		value := v.Field(i)
+
+		switch {
+		case value.Kind() == reflect.Struct:
+			res[key] = toMap(value)
+		case field.Tag.Get("secret") == "true":
+			res[key] = redact(value.String())
+		case value.Type() == reflect.TypeFor[time.Duration]():
+			res[key] = time.Duration(value.Int()).String()
+		default:
+			res[key] = value.Interface()
+		}
+	}
+
+	return res
+}
+
+// redact hides the password of a connection URL or DSN, keeping the rest to show where it points.
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	u, err := url.Parse(secret)
+	if err != nil || u.Scheme == "" {
+		return keyValuePassword.ReplaceAllString(secret, "${1}"+redacted)
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+
+	q := u.Query()
+	if q.Has("password") {
+		q.Set("password", redacted)
+		u.RawQuery = q.Encode()
+	}
+
+	return u.String()
+}
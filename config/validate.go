@@ -0,0 +1,122 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/locks"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"go.uber.org/zap/zapcore"
+)
+
+// Validate reports every invalid setting at once, named by its config key.
+func (c *AppConfig) Validate() error {
+	var problems []string
+
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s (%s): %s", key, envName(key), fmt.Sprintf(format, args...)))
+		}
+	}
+	positive := func(key string, d time.Duration) {
+		check(d > 0, key, "must be a positive duration, got %s", d)
+	}
+
+	check(c.App.Name != "", "app.name", "is required")
+	check(c.App.Port != 0, "app.port", "is required")
+	check(c.App.ShutdownDelay >= 0, "app.shutdown_delay", "must not be negative")
+
+	positive("http.read_timeout", c.HTTP.ReadTimeout)
+	positive("http.read_header_timeout", c.HTTP.ReadHeaderTimeout)
+	positive("http.write_timeout", c.HTTP.WriteTimeout)
+	positive("http.idle_timeout", c.HTTP.IdleTimeout)
+	positive("http.shutdown_timeout", c.HTTP.ShutdownTimeout)
+
+	check(c.GRPC.Port != 0, "grpc.port", "is required")
+	check(c.GRPC.Port != c.App.Port, "grpc.port", "must differ from app.port")
+
+	_, err := zapcore.ParseLevel(c.Log.Level)
+	check(err == nil, "log.level", "must be one of debug, info, warn or error, got %q", c.Log.Level)
+
+	check(slices.Contains(tracing.GetExporters(), tracing.Exporter(c.Tracing.Exporter)), "tracing.exporter",
+		"must be one of %v, got %q", tracing.GetExporters(), c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio",
+		"must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+
+	positive("streams.heartbeat_interval", c.Streams.HeartbeatInterval)
+	check(c.Streams.MaxConnections > 0, "streams.max_connections", "must be positive")
+	check(c.Streams.MaxConnectionsPerWallet > 0, "streams.max_connections_per_wallet", "must be positive")
+
+	check(slices.Contains(storage.GetBackends(), c.StorageBackend()), "storage.backend",
+		"must be one of %v, got %q", storage.GetBackends(), c.Storage.Backend)
+	positive("storage.snapshot_interval", c.Storage.SnapshotInterval)
+
+	// A database is only needed when data is not kept in memory, and Redis only along with Postgres: a
+	// SQLite deployment is a single instance, which caches and locks within the process by default.
+	external := c.StorageBackend() != storage.BackendMemory
+	needsRedis := external && dblib.Driver(c.Database.Driver) != dblib.DriverSQLite
+
+	check(slices.Contains(dblib.GetDrivers(), dblib.Driver(c.Database.Driver)), "database.driver",
+		"must be one of %v, got %q", dblib.GetDrivers(), c.Database.Driver)
+	check(c.Database.DSN != "" || !external, "database.dsn", "is required")
+	check(c.Database.MaxOpenConns > 0, "database.max_open_conns", "must be positive")
+	check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns", "must be between 0 and database.max_open_conns")
+	positive("database.conn_max_lifetime", c.Database.ConnMaxLifetime)
+
+	redisURL, err := url.Parse(c.Redis.URL)
+	check(c.Redis.URL != "" || !needsRedis, "redis.url", "is required")
+	check(c.Redis.URL == "" || (err == nil && (redisURL.Scheme == "redis" || redisURL.Scheme == "rediss")),
+		"redis.url", "must be a redis:// or rediss:// URL")
+	positive("redis.balance_ttl", c.Redis.BalanceTTL)
+	positive("redis.idempotency_ttl", c.Redis.IdempotencyTTL)
+
+	check(c.Approvals.Threshold >= 0, "approvals.threshold", "must not be negative")
+	check(!slices.Contains(c.Approvals.Approvers, ""), "approvals.approvers", "must not contain empty IDs")
+
+	feeErr := c.FeeSchedule().Validate()
+	check(feeErr == nil, "fees.rules", "%v", feeErr)
+
+	for i, bucket := range c.Buckets.Priority {
+		check(slices.Contains(types.GetBalanceBuckets(), types.BalanceBucket(bucket)), "buckets.priority",
+			"must only list buckets of %v, got %q", types.GetBalanceBuckets(), bucket)
+		check(!slices.Contains(c.Buckets.Priority[:i], bucket), "buckets.priority", "lists %q twice", bucket)
+	}
+
+	check(c.Buckets.PromoTTL >= 0, "buckets.promo_ttl", "must not be negative")
+	positive("buckets.expiry_interval", c.Buckets.ExpiryInterval)
+
+	interestErr := c.InterestSchedule().Validate()
+	check(interestErr == nil, "interest.rates", "%v", interestErr)
+	positive("interest.run_interval", c.Interest.RunInterval)
+	positive("disputes.response_window", c.Disputes.ResponseWindow)
+
+	riskErr := c.Risk.Validate()
+	check(riskErr == nil, "risk", "%v", riskErr)
+
+	check(slices.Contains(locks.GetBackends(), c.LockBackend()), "locks.backend",
+		"must be one of %v, got %q", locks.GetBackends(), c.Locks.Backend)
+	check(c.LockBackend() != locks.BackendRedis || c.Redis.URL != "" || !external, "locks.backend",
+		"redis locks need redis.url")
+	check(c.LockBackend() != locks.BackendPostgres ||
+		dblib.Driver(c.Database.Driver) == dblib.DriverPostgres, "locks.backend",
+		"postgres locks need database.driver postgres")
+	check(c.LockBackend() != locks.BackendPostgres || c.Database.MaxOpenConns >= 2, "database.max_open_conns",
+		"must be at least 2 with postgres locks, which keep one connection")
+	positive("locks.wait", c.Locks.Wait)
+	positive("locks.ttl", c.Locks.TTL)
+	positive("locks.retry_delay", c.Locks.RetryDelay)
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration:\n  " + strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
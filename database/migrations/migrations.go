@@ -0,0 +1,66 @@
+// Package migrations embeds the goose migrations, so the server binary can apply them and knows the
+// schema version it was built for.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// FS holds the migrations of each driver in a directory named after it. Both directories carry the
+// same versions, so a schema version means the same on either database.
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var FS embed.FS
+
+// NewProvider returns a goose provider of the embedded migrations of driver. Migrating Postgres holds
+// an advisory lock, so replicas migrating at the same time apply each migration once. SQLite databases
+// have a single writer and need no lock.
+func NewProvider(db *sql.DB, driver dblib.Driver) (*goose.Provider, error) {
+	dir, err := fs.Sub(FS, string(driver))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	if driver == dblib.DriverSQLite {
+		provider, err := goose.NewProvider(goose.DialectSQLite3, db, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load migrations: %w", err)
+		}
+
+		return provider, nil
+	}
+
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration lock: %w", err)
+	}
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, dir, goose.WithSessionLocker(locker))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	return provider, nil
+}
+
+// CheckVersion fails unless the database was migrated to exactly the latest embedded migration.
+func CheckVersion(ctx context.Context, provider *goose.Provider) error {
+	current, target, err := provider.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	if current != target {
+		return fmt.Errorf("database is at migration %d, expected %d", current, target)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	_ "github.com/glebarez/go-sqlite"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider_LoadsEmbeddedMigrations(t *testing.T) {
+	// Opening does not connect, which loading the migrations does not need.
+	db, err := sql.Open("pgx", "postgres://localhost/wallet")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	provider, err := NewProvider(db, dblib.DriverPostgres)
+	require.NoError(t, err)
+
+	files, err := fs.Glob(FS, "postgres/*.sql")
+	require.NoError(t, err)
+
+	sources := provider.ListSources()
+	require.Len(t, sources, len(files))
+	assert.Equal(t, int64(20250626150237), sources[0].Version)
+}
+
+func TestDrivers_ShareVersions(t *testing.T) {
+	postgres, err := fs.Glob(FS, "postgres/*.sql")
+	require.NoError(t, err)
+
+	sqlite, err := fs.Glob(FS, "sqlite/*.sql")
+	require.NoError(t, err)
+
+	require.Len(t, sqlite, len(postgres))
+
+	for i := range postgres {
+		assert.Equal(t, filepath.Base(postgres[i]), filepath.Base(sqlite[i]))
+	}
+}
+
+func TestNewProvider_MigratesSQLiteUpAndDown(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "wallet.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	provider, err := NewProvider(db, dblib.DriverSQLite)
+	require.NoError(t, err)
+
+	_, err = provider.Up(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, CheckVersion(t.Context(), provider))
+
+	_, err = provider.DownTo(t.Context(), 0)
+	require.NoError(t, err)
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_note_trgm ON transactions USING gin (note gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_amount ON transactions(wallet_id, amount);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_updated_at ON transactions(wallet_id, updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_wallet_id_updated_at;
+DROP INDEX IF EXISTS idx_transactions_wallet_id_amount;
+DROP INDEX IF EXISTS idx_transactions_note_trgm;
+-- +goose StatementEnd
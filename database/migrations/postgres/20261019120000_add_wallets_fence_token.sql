@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS fence_token BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE wallets DROP COLUMN IF EXISTS fence_token;
+-- +goose StatementEnd
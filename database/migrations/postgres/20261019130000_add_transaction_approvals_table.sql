@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE transaction_approvals (
+    id VARCHAR(26) PRIMARY KEY,
+    transaction_id VARCHAR(26) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(128) NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
+
+CREATE INDEX idx_transaction_approvals_transaction_id ON transaction_approvals(transaction_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS transaction_approvals;
+-- +goose StatementEnd
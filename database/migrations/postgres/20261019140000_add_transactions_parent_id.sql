@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS parent_id VARCHAR(26) NULL REFERENCES transactions(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_parent_id ON transactions(parent_id) WHERE parent_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_parent_id;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
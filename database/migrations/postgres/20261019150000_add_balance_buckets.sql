@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS bucket VARCHAR(20) NOT NULL DEFAULT '';
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP NULL;
+
+UPDATE transactions SET bucket = 'cash' WHERE type = 'credit';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_expires_at ON transactions(expires_at) WHERE expires_at IS NOT NULL;
+
+CREATE TABLE transaction_allocations (
+    id VARCHAR(26) PRIMARY KEY,
+    transaction_id VARCHAR(26) NOT NULL,
+    wallet_id VARCHAR(26) NOT NULL,
+    bucket VARCHAR(20) NOT NULL,
+    credit_id VARCHAR(26) NULL,
+    amount INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id),
+    FOREIGN KEY (credit_id) REFERENCES transactions(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_allocations_wallet_id ON transaction_allocations(wallet_id);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_allocations_credit_id ON transaction_allocations(credit_id)
+    WHERE credit_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS transaction_allocations;
+
+DROP INDEX IF EXISTS idx_transactions_expires_at;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS bucket;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS product VARCHAR(32) NOT NULL DEFAULT '';
+
+-- An owner may hold a wallet of each product in a currency, such as a savings wallet next to the main one.
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS unique_wallets_owner_id_currency;
+
+ALTER TABLE wallets ADD CONSTRAINT unique_wallets_owner_id_currency_product UNIQUE (owner_id, currency, product);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS unique_wallets_owner_id_currency_product;
+
+ALTER TABLE wallets ADD CONSTRAINT unique_wallets_owner_id_currency UNIQUE (owner_id, currency);
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS product;
+-- +goose StatementEnd
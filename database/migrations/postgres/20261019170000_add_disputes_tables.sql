@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE disputes (
+    id VARCHAR(26) PRIMARY KEY,
+    transaction_id VARCHAR(26) NOT NULL UNIQUE,
+    wallet_id VARCHAR(26) NOT NULL,
+    amount INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    provisional_credit_id VARCHAR(26) NULL,
+    settlement_id VARCHAR(26) NULL,
+    resolution_note TEXT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    FOREIGN KEY (provisional_credit_id) REFERENCES transactions(id),
+    FOREIGN KEY (settlement_id) REFERENCES transactions(id)
+);
+
+CREATE INDEX idx_disputes_wallet_id ON disputes(wallet_id);
+
+CREATE INDEX idx_disputes_status_due_at ON disputes(status, due_at);
+
+CREATE TABLE dispute_notes (
+    id VARCHAR(26) PRIMARY KEY,
+    dispute_id VARCHAR(26) NOT NULL,
+    actor_id VARCHAR(128) NOT NULL,
+    note TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (dispute_id) REFERENCES disputes(id)
+);
+
+CREATE INDEX idx_dispute_notes_dispute_id ON dispute_notes(dispute_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS dispute_notes;
+
+DROP TABLE IF EXISTS disputes;
+-- +goose StatementEnd
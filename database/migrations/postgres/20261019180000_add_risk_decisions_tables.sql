@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE risk_decisions (
+    id VARCHAR(26) PRIMARY KEY,
+    wallet_id VARCHAR(26) NOT NULL,
+    transaction_id VARCHAR(26) NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    amount INTEGER NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
+
+CREATE INDEX idx_risk_decisions_wallet_id ON risk_decisions(wallet_id);
+
+CREATE INDEX idx_risk_decisions_transaction_id ON risk_decisions(transaction_id);
+
+CREATE TABLE risk_rule_hits (
+    id VARCHAR(26) PRIMARY KEY,
+    decision_id VARCHAR(26) NOT NULL,
+    rule VARCHAR(64) NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    FOREIGN KEY (decision_id) REFERENCES risk_decisions(id)
+);
+
+CREATE INDEX idx_risk_rule_hits_decision_id ON risk_rule_hits(decision_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS risk_rule_hits;
+
+DROP TABLE IF EXISTS risk_decisions;
+-- +goose StatementEnd
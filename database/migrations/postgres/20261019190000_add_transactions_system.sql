@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Postings the service makes on its own account, which the velocity rule does not count. Older postings are
+-- left unmarked, as the rule only looks back over a short window.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS system BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS system;
+-- +goose StatementEnd
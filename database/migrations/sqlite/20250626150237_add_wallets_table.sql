@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wallets (
+    id VARCHAR(26) PRIMARY KEY,
+    owner_id VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    CONSTRAINT unique_wallets_owner_id_currency UNIQUE (owner_id, currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallets_owner_id ON wallets(owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS wallets;
+-- +goose StatementEnd
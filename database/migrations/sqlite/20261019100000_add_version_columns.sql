@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wallets ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE transactions ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN version;
+ALTER TABLE wallets DROP COLUMN version;
+-- +goose StatementEnd
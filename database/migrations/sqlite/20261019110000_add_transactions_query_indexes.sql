@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SQLite has no trigram indexes; note searches scan the transactions of the wallet.
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_amount ON transactions(wallet_id, amount);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_updated_at ON transactions(wallet_id, updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_wallet_id_updated_at;
+DROP INDEX IF EXISTS idx_transactions_wallet_id_amount;
+-- +goose StatementEnd
@@ -0,0 +1,72 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- +goose StatementBegin
+-- SQLite cannot drop a constraint, so the table is rebuilt. Foreign keys can only be switched off outside
+-- a transaction, and must be while the referenced table is dropped.
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE wallets_new (
+    id VARCHAR(26) PRIMARY KEY,
+    owner_id VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    fence_token BIGINT NOT NULL DEFAULT 0,
+    product VARCHAR(32) NOT NULL DEFAULT '',
+
+    -- An owner may hold a wallet of each product in a currency, such as a savings wallet next to the main one.
+    CONSTRAINT unique_wallets_owner_id_currency_product UNIQUE (owner_id, currency, product)
+);
+
+INSERT INTO wallets_new (id, owner_id, currency, status, created_at, updated_at, deleted_at, version, fence_token)
+SELECT id, owner_id, currency, status, created_at, updated_at, deleted_at, version, fence_token FROM wallets;
+
+DROP TABLE wallets;
+
+ALTER TABLE wallets_new RENAME TO wallets;
+
+CREATE INDEX IF NOT EXISTS idx_wallets_owner_id ON wallets(owner_id);
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE wallets_old (
+    id VARCHAR(26) PRIMARY KEY,
+    owner_id VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    fence_token BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT unique_wallets_owner_id_currency UNIQUE (owner_id, currency)
+);
+
+INSERT INTO wallets_old (id, owner_id, currency, status, created_at, updated_at, deleted_at, version, fence_token)
+SELECT id, owner_id, currency, status, created_at, updated_at, deleted_at, version, fence_token FROM wallets;
+
+DROP TABLE wallets;
+
+ALTER TABLE wallets_old RENAME TO wallets;
+
+CREATE INDEX IF NOT EXISTS idx_wallets_owner_id ON wallets(owner_id);
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
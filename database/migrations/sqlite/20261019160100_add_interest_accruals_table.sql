@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE interest_accruals (
+    wallet_id VARCHAR(26) NOT NULL,
+    accrued_on VARCHAR(10) NOT NULL,
+    balance INTEGER NOT NULL,
+    annual_basis_points INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    carry INTEGER NOT NULL,
+    transaction_id VARCHAR(26) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_id, accrued_on),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
+
+CREATE INDEX idx_interest_accruals_unposted ON interest_accruals(accrued_on) WHERE transaction_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS interest_accruals;
+-- +goose StatementEnd
@@ -17,9 +17,57 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/transactions": {
+        "/healthz": {
+            "get": {
+                "description": "Succeeds as long as the process is serving HTTP.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "operationId": "healthz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_controller_health.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks Postgres, Redis and the migration version. Fails while the server shuts down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "operationId": "readyz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_controller_health.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_controller_health.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/disputes": {
             "get": {
-                "description": "List transactions with pagination",
+                "description": "List disputes with filtering and pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -27,31 +75,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "disputes"
                 ],
-                "summary": "List transactions",
-                "operationId": "listTransactions",
+                "summary": "List disputes",
+                "operationId": "listDisputes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "CreatedAtFrom is the start date for filtering transactions.",
-                        "name": "created_at_from",
+                        "description": "After is an opaque cursor; only items after it are returned. Switches the listing to cursor mode.",
+                        "name": "after",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "CreatedAtTo is the end date for filtering transactions.",
-                        "name": "created_at_to",
+                        "description": "Before is an opaque cursor; only items before it are returned. Switches the listing to cursor mode.",
+                        "name": "before",
                         "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "IDs of the transactions to filter.",
-                        "name": "ids",
+                        "type": "string",
+                        "description": "DueBefore only includes disputes due before it, such as those past their deadline.",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit is the number of items per page in cursor mode, with a maximum of 100.",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
@@ -73,29 +125,26 @@ const docTemplate = `{
                         "type": "array",
                         "items": {
                             "enum": [
-                                "pending",
-                                "completed",
-                                "failed"
+                                "open",
+                                "under_review",
+                                "won",
+                                "lost"
                             ],
                             "type": "string"
                         },
                         "collectionFormat": "csv",
-                        "description": "Statuses of the transactions to filter.",
+                        "description": "Statuses of the disputes to filter.",
                         "name": "statuses",
                         "in": "query"
                     },
                     {
                         "type": "array",
                         "items": {
-                            "enum": [
-                                "credit",
-                                "debit"
-                            ],
                             "type": "string"
                         },
                         "collectionFormat": "csv",
-                        "description": "Types of the transactions to filter.",
-                        "name": "types",
+                        "description": "Transaction IDs to filter.",
+                        "name": "transaction_ids",
                         "in": "query"
                     },
                     {
@@ -113,7 +162,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionsResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputesResponse"
                         }
                     },
                     "400": {
@@ -122,12 +171,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
-                        }
-                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -139,11 +182,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
                     }
                 }
             },
             "post": {
-                "description": "Create a new transaction",
+                "description": "Open a dispute of a completed debit, optionally crediting the amount back while it is open",
                 "consumes": [
                     "application/json"
                 ],
@@ -151,18 +200,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "disputes"
                 ],
-                "summary": "Create transaction",
-                "operationId": "createTransaction",
+                "summary": "Open dispute",
+                "operationId": "openDispute",
                 "parameters": [
                     {
-                        "description": "Transaction data",
-                        "name": "transaction",
+                        "description": "Dispute data",
+                        "name": "dispute",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateTransactionRequest"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.OpenDisputeRequest"
                         }
                     }
                 ],
@@ -170,7 +219,13 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Dispute version"
+                            }
                         }
                     },
                     "400": {
@@ -185,6 +240,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -196,13 +257,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/transactions/{id}": {
+        "/v1/disputes/{id}": {
             "get": {
-                "description": "Get transaction by ID",
+                "description": "Get a dispute along with its notes",
                 "consumes": [
                     "application/json"
                 ],
@@ -210,14 +277,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "disputes"
                 ],
-                "summary": "Get transaction by ID",
-                "operationId": "getTransactionByID",
+                "summary": "Get dispute",
+                "operationId": "getDispute",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transaction ID",
+                        "description": "Dispute ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -227,7 +294,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Dispute version"
+                            }
                         }
                     },
                     "400": {
@@ -242,14 +315,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
@@ -257,9 +330,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transactions/{id}/status": {
-            "put": {
-                "description": "Update the status of a transaction",
+        "/v1/disputes/{id}/notes": {
+            "post": {
+                "description": "Attach evidence to a dispute that is not resolved yet, recorded under the X-Actor-ID caller",
                 "consumes": [
                     "application/json"
                 ],
@@ -267,33 +340,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "disputes"
                 ],
-                "summary": "Update transaction status",
-                "operationId": "updateTransactionStatus",
+                "summary": "Add dispute note",
+                "operationId": "addDisputeNote",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transaction ID",
+                        "description": "Dispute ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "New status for the transaction",
-                        "name": "status",
+                        "description": "Evidence",
+                        "name": "note",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.AddDisputeNoteRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeNoteResponse"
                         }
                     },
                     "400": {
@@ -308,8 +381,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
@@ -319,13 +392,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/wallets": {
-            "get": {
-                "description": "List wallets with pagination",
+        "/v1/disputes/{id}/status": {
+            "patch": {
+                "description": "Move an open dispute under review, or resolve one under review. Won disputes refund the amount\nunless it was provisionally credited, and lost ones take back the provisional credit, even below\na zero balance",
                 "consumes": [
                     "application/json"
                 ],
@@ -333,70 +412,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "wallets"
+                    "disputes"
                 ],
-                "summary": "List wallets",
-                "operationId": "listWallets",
+                "summary": "Update dispute status",
+                "operationId": "updateDisputeStatus",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "enum": [
-                                "USD",
-                                "EUR",
-                                "GBP",
-                                "AED",
-                                "BHD",
-                                "SAR"
-                            ],
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Currencies to filter.",
-                        "name": "currencies",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "IDs of the wallets to filter.",
-                        "name": "ids",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Owner IDs to filter.",
-                        "name": "owner_ids",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Page is the current page number, starting from 1.",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Expected dispute version as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header"
                     },
                     {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "PerPage is the number of items per page, with a maximum of 100.",
-                        "name": "per_page",
-                        "in": "query"
+                        "description": "New status for the dispute",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.UpdateDisputeStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletsResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Dispute version"
+                            }
                         }
                     },
                     "400": {
@@ -405,16 +459,42 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/v1/interest/accruals": {
             "post": {
-                "description": "Create a new wallet with initial balance",
+                "description": "Accrue the interest wallets earned on a day that is over. A day only ever accrues once",
                 "consumes": [
                     "application/json"
                 ],
@@ -422,26 +502,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "wallets"
+                    "interest"
                 ],
-                "summary": "Create a new wallet",
-                "operationId": "createWallet",
+                "summary": "Accrue interest",
+                "operationId": "accrueInterest",
                 "parameters": [
                     {
-                        "description": "Wallet data",
-                        "name": "wallet",
+                        "description": "Day to accrue",
+                        "name": "accrual",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateWalletRequest"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.AccrueInterestRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.InterestAccrualResponse"
                         }
                     },
                     "400": {
@@ -461,13 +541,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/wallets/{id}": {
-            "get": {
-                "description": "Get wallet by ID",
+        "/v1/interest/payouts": {
+            "post": {
+                "description": "Pay out the interest wallets accrued in a month that is over, with a credit per wallet",
                 "consumes": [
                     "application/json"
                 ],
@@ -475,24 +561,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "wallets"
+                    "interest"
                 ],
-                "summary": "Get wallet by ID",
-                "operationId": "getWalletByID",
+                "summary": "Post interest",
+                "operationId": "postInterest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Wallet ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Month to pay out",
+                        "name": "payout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.PostInterestRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.InterestPayoutResponse"
                         }
                     },
                     "400": {
@@ -501,12 +589,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
-                        }
-                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -518,13 +600,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
-                    }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
                 }
             }
         },
-        "/v1/wallets/{id}/balance": {
+        "/v1/risk/decisions": {
             "get": {
-                "description": "Get wallet with balance by ID",
+                "description": "List the decisions of the risk rules run before transactions were created, with the rules hit",
                 "consumes": [
                     "application/json"
                 ],
@@ -532,24 +620,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "wallets"
+                    "risk"
                 ],
-                "summary": "Get wallet with balance",
-                "operationId": "getWalletWithBalance",
+                "summary": "List risk decisions",
+                "operationId": "listRiskDecisions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Wallet ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "After is an opaque cursor; only items after it are returned. Switches the listing to cursor mode.",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Before is an opaque cursor; only items before it are returned. Switches the listing to cursor mode.",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "allow",
+                                "review",
+                                "deny"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Decisions to filter.",
+                        "name": "decisions",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit is the number of items per page in cursor mode, with a maximum of 100.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page is the current page number, starting from 1.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "PerPage is the number of items per page, with a maximum of 100.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Transaction IDs to filter. Denied transactions were never created, so they have no ID.",
+                        "name": "transaction_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Wallet IDs to filter.",
+                        "name": "wallet_ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.RiskDecisionsResponse"
                         }
                     },
                     "400": {
@@ -558,12 +709,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
-                        }
-                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -575,13 +720,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
                     }
                 }
             }
         },
-        "/v1/wallets/{id}/status": {
-            "patch": {
-                "description": "Update wallet status",
+        "/v1/transactions": {
+            "get": {
+                "description": "List transactions with filtering, sorting and pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -589,33 +740,171 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "wallets"
+                    "transactions"
                 ],
-                "summary": "Update wallet status",
-                "operationId": "updateWalletStatus",
+                "summary": "List transactions",
+                "operationId": "listTransactions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Wallet ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "After is an opaque cursor; only items after it are returned. Switches the listing to cursor mode.",
+                        "name": "after",
+                        "in": "query"
                     },
                     {
-                        "description": "New wallet status",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "AmountMax is the largest transaction amount to include.",
+                        "name": "amount_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "AmountMin is the smallest transaction amount to include.",
+                        "name": "amount_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Before is an opaque cursor; only items before it are returned. Switches the listing to cursor mode.",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CreatedAtFrom is the start date for filtering transactions.",
+                        "name": "created_at_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CreatedAtTo is the end date for filtering transactions.",
+                        "name": "created_at_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
                             "type": "string"
-                        }
+                        },
+                        "collectionFormat": "csv",
+                        "description": "IDs of the transactions to filter.",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit is the number of items per page in cursor mode, with a maximum of 100.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "minLength": 1,
+                        "type": "string",
+                        "description": "Note is a case-insensitive substring the transaction note must contain.",
+                        "name": "note",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page is the current page number, starting from 1.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "PerPage is the number of items per page, with a maximum of 100.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "amount",
+                            "-amount",
+                            "created_at",
+                            "-created_at",
+                            "updated_at",
+                            "-updated_at"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "TransactionSortAmountAsc",
+                            "TransactionSortAmountDesc",
+                            "TransactionSortCreatedAtAsc",
+                            "TransactionSortCreatedAtDesc",
+                            "TransactionSortUpdatedAtAsc",
+                            "TransactionSortUpdatedAtDesc"
+                        ],
+                        "description": "Sort orders the results by amount, created_at or updated_at. Prefix with \"-\" for descending order.",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "pending",
+                                "completed",
+                                "failed",
+                                "awaiting_approval",
+                                "rejected"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Statuses of the transactions to filter.",
+                        "name": "statuses",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "credit",
+                                "debit"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Types of the transactions to filter.",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UpdatedAtFrom is the start date for filtering transactions by last update.",
+                        "name": "updated_at_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UpdatedAtTo is the end date for filtering transactions by last update.",
+                        "name": "updated_at_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Wallet IDs to filter.",
+                        "name": "wallet_ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionsResponse"
                         }
                     },
                     "400": {
@@ -641,174 +930,1728 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.ValidationError"
+            },
+            "post": {
+                "description": "Create a new transaction. Transactions that await approval need the X-Actor-ID of their maker.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Create transaction",
+                "operationId": "createTransaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the maker",
+                        "name": "X-Actor-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Transaction data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateTransactionRequest"
+                        }
                     }
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.ValidationError": {
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/quote": {
+            "post": {
+                "description": "Preview the fees a transaction would be charged, without creating it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Quote transaction",
+                "operationId": "quoteTransaction",
+                "parameters": [
+                    {
+                        "description": "Transaction to quote",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.QuoteTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionQuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}": {
+            "get": {
+                "description": "Get transaction by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transaction by ID",
+                "operationId": "getTransactionByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Transaction version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}/approvals": {
+            "get": {
+                "description": "List the approval trail of a transaction, oldest entry first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "List transaction approvals",
+                "operationId": "listTransactionApprovals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionApprovalsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}/approve": {
+            "post": {
+                "description": "Approve a transaction awaiting approval, which becomes pending. The approver is identified\nby the X-Actor-ID header and must not be the maker of the transaction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Approve transaction",
+                "operationId": "approveTransaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the approver",
+                        "name": "X-Actor-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the approval",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.ReviewTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Transaction version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}/reject": {
+            "post": {
+                "description": "Reject a transaction awaiting approval. The approver is identified by the X-Actor-ID header\nand must not be the maker of the transaction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Reject transaction",
+                "operationId": "rejectTransaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the approver",
+                        "name": "X-Actor-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the rejection",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.ReviewTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Transaction version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transactions/{id}/status": {
+            "put": {
+                "description": "Update the status of a transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Update transaction status",
+                "operationId": "updateTransactionStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected transaction version as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "New status for the transaction",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Transaction version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets": {
+            "get": {
+                "description": "List wallets with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "List wallets",
+                "operationId": "listWallets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "After is an opaque cursor; only items after it are returned. Switches the listing to cursor mode.",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Before is an opaque cursor; only items before it are returned. Switches the listing to cursor mode.",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "USD",
+                                "EUR",
+                                "GBP",
+                                "AED",
+                                "BHD",
+                                "SAR"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Currencies to filter.",
+                        "name": "currencies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "IDs of the wallets to filter.",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit is the number of items per page in cursor mode, with a maximum of 100.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Owner IDs to filter.",
+                        "name": "owner_ids",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page is the current page number, starting from 1.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "PerPage is the number of items per page, with a maximum of 100.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Products to filter.",
+                        "name": "products",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new wallet with initial balance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Create a new wallet",
+                "operationId": "createWallet",
+                "parameters": [
+                    {
+                        "description": "Wallet data",
+                        "name": "wallet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}": {
+            "get": {
+                "description": "Get wallet by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get wallet by ID",
+                "operationId": "getWalletByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Wallet version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/balance": {
+            "get": {
+                "description": "Get wallet with balance by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get wallet with balance",
+                "operationId": "getWalletWithBalance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/events": {
+            "get": {
+                "description": "Server-sent events for the transactions of a wallet, each carrying the new balance.\nReconnect with Last-Event-ID to receive the events missed in between.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Stream wallet events",
+                "operationId": "streamWalletEvents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/status": {
+            "patch": {
+                "description": "Update wallet status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Update wallet status",
+                "operationId": "updateWalletStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected wallet version as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "New wallet status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Wallet version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.Error": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.ErrorCode"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.ValidationError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "RequestID identifies the failed request in the service logs.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_http_apierror.ValidationError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_pagination.Pagination": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "NextCursor and PrevCursor are only set in cursor mode, when there are more items in that direction.",
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.ApprovalAction": {
+            "type": "string",
+            "enum": [
+                "requested",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "ApprovalActionRequested",
+                "ApprovalActionApproved",
+                "ApprovalActionRejected"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.BalanceBucket": {
+            "type": "string",
+            "enum": [
+                "cash",
+                "promo"
+            ],
+            "x-enum-varnames": [
+                "BalanceBucketCash",
+                "BalanceBucketPromo"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.Currency": {
+            "type": "string",
+            "enum": [
+                "USD",
+                "EUR",
+                "GBP",
+                "AED",
+                "BHD",
+                "SAR"
+            ],
+            "x-enum-varnames": [
+                "CurrencyUSD",
+                "CurrencyEUR",
+                "CurrencyGBP",
+                "CurrencyAED",
+                "CurrencyBHD",
+                "CurrencySAR"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.DisputeStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "under_review",
+                "won",
+                "lost"
+            ],
+            "x-enum-varnames": [
+                "DisputeStatusOpen",
+                "DisputeStatusUnderReview",
+                "DisputeStatusWon",
+                "DisputeStatusLost"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "bad_request",
+                "validation_failed",
+                "internal_error",
+                "service_unavailable",
+                "not_found",
+                "conflict",
+                "unprocessable_entity",
+                "too_many_requests",
+                "wallet_not_found",
+                "transaction_not_found",
+                "insufficient_funds",
+                "wallet_not_active",
+                "invalid_transition",
+                "duplicate_wallet",
+                "version_conflict",
+                "invalid_cursor",
+                "lock_lost",
+                "approval_required",
+                "not_an_approver",
+                "self_approval",
+                "unknown_maker",
+                "linked_transaction",
+                "period_not_over",
+                "dispute_not_found",
+                "duplicate_dispute",
+                "not_disputable",
+                "dispute_closed",
+                "transaction_denied",
+                "fee_wallet_unusable"
+            ],
+            "x-enum-varnames": [
+                "ErrorCodeBadRequest",
+                "ErrorCodeValidation",
+                "ErrorCodeInternal",
+                "ErrorCodeUnavailable",
+                "ErrorCodeNotFound",
+                "ErrorCodeConflict",
+                "ErrorCodeUnprocessable",
+                "ErrorCodeTooManyRequests",
+                "ErrorCodeWalletNotFound",
+                "ErrorCodeTransactionNotFound",
+                "ErrorCodeInsufficientFunds",
+                "ErrorCodeWalletNotActive",
+                "ErrorCodeInvalidTransition",
+                "ErrorCodeDuplicateWallet",
+                "ErrorCodeVersionConflict",
+                "ErrorCodeInvalidCursor",
+                "ErrorCodeLockLost",
+                "ErrorCodeApprovalRequired",
+                "ErrorCodeNotApprover",
+                "ErrorCodeSelfApproval",
+                "ErrorCodeUnknownMaker",
+                "ErrorCodeLinkedTransaction",
+                "ErrorCodePeriodNotOver",
+                "ErrorCodeDisputeNotFound",
+                "ErrorCodeDuplicateDispute",
+                "ErrorCodeNotDisputable",
+                "ErrorCodeDisputeClosed",
+                "ErrorCodeTransactionDenied",
+                "ErrorCodeFeeWalletUnusable"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.EventType": {
+            "type": "string",
+            "enum": [
+                "transaction.created",
+                "transaction.status_updated"
+            ],
+            "x-enum-varnames": [
+                "EventTypeTransactionCreated",
+                "EventTypeTransactionStatusUpdated"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.RiskDecision": {
+            "type": "string",
+            "enum": [
+                "allow",
+                "review",
+                "deny"
+            ],
+            "x-enum-varnames": [
+                "RiskDecisionAllow",
+                "RiskDecisionReview",
+                "RiskDecisionDeny"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionSort": {
+            "type": "string",
+            "enum": [
+                "amount",
+                "-amount",
+                "created_at",
+                "-created_at",
+                "updated_at",
+                "-updated_at"
+            ],
+            "x-enum-varnames": [
+                "TransactionSortAmountAsc",
+                "TransactionSortAmountDesc",
+                "TransactionSortCreatedAtAsc",
+                "TransactionSortCreatedAtDesc",
+                "TransactionSortUpdatedAtAsc",
+                "TransactionSortUpdatedAtDesc"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "completed",
+                "failed",
+                "awaiting_approval",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "TransactionStatusPending",
+                "TransactionStatusCompleted",
+                "TransactionStatusFailed",
+                "TransactionStatusAwaitingApproval",
+                "TransactionStatusRejected"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionType": {
+            "type": "string",
+            "enum": [
+                "credit",
+                "debit"
+            ],
+            "x-enum-varnames": [
+                "TransactionTypeCredit",
+                "TransactionTypeDebit"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.WalletStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive",
+                "frozen"
+            ],
+            "x-enum-varnames": [
+                "WalletStatusActive",
+                "WalletStatusInactive",
+                "WalletStatusFrozen"
+            ]
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.AccrueInterestRequest": {
             "type": "object",
+            "required": [
+                "date"
+            ],
             "properties": {
-                "message": {
+                "date": {
+                    "description": "Date is the UTC day to accrue, as YYYY-MM-DD.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.AddDisputeNoteRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.BalanceBucket": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Available leaves out the credits that expired but were not yet written off.",
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "bucket": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.BalanceBucket"
+                },
+                "expiring": {
+                    "description": "Expiring are the credits of the bucket that expire, soonest first, with what is left of them.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.ExpiringCredit"
+                    }
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateTransactionRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "idempotency_key",
+                "type",
+                "wallet_id"
+            ],
+            "properties": {
+                "adjustment": {
+                    "description": "Adjustment marks a manual correction made by an operator. Adjustments always await approval.",
+                    "type": "boolean"
+                },
+                "amount": {
+                    "description": "Amount to be added or deducted from the wallet.",
+                    "type": "integer"
+                },
+                "bucket": {
+                    "description": "Bucket is the balance bucket a credit funds. Defaults to cash.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.BalanceBucket"
+                        }
+                    ]
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is when what is left of a credit is written off. Promotional credits get the configured\nlifetime when it is not set.",
                     "type": "string"
                 },
-                "source": {
+                "idempotency_key": {
+                    "description": "Idempotency key for the transaction.",
+                    "type": "string"
+                },
+                "note": {
+                    "description": "Note for the transaction.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type of transaction.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionType"
+                        }
+                    ]
+                },
+                "wallet_id": {
+                    "description": "Unique identifier for the wallet.",
                     "type": "string"
                 }
             }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_pagination.Pagination": {
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateWalletRequest": {
             "type": "object",
+            "required": [
+                "currency",
+                "owner_id"
+            ],
             "properties": {
-                "count": {
+                "currency": {
+                    "description": "Currency of the wallet.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.Currency"
+                        }
+                    ]
+                },
+                "owner_id": {
+                    "description": "Unique identifier for the wallet owner.",
+                    "type": "string"
+                },
+                "product": {
+                    "description": "Product of the wallet, such as savings. An owner may hold a wallet of each product in a currency.",
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Dispute": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "integer"
                 },
-                "page": {
+                "created_at": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "description": "DueAt is the deadline to resolve the dispute by.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "Notes are the evidence attached to the dispute, oldest first, returned when it is looked up.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeNote"
+                    }
+                },
+                "provisional_credit_id": {
+                    "description": "ProvisionalCreditID is the credit given back while the dispute is open.",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "resolution_note": {
+                    "type": "string"
+                },
+                "settlement_id": {
+                    "description": "SettlementID is the transaction that settled the dispute: the credit of a dispute won without a\nprovisional credit, or the reversal of the provisional credit of a dispute lost.",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.DisputeStatus"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
                     "type": "integer"
                 },
-                "per_page": {
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeNote": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dispute_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeNoteResponse": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeNote"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputeResponse": {
+            "type": "object",
+            "properties": {
+                "dispute": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Dispute"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.DisputesResponse": {
+            "type": "object",
+            "properties": {
+                "disputes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Dispute"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Metadata"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.ExpiringCredit": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "integer"
                 },
-                "total": {
+                "credit_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Fee": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "integer"
                 },
-                "total_pages": {
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.InterestAccrualResponse": {
+            "type": "object",
+            "properties": {
+                "accrued": {
+                    "description": "Accrued is the number of wallets whose interest accrued by this run. Days already accrued are\nnot counted again.",
                     "type": "integer"
+                },
+                "date": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.Currency": {
-            "type": "string",
-            "enum": [
-                "USD",
-                "EUR",
-                "GBP",
-                "AED",
-                "BHD",
-                "SAR"
-            ],
-            "x-enum-varnames": [
-                "CurrencyUSD",
-                "CurrencyEUR",
-                "CurrencyGBP",
-                "CurrencyAED",
-                "CurrencyBHD",
-                "CurrencySAR"
-            ]
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.InterestPayoutResponse": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "string"
+                },
+                "posted": {
+                    "description": "Posted is the number of wallets credited with interest by this run.",
+                    "type": "integer"
+                }
+            }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "completed",
-                "failed"
-            ],
-            "x-enum-varnames": [
-                "TransactionStatusPending",
-                "TransactionStatusCompleted",
-                "TransactionStatusFailed"
-            ]
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Metadata": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_pagination.Pagination"
+                }
+            }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionType": {
-            "type": "string",
-            "enum": [
-                "credit",
-                "debit"
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.OpenDisputeRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "transaction_id"
             ],
-            "x-enum-varnames": [
-                "TransactionTypeCredit",
-                "TransactionTypeDebit"
-            ]
+            "properties": {
+                "due_at": {
+                    "description": "DueAt is the deadline to resolve the dispute by. Defaults to the configured response window.",
+                    "type": "string"
+                },
+                "provisional_credit": {
+                    "description": "ProvisionalCredit credits the disputed amount back while the dispute is open. It is reversed if the\ndispute is lost.",
+                    "type": "boolean"
+                },
+                "reason": {
+                    "description": "Reason the wallet owner gave for the dispute.",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "transaction_id": {
+                    "description": "TransactionID is the completed debit disputed.",
+                    "type": "string"
+                }
+            }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.WalletStatus": {
-            "type": "string",
-            "enum": [
-                "active",
-                "inactive",
-                "frozen"
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.PostInterestRequest": {
+            "type": "object",
+            "required": [
+                "month"
             ],
-            "x-enum-varnames": [
-                "WalletStatusActive",
-                "WalletStatusInactive",
-                "WalletStatusFrozen"
-            ]
+            "properties": {
+                "month": {
+                    "description": "Month is the UTC month to pay out, as YYYY-MM.",
+                    "type": "string"
+                }
+            }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateTransactionRequest": {
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.QuoteTransactionRequest": {
             "type": "object",
             "required": [
                 "amount",
-                "idempotency_key",
                 "type",
                 "wallet_id"
             ],
             "properties": {
                 "amount": {
-                    "description": "Amount to be added or deducted from the wallet.",
                     "type": "integer"
                 },
+                "type": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionType"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.ReviewTransactionRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Reason for the decision, kept in the approval trail.",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.RiskDecision": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "decision": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.RiskDecision"
+                },
+                "hits": {
+                    "description": "Hits are the rules that did not allow the transaction.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.RiskRuleHit"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
                 "idempotency_key": {
-                    "description": "Idempotency key for the transaction.",
                     "type": "string"
                 },
-                "note": {
-                    "description": "Note for the transaction.",
+                "transaction_id": {
+                    "description": "TransactionID is the transaction created, which denied transactions have none of.",
                     "type": "string"
                 },
                 "type": {
-                    "description": "Type of transaction.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionType"
-                        }
-                    ]
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionType"
                 },
                 "wallet_id": {
-                    "description": "Unique identifier for the wallet.",
                     "type": "string"
                 }
             }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.CreateWalletRequest": {
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.RiskDecisionsResponse": {
             "type": "object",
-            "required": [
-                "currency",
-                "owner_id"
-            ],
             "properties": {
-                "currency": {
-                    "description": "Currency of the wallet.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.Currency"
-                        }
-                    ]
+                "metadata": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Metadata"
                 },
-                "owner_id": {
-                    "description": "Unique identifier for the wallet owner.",
-                    "type": "string"
+                "risk_decisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.RiskDecision"
+                    }
                 }
             }
         },
-        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Metadata": {
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.RiskRuleHit": {
             "type": "object",
             "properties": {
-                "pagination": {
-                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_internal_util_pagination.Pagination"
+                "decision": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.RiskDecision"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
                 }
             }
         },
@@ -818,15 +2661,38 @@ const docTemplate = `{
                 "amount": {
                     "type": "integer"
                 },
+                "bucket": {
+                    "description": "Bucket is the balance bucket a credit funds.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.BalanceBucket"
+                        }
+                    ]
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "expires_at": {
+                    "description": "ExpiresAt is when what is left of a credit is written off.",
+                    "type": "string"
+                },
+                "fees": {
+                    "description": "Fees are the fee transactions charged on the transaction, returned when it is created.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Transaction"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
                 "note": {
                     "type": "string"
                 },
+                "parent_id": {
+                    "description": "ParentID is the transaction a fee transaction was charged on.",
+                    "type": "string"
+                },
                 "status": {
                     "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionStatus"
                 },
@@ -836,11 +2702,86 @@ const docTemplate = `{
                 "updated_at": {
                     "type": "string"
                 },
+                "version": {
+                    "type": "integer"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionApproval": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.ApprovalAction"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionApprovalsResponse": {
+            "type": "object",
+            "properties": {
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionApproval"
+                    }
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionQuote": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "balance_change": {
+                    "description": "BalanceChange is how the wallet balance changes once the transaction and its fees complete.",
+                    "type": "integer"
+                },
+                "currency": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.Currency"
+                },
+                "fees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Fee"
+                    }
+                },
+                "total_fees": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.TransactionType"
+                },
                 "wallet_id": {
                     "type": "string"
                 }
             }
         },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "quote": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionQuote"
+                }
+            }
+        },
         "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.TransactionResponse": {
             "type": "object",
             "properties": {
@@ -863,12 +2804,35 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.UpdateDisputeStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "description": "Note explains the resolution of a won or lost dispute. Otherwise it is added to the dispute notes.",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.DisputeStatus"
+                }
+            }
+        },
         "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Wallet": {
             "type": "object",
             "properties": {
                 "balance": {
                     "type": "integer"
                 },
+                "buckets": {
+                    "description": "Buckets split the balance by the buckets of the credits funding it.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.BalanceBucket"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -881,11 +2845,41 @@ const docTemplate = `{
                 "owner_id": {
                     "type": "string"
                 },
+                "product": {
+                    "type": "string"
+                },
                 "status": {
                     "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.WalletStatus"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.WalletEvent": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "Balance is the wallet balance after the change, when known.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_wallet.Transaction"
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_Shaheen-AlQaraghuli_wallet-go_pkg_types.EventType"
+                },
+                "wallet_id": {
+                    "type": "string"
                 }
             }
         },
@@ -910,6 +2904,31 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "internal_app_controller_health.ComponentStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_app_controller_health.Response": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/internal_app_controller_health.ComponentStatus"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
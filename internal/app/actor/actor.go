@@ -0,0 +1,21 @@
+// Package actor carries the identity of the caller through contexts. The identity is asserted by the
+// gateway in front of the service, which authenticates callers; the service only records and checks it.
+package actor
+
+import "context"
+
+type idKey struct{}
+
+// MaxIDLength bounds the caller supplied IDs stored in the approval trail.
+const MaxIDLength = 128
+
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// ID returns the ID of the caller, or an empty string when the caller did not identify itself.
+func ID(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+
+	return id
+}
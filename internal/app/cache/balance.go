@@ -5,15 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-const (
-	balanceKeyPrefix = "balance"
-	balanceTTL       = 24 * time.Hour
-)
+const balanceKeyPrefix = "balance"
 
 func (c *Cache) GetBalance(ctx context.Context, walletID string) (*int, error) {
 	key := c.makeKey(balanceKeyPrefix, walletID)
@@ -39,7 +35,7 @@ func (c *Cache) GetBalance(ctx context.Context, walletID string) (*int, error) {
 func (c *Cache) SetBalance(ctx context.Context, walletID string, balance int) error {
 	key := c.makeKey(balanceKeyPrefix, walletID)
 
-	err := c.client.Set(ctx, key, strconv.Itoa(balance), balanceTTL).Err()
+	err := c.client.Set(ctx, key, strconv.Itoa(balance), c.balanceTTL).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set balance in cache: %w", err)
 	}
@@ -4,52 +4,143 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"time"
 
 	"github.com/go-redsync/redsync/v4"
 	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
-const (
-	MutexTTL   = 15 * time.Second
-	MutexTries = 100
-	MutexDelay = 100 * time.Millisecond
-)
-
 type Cache struct {
 	client  *redis.Client
 	redsync *redsync.Redsync
 	appName string
+
+	mutexTTL        time.Duration
+	mutexRetryDelay time.Duration
+	balanceTTL      time.Duration
+	idempotencyTTL  time.Duration
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithMutex sets how long locks are held at most and how often acquiring a taken one is retried.
+// Defaults to 15s and 100ms.
+func WithMutex(ttl, retryDelay time.Duration) Option {
+	return func(c *Cache) {
+		c.mutexTTL = ttl
+		c.mutexRetryDelay = retryDelay
+	}
+}
+
+// WithBalanceTTL sets how long cached balances are kept. Defaults to 24h.
+func WithBalanceTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.balanceTTL = ttl
+	}
 }
 
-func New(url, appName string) *Cache {
+// WithIdempotencyTTL sets how long idempotency keys are remembered. Defaults to 24h.
+func WithIdempotencyTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.idempotencyTTL = ttl
+	}
+}
+
+func New(url, appName string, opts ...Option) *Cache {
 	parsedUrl, err := redis.ParseURL(url)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	client := redis.NewClient(parsedUrl)
+	client.AddHook(errorHook{})
+
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		log.Fatal(err)
+	}
+
+	c := &Cache{
+		client:          client,
+		redsync:         redsync.New(goredis.NewPool(client)),
+		appName:         appName,
+		mutexTTL:        15 * time.Second,
+		mutexRetryDelay: 100 * time.Millisecond,
+		balanceTTL:      24 * time.Hour,
+		idempotencyTTL:  24 * time.Hour,
+	}
 
-	return &Cache{
-		client:  client,
-		redsync: redsync.New(goredis.NewPool(client)),
-		appName: appName,
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
-func (c *Cache) Mutex(ctx context.Context, key string) (func(context.Context) (bool, error), error) {
+// Lock acquires a redsync mutex on key, retrying until ctx is done. The mutex is extended while held,
+// so work outlasting the TTL keeps it; the TTL only frees the keys of holders that died.
+func (c *Cache) Lock(ctx context.Context, key string) (func(context.Context) error, error) {
 	mutex := c.redsync.NewMutex(c.makeKey("mutex", key),
-		redsync.WithExpiry(MutexTTL),
-		redsync.WithTries(MutexTries),
-		redsync.WithRetryDelay(MutexDelay),
+		redsync.WithExpiry(c.mutexTTL),
+		redsync.WithTries(math.MaxInt32),
+		redsync.WithRetryDelay(c.mutexRetryDelay),
 	)
 
 	if err := mutex.LockContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to acquire mutex lock for key %s: %w", key, err)
+		return nil, err
 	}
 
-	return mutex.UnlockContext, nil
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go c.keepAlive(mutex, stop, stopped)
+
+	return func(ctx context.Context) error {
+		close(stop)
+		<-stopped
+
+		_, err := mutex.UnlockContext(ctx)
+
+		return err
+	}, nil
+}
+
+// keepAlive extends mutex every third of its TTL until stop is closed. Once an extension fails the
+// mutex may expire and pass to another holder, whose writes then win through the wallet fencing token.
+func (c *Cache) keepAlive(mutex *redsync.Mutex, stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	interval := c.mutexTTL / 3
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			extended, err := mutex.ExtendContext(ctx)
+
+			cancel()
+
+			if err != nil || !extended {
+				mutexExtensions.WithLabelValues("failed").Inc()
+
+				return
+			}
+
+			mutexExtensions.WithLabelValues("extended").Inc()
+		}
+	}
+}
+
+func (c *Cache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
 }
 
 func (c *Cache) makeKey(prefix, id string) string {
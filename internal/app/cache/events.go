@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	eventsKeyPrefix = "events"
+	// eventsHistorySize is roughly how many recent events of each wallet are kept for resuming.
+	eventsHistorySize = 1000
+	eventsTTL         = 24 * time.Hour
+)
+
+// streamID matches the IDs Redis assigns to stream entries.
+var streamID = regexp.MustCompile(`^\d+(-\d+)?$`)
+
+// EventBus shares transaction events between instances. Events are appended to a Redis stream per
+// wallet, which keeps them for resuming, and announced on a pub/sub channel, which every instance
+// fans out to its own subscribers.
+type EventBus struct {
+	cache  *Cache
+	local  *events.Broker
+	logger *zap.Logger
+}
+
+func (c *Cache) EventBus(logger *zap.Logger) *EventBus {
+	return &EventBus{
+		cache:  c,
+		local:  events.NewBroker(events.WithHistory(0)),
+		logger: logger,
+	}
+}
+
+// Publish stores the event in the wallet stream, whose entry ID becomes the event ID, and announces it.
+func (b *EventBus) Publish(ctx context.Context, event events.Event) error {
+	key := b.cache.makeKey(eventsKeyPrefix, event.WalletID)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	id, err := b.cache.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: eventsHistorySize,
+		Approx: true,
+		Values: map[string]any{"event": data},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to append event to stream: %w", err)
+	}
+
+	if err := b.cache.client.Expire(ctx, key, eventsTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set event stream expiry: %w", err)
+	}
+
+	event.ID = id
+
+	data, err = json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := b.cache.client.Publish(ctx, b.channel(), data).Err(); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe delivers the events of a wallet published by any instance while Run is running.
+func (b *EventBus) Subscribe(ctx context.Context, walletID string) (<-chan events.Event, error) {
+	return b.local.Subscribe(ctx, walletID)
+}
+
+func (b *EventBus) Since(ctx context.Context, walletID, afterID string) ([]events.Event, error) {
+	if !streamID.MatchString(afterID) {
+		return nil, events.ErrInvalidEventID
+	}
+
+	entries, err := b.cache.client.XRangeN(ctx, b.cache.makeKey(eventsKeyPrefix, walletID),
+		"("+afterID, "+", eventsHistorySize).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event stream: %w", err)
+	}
+
+	res := make([]events.Event, 0, len(entries))
+
+	for _, entry := range entries {
+		data, _ := entry.Values["event"].(string)
+
+		var event events.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+
+		event.ID = entry.ID
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// Run receives the events announced by every instance and hands them to local subscribers until ctx
+// is done. The client reconnects on its own when the connection to Redis drops.
+func (b *EventBus) Run(ctx context.Context) {
+	pubsub := b.cache.client.Subscribe(ctx, b.channel())
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var event events.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				b.logger.Error("error unmarshalling event", zap.Error(err))
+
+				continue
+			}
+
+			b.local.Dispatch(event)
+		}
+	}
+}
+
+func (b *EventBus) channel() string {
+	return b.cache.makeKey(eventsKeyPrefix, "announcements")
+}
@@ -5,16 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
 	"github.com/redis/go-redis/v9"
 )
 
-const (
-	idempotencyKeyPrefix = "idempotency"
-	idempotencyTTL       = 24 * time.Hour
-)
+const idempotencyKeyPrefix = "idempotency"
 
 func (c *Cache) GetIdempotentTransaction(ctx context.Context, idempotencyKey string) (*models.Transaction, error) {
 	key := c.makeKey(idempotencyKeyPrefix, idempotencyKey)
@@ -49,7 +45,7 @@ func (c *Cache) SetIdempotentTransaction(
 		return fmt.Errorf("failed to marshal transaction: %w", err)
 	}
 
-	err = c.client.Set(ctx, key, data, idempotencyTTL).Err()
+	err = c.client.Set(ctx, key, data, c.idempotencyTTL).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set idempotent transaction in cache: %w", err)
 	}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+)
+
+// InProcess caches balances and idempotent transactions in process memory, expiring them like the Redis
+// cache does. It suits single instance deployments, such as SQLite ones, that run without Redis.
+type InProcess struct {
+	mu           sync.Mutex
+	balances     map[string]entry[int]
+	transactions map[string]entry[models.Transaction]
+	lastSweep    time.Time
+
+	balanceTTL     time.Duration
+	idempotencyTTL time.Duration
+	now            func() time.Time
+}
+
+type entry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// NewInProcess creates an in-process cache keeping balances for balanceTTL and idempotency keys for
+// idempotencyTTL.
+func NewInProcess(balanceTTL, idempotencyTTL time.Duration, now func() time.Time) *InProcess {
+	return &InProcess{
+		balances:       map[string]entry[int]{},
+		transactions:   map[string]entry[models.Transaction]{},
+		lastSweep:      now(),
+		balanceTTL:     balanceTTL,
+		idempotencyTTL: idempotencyTTL,
+		now:            now,
+	}
+}
+
+func (c *InProcess) GetBalance(_ context.Context, walletID string) (*int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, found := c.balances[walletID]
+	if !found || !c.now().Before(cached.expiresAt) {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &cached.value, nil
+}
+
+func (c *InProcess) SetBalance(_ context.Context, walletID string, balance int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweep()
+	c.balances[walletID] = entry[int]{value: balance, expiresAt: c.now().Add(c.balanceTTL)}
+
+	return nil
+}
+
+func (c *InProcess) GetIdempotentTransaction(_ context.Context, idempotencyKey string) (*models.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, found := c.transactions[idempotencyKey]
+	if !found || !c.now().Before(cached.expiresAt) {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &cached.value, nil
+}
+
+func (c *InProcess) SetIdempotentTransaction(
+	_ context.Context,
+	idempotencyKey string,
+	transaction models.Transaction,
+) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweep()
+	c.transactions[idempotencyKey] = entry[models.Transaction]{
+		value:     transaction,
+		expiresAt: c.now().Add(c.idempotencyTTL),
+	}
+
+	return nil
+}
+
+// sweep drops the expired entries, at most once per the shorter TTL, so keys never read again do not
+// pile up. The caller holds c.mu.
+func (c *InProcess) sweep() {
+	now := c.now()
+	if now.Sub(c.lastSweep) < min(c.balanceTTL, c.idempotencyTTL) {
+		return
+	}
+
+	c.lastSweep = now
+
+	for key, cached := range c.balances {
+		if !now.Before(cached.expiresAt) {
+			delete(c.balances, key)
+		}
+	}
+
+	for key, cached := range c.transactions {
+		if !now.Before(cached.expiresAt) {
+			delete(c.transactions, key)
+		}
+	}
+}
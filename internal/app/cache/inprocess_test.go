@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInProcess_Expiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewInProcess(time.Minute, time.Hour, func() time.Time { return now })
+	ctx := t.Context()
+
+	require.NoError(t, c.SetBalance(ctx, "wallet-1", 500))
+	require.NoError(t, c.SetIdempotentTransaction(ctx, "key-1", models.Transaction{ID: "txn-1"}))
+
+	balance, err := c.GetBalance(ctx, "wallet-1")
+	require.NoError(t, err)
+	require.NotNil(t, balance)
+	assert.Equal(t, 500, *balance)
+
+	now = now.Add(time.Minute)
+
+	balance, err = c.GetBalance(ctx, "wallet-1")
+	require.NoError(t, err)
+	assert.Nil(t, balance, "balances expire after their TTL")
+
+	transaction, err := c.GetIdempotentTransaction(ctx, "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+	assert.Equal(t, "txn-1", transaction.ID)
+
+	now = now.Add(time.Hour)
+	require.NoError(t, c.SetBalance(ctx, "wallet-2", 100))
+
+	transaction, err = c.GetIdempotentTransaction(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Nil(t, transaction, "idempotency keys expire after their TTL")
+	assert.Len(t, c.transactions, 0, "expired entries are swept")
+	assert.Len(t, c.balances, 1)
+
+	missing, err := c.GetBalance(ctx, "wallet-3")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
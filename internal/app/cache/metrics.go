@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+var redisErrors = metrics.NewCounterVec(prometheus.CounterOpts{
+	Name: "redis_errors_total",
+	Help: "Failed Redis commands by command name.",
+}, "command")
+
+var mutexExtensions = metrics.NewCounterVec(prometheus.CounterOpts{
+	Name: "mutex_extensions_total",
+	Help: "Extensions of held Redis mutexes, by outcome.",
+}, "outcome")
+
+// errorHook counts failed Redis commands. Missing keys are not failures.
+type errorHook struct{}
+
+func (errorHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			redisErrors.WithLabelValues("dial").Inc()
+		}
+
+		return conn, err
+	}
+}
+
+func (errorHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if err != nil && !errors.Is(err, redis.Nil) {
+			redisErrors.WithLabelValues(cmd.Name()).Inc()
+		}
+
+		return err
+	}
+}
+
+func (errorHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+
+		for _, cmd := range cmds {
+			if cmdErr := cmd.Err(); cmdErr != nil && !errors.Is(cmdErr, redis.Nil) {
+				redisErrors.WithLabelValues(cmd.Name()).Inc()
+			}
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,248 @@
+package disputes
+
+import (
+	"context"
+
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	_ "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/etag"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/gin-gonic/gin"
+)
+
+type disputeService interface {
+	OpenDispute(ctx context.Context, req svcModels.OpenDisputeRequest) (svcModels.Dispute, error)
+	GetDispute(ctx context.Context, id string) (svcModels.Dispute, error)
+	ListDisputes(ctx context.Context, query svcModels.QueryDisputes) (
+		svcModels.Disputes, *pagination.Pagination, error)
+	UpdateDisputeStatus(ctx context.Context, id, status string, note *string, expectedVersion *int) (
+		svcModels.Dispute, error)
+	AddDisputeNote(ctx context.Context, id, note string) (svcModels.DisputeNote, error)
+}
+
+type Controller struct {
+	disputeSvc disputeService
+}
+
+func New(disputeSvc disputeService) *Controller {
+	return &Controller{
+		disputeSvc: disputeSvc,
+	}
+}
+
+// OpenDispute godoc
+//
+// @Summary      Open dispute
+// @Description  Open a dispute of a completed debit, optionally crediting the amount back while it is open
+// @ID openDispute
+// @Tags         disputes
+// @Accept       json
+// @Produce      json
+// @Param        dispute  body      wallet.OpenDisputeRequest  true  "Dispute data"
+// @Success      201      {object}  wallet.DisputeResponse
+// @Header       201      {string}  ETag  "Dispute version"
+// @Failure      400      {object}  apierror.Error
+// @Failure      404      {object}  apierror.Error
+// @Failure      409      {object}  apierror.Error
+// @Failure      422      {object}  apierror.Error
+// @Failure      500      {object}  apierror.Error
+// @Failure      503      {object}  apierror.Error
+// @Router       /v1/disputes [post]
+func (c *Controller) OpenDispute(ctx *gin.Context) {
+	var req wallet.OpenDisputeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	dispute, err := c.disputeSvc.OpenDispute(ctx, svcModels.OpenDisputeRequest{}.FromRequest(req))
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	etag.Set(ctx, dispute.Version)
+	ctx.JSON(201, wallet.DisputeResponse{
+		Dispute: dispute.ToResponse(),
+	})
+}
+
+// GetDispute godoc
+//
+// @Summary      Get dispute
+// @Description  Get a dispute along with its notes
+// @ID getDispute
+// @Tags         disputes
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Dispute ID"
+// @Success      200  {object}  wallet.DisputeResponse
+// @Header       200  {string}  ETag  "Dispute version"
+// @Failure      400  {object}  apierror.Error
+// @Failure      404  {object}  apierror.Error
+// @Failure      500  {object}  apierror.Error
+// @Failure      503  {object}  apierror.Error
+// @Router       /v1/disputes/{id} [get]
+func (c *Controller) GetDispute(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		jsonlib.SendBadRequestError(ctx, "Dispute ID is required")
+
+		return
+	}
+
+	dispute, err := c.disputeSvc.GetDispute(ctx, id)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	etag.Set(ctx, dispute.Version)
+	ctx.JSON(200, wallet.DisputeResponse{
+		Dispute: dispute.ToResponse(),
+	})
+}
+
+// ListDisputes godoc
+//
+// @Summary      List disputes
+// @Description  List disputes with filtering and pagination
+// @ID listDisputes
+// @Tags         disputes
+// @Accept       json
+// @Produce      json
+// @Param        query  query  wallet.ListDisputesRequest  true  "Query parameters"
+// @Success      200    {object}  wallet.DisputesResponse
+// @Failure      400    {object}  apierror.Error
+// @Failure      422    {object}  apierror.Error
+// @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
+// @Router       /v1/disputes [get]
+func (c *Controller) ListDisputes(ctx *gin.Context) {
+	var req wallet.ListDisputesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	disputes, pagination, err := c.disputeSvc.ListDisputes(ctx, svcModels.QueryDisputes{}.FromRequest(req))
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(200, wallet.DisputesResponse{
+		Disputes: disputes.ToResponse(),
+		Metadata: wallet.Metadata{
+			Pagination: *pagination,
+		},
+	})
+}
+
+// UpdateDisputeStatus godoc
+//
+// @Summary      Update dispute status
+// @Description  Move an open dispute under review, or resolve one under review. Won disputes refund the amount
+// @Description  unless it was provisionally credited, and lost ones take back the provisional credit, even below
+// @Description  a zero balance
+// @ID updateDisputeStatus
+// @Tags         disputes
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                             true   "Dispute ID"
+// @Param        If-Match header    string                             false  "Expected dispute version as returned in the ETag header"
+// @Param        status   body      wallet.UpdateDisputeStatusRequest  true   "New status for the dispute"
+// @Success      200      {object}  wallet.DisputeResponse
+// @Header       200      {string}  ETag  "Dispute version"
+// @Failure      400      {object}  apierror.Error
+// @Failure      404      {object}  apierror.Error
+// @Failure      409      {object}  apierror.Error
+// @Failure      422      {object}  apierror.Error
+// @Failure      500      {object}  apierror.Error
+// @Failure      503      {object}  apierror.Error
+// @Router       /v1/disputes/{id}/status [patch]
+func (c *Controller) UpdateDisputeStatus(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		jsonlib.SendBadRequestError(ctx, "Dispute ID is required")
+
+		return
+	}
+
+	expectedVersion, err := etag.ParseIfMatch(ctx)
+	if err != nil {
+		jsonlib.SendBadRequestError(ctx, err.Error())
+
+		return
+	}
+
+	var req wallet.UpdateDisputeStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	dispute, err := c.disputeSvc.UpdateDisputeStatus(ctx, id, string(req.Status), req.Note, expectedVersion)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	etag.Set(ctx, dispute.Version)
+	ctx.JSON(200, wallet.DisputeResponse{
+		Dispute: dispute.ToResponse(),
+	})
+}
+
+// AddDisputeNote godoc
+//
+// @Summary      Add dispute note
+// @Description  Attach evidence to a dispute that is not resolved yet, recorded under the X-Actor-ID caller
+// @ID addDisputeNote
+// @Tags         disputes
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                        true  "Dispute ID"
+// @Param        note  body      wallet.AddDisputeNoteRequest  true  "Evidence"
+// @Success      201   {object}  wallet.DisputeNoteResponse
+// @Failure      400   {object}  apierror.Error
+// @Failure      404   {object}  apierror.Error
+// @Failure      409   {object}  apierror.Error
+// @Failure      500   {object}  apierror.Error
+// @Failure      503   {object}  apierror.Error
+// @Router       /v1/disputes/{id}/notes [post]
+func (c *Controller) AddDisputeNote(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		jsonlib.SendBadRequestError(ctx, "Dispute ID is required")
+
+		return
+	}
+
+	var req wallet.AddDisputeNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	note, err := c.disputeSvc.AddDisputeNote(ctx, id, req.Note)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(201, wallet.DisputeNoteResponse{
+		DisputeNote: note.ToResponse(),
+	})
+}
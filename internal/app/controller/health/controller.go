@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+
+	// checkTimeout bounds each dependency check, so a hung dependency fails the probe instead of stalling it.
+	checkTimeout = 2 * time.Second
+)
+
+// Check reports whether a dependency the service needs to serve traffic is usable.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type ComponentStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Response struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+type Controller struct {
+	checks   []Check
+	draining atomic.Bool
+}
+
+func New(checks ...Check) *Controller {
+	return &Controller{
+		checks: checks,
+	}
+}
+
+// Drain fails readiness from now on, so load balancers stop routing new requests while in-flight ones
+// complete.
+func (c *Controller) Drain() {
+	c.draining.Store(true)
+}
+
+// Healthz godoc
+//
+// @Summary      Liveness probe
+// @Description  Succeeds as long as the process is serving HTTP.
+// @ID healthz
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Response
+// @Router       /healthz [get]
+func (c *Controller) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, Response{Status: StatusOK})
+}
+
+// Readyz godoc
+//
+// @Summary      Readiness probe
+// @Description  Checks Postgres, Redis and the migration version. Fails while the server shuts down.
+// @ID readyz
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Response
+// @Failure      503  {object}  health.Response
+// @Router       /readyz [get]
+func (c *Controller) Readyz(ctx *gin.Context) {
+	res := Response{
+		Status:     StatusOK,
+		Components: c.runChecks(ctx.Request.Context()),
+	}
+
+	for _, component := range res.Components {
+		if component.Status != StatusOK {
+			res.Status = StatusUnavailable
+		}
+	}
+
+	if c.draining.Load() {
+		res.Status = StatusDraining
+	}
+
+	if res.Status != StatusOK {
+		ctx.JSON(http.StatusServiceUnavailable, res)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *Controller) runChecks(ctx context.Context) map[string]ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		res = make(map[string]ComponentStatus, len(c.checks))
+	)
+
+	for _, check := range c.checks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			status := ComponentStatus{Status: StatusOK}
+			if err := check.Check(ctx); err != nil {
+				status = ComponentStatus{Status: StatusUnavailable, Error: err.Error()}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			res[check.Name] = status
+		}()
+	}
+
+	wg.Wait()
+
+	return res
+}
@@ -0,0 +1,98 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	healthCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name           string
+		redis          func(context.Context) error
+		drain          bool
+		expectedStatus int
+		expected       healthCtrl.Response
+	}{
+		{
+			name:           "all components ok",
+			redis:          ok,
+			expectedStatus: http.StatusOK,
+			expected: healthCtrl.Response{
+				Status: healthCtrl.StatusOK,
+				Components: map[string]healthCtrl.ComponentStatus{
+					"postgres": {Status: healthCtrl.StatusOK},
+					"redis":    {Status: healthCtrl.StatusOK},
+				},
+			},
+		},
+		{
+			name:           "failing component",
+			redis:          down,
+			expectedStatus: http.StatusServiceUnavailable,
+			expected: healthCtrl.Response{
+				Status: healthCtrl.StatusUnavailable,
+				Components: map[string]healthCtrl.ComponentStatus{
+					"postgres": {Status: healthCtrl.StatusOK},
+					"redis":    {Status: healthCtrl.StatusUnavailable, Error: "connection refused"},
+				},
+			},
+		},
+		{
+			name:           "draining",
+			redis:          ok,
+			drain:          true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expected: healthCtrl.Response{
+				Status: healthCtrl.StatusDraining,
+				Components: map[string]healthCtrl.ComponentStatus{
+					"postgres": {Status: healthCtrl.StatusOK},
+					"redis":    {Status: healthCtrl.StatusOK},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := healthCtrl.New(
+				healthCtrl.Check{Name: "postgres", Check: ok},
+				healthCtrl.Check{Name: "redis", Check: tt.redis},
+			)
+			if tt.drain {
+				controller.Drain()
+			}
+
+			engine := gin.New()
+			engine.GET("/healthz", controller.Healthz)
+			engine.GET("/readyz", controller.Readyz)
+
+			res := httptest.NewRecorder()
+			engine.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.expectedStatus, res.Code)
+
+			var body healthCtrl.Response
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, tt.expected, body)
+
+			// Liveness does not depend on the components nor on draining.
+			res = httptest.NewRecorder()
+			engine.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, http.StatusOK, res.Code)
+		})
+	}
+}
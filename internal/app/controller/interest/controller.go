@@ -0,0 +1,106 @@
+package interest
+
+import (
+	"context"
+	"time"
+
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	_ "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/gin-gonic/gin"
+)
+
+// monthLayout is the layout of PostInterestRequest.Month.
+const monthLayout = "2006-01"
+
+type interestService interface {
+	AccrueInterest(ctx context.Context, date time.Time) (int, error)
+	PostInterest(ctx context.Context, date time.Time) (int, error)
+}
+
+type Controller struct {
+	interestSvc interestService
+}
+
+func New(interestSvc interestService) *Controller {
+	return &Controller{
+		interestSvc: interestSvc,
+	}
+}
+
+// AccrueInterest godoc
+//
+// @Summary      Accrue interest
+// @Description  Accrue the interest wallets earned on a day that is over. A day only ever accrues once
+// @ID accrueInterest
+// @Tags         interest
+// @Accept       json
+// @Produce      json
+// @Param        accrual  body      wallet.AccrueInterestRequest  true  "Day to accrue"
+// @Success      200      {object}  wallet.InterestAccrualResponse
+// @Failure      400      {object}  apierror.Error
+// @Failure      422      {object}  apierror.Error
+// @Failure      500      {object}  apierror.Error
+// @Failure      503      {object}  apierror.Error
+// @Router       /v1/interest/accruals [post]
+func (c *Controller) AccrueInterest(ctx *gin.Context) {
+	var req wallet.AccrueInterestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	date, _ := time.Parse(svcModels.DateLayout, req.Date) // Validated by the binding.
+
+	accrued, err := c.interestSvc.AccrueInterest(ctx, date)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(200, wallet.InterestAccrualResponse{
+		Date:    req.Date,
+		Accrued: accrued,
+	})
+}
+
+// PostInterest godoc
+//
+// @Summary      Post interest
+// @Description  Pay out the interest wallets accrued in a month that is over, with a credit per wallet
+// @ID postInterest
+// @Tags         interest
+// @Accept       json
+// @Produce      json
+// @Param        payout  body      wallet.PostInterestRequest  true  "Month to pay out"
+// @Success      200     {object}  wallet.InterestPayoutResponse
+// @Failure      400     {object}  apierror.Error
+// @Failure      422     {object}  apierror.Error
+// @Failure      500     {object}  apierror.Error
+// @Failure      503     {object}  apierror.Error
+// @Router       /v1/interest/payouts [post]
+func (c *Controller) PostInterest(ctx *gin.Context) {
+	var req wallet.PostInterestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	month, _ := time.Parse(monthLayout, req.Month) // Validated by the binding.
+
+	posted, err := c.interestSvc.PostInterest(ctx, month)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(200, wallet.InterestPayoutResponse{
+		Month:  req.Month,
+		Posted: posted,
+	})
+}
@@ -0,0 +1,65 @@
+package risk
+
+import (
+	"context"
+
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	_ "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/gin-gonic/gin"
+)
+
+type riskService interface {
+	ListRiskDecisions(ctx context.Context, query svcModels.QueryRiskDecisions) (
+		svcModels.RiskDecisions, *pagination.Pagination, error)
+}
+
+type Controller struct {
+	riskSvc riskService
+}
+
+func New(riskSvc riskService) *Controller {
+	return &Controller{
+		riskSvc: riskSvc,
+	}
+}
+
+// ListRiskDecisions godoc
+//
+// @Summary      List risk decisions
+// @Description  List the decisions of the risk rules run before transactions were created, with the rules hit
+// @ID listRiskDecisions
+// @Tags         risk
+// @Accept       json
+// @Produce      json
+// @Param        query  query  wallet.ListRiskDecisionsRequest  true  "Query parameters"
+// @Success      200    {object}  wallet.RiskDecisionsResponse
+// @Failure      400    {object}  apierror.Error
+// @Failure      422    {object}  apierror.Error
+// @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
+// @Router       /v1/risk/decisions [get]
+func (c *Controller) ListRiskDecisions(ctx *gin.Context) {
+	var req wallet.ListRiskDecisionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	decisions, pagination, err := c.riskSvc.ListRiskDecisions(ctx, svcModels.QueryRiskDecisions{}.FromRequest(req))
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(200, wallet.RiskDecisionsResponse{
+		RiskDecisions: decisions.ToResponse(),
+		Metadata: wallet.Metadata{
+			Pagination: *pagination,
+		},
+	})
+}
@@ -0,0 +1,232 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	_ "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	_ "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// retryAfter is suggested to clients turned away by the connection limits.
+	retryAfter = 5 * time.Second
+	// reconnectDelay is how long browsers wait before reconnecting a dropped stream.
+	reconnectDelay = 3 * time.Second
+)
+
+type walletService interface {
+	GetWalletByID(ctx context.Context, id string) (svcModels.Wallet, error)
+}
+
+type eventStream interface {
+	Subscribe(ctx context.Context, walletID string) (<-chan events.Event, error)
+	Since(ctx context.Context, walletID, afterID string) ([]events.Event, error)
+}
+
+// Config bounds the resources held by event streams.
+type Config struct {
+	// HeartbeatInterval is how often a comment is sent on idle streams to keep proxies from closing them.
+	HeartbeatInterval time.Duration
+	// MaxConnections caps the streams served by one instance.
+	MaxConnections int
+	// MaxConnectionsPerWallet caps the streams of a single wallet on one instance.
+	MaxConnectionsPerWallet int
+	// Done ends every open stream when closed, so the server can shut down. Clients reconnect and resume.
+	Done <-chan struct{}
+}
+
+func DefaultConfig() Config {
+	return Config{
+		HeartbeatInterval:       15 * time.Second,
+		MaxConnections:          1000,
+		MaxConnectionsPerWallet: 5,
+	}
+}
+
+type Controller struct {
+	walletSvc walletService
+	events    eventStream
+	cfg       Config
+
+	mu          sync.Mutex
+	connections int
+	perWallet   map[string]int
+}
+
+func New(walletSvc walletService, events eventStream, cfg Config) *Controller {
+	return &Controller{
+		walletSvc: walletSvc,
+		events:    events,
+		cfg:       cfg,
+		perWallet: map[string]int{},
+	}
+}
+
+// StreamWalletEvents godoc
+//
+// @Summary      Stream wallet events
+// @Description  Server-sent events for the transactions of a wallet, each carrying the new balance.
+// @Description  Reconnect with Last-Event-ID to receive the events missed in between.
+// @ID streamWalletEvents
+// @Tags         wallets
+// @Produce      text/event-stream
+// @Param        id             path    string  true   "Wallet ID"
+// @Param        Last-Event-ID  header  string  false  "ID of the last event received"
+// @Success      200  {object}  wallet.WalletEvent
+// @Failure      400  {object}  apierror.Error
+// @Failure      404  {object}  apierror.Error
+// @Failure      429  {object}  apierror.Error
+// @Failure      500  {object}  apierror.Error
+// @Failure      503  {object}  apierror.Error
+// @Router       /v1/wallets/{id}/events [get]
+func (c *Controller) StreamWalletEvents(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		jsonlib.SendBadRequestError(ctx, "Wallet ID is required")
+
+		return
+	}
+
+	if _, err := c.walletSvc.GetWalletByID(ctx, id); err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	release, ok := c.acquire(ctx, id)
+	if !ok {
+		return
+	}
+	defer release()
+
+	streamCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	// Subscribing before reading the history ensures no event falls in between; duplicates are skipped.
+	live, err := c.events.Subscribe(streamCtx, id)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, services.Unavailable(err))
+
+		return
+	}
+
+	var missed []events.Event
+
+	if lastEventID := ctx.GetHeader("Last-Event-ID"); lastEventID != "" {
+		missed, err = c.events.Since(streamCtx, id, lastEventID)
+		if errors.Is(err, events.ErrInvalidEventID) {
+			jsonlib.SendBadRequestError(ctx, "Last-Event-ID is not a valid event ID")
+
+			return
+		}
+
+		if err != nil {
+			jsonlib.SendGenericAPIError(ctx, services.Unavailable(err))
+
+			return
+		}
+	}
+
+	// Streams outlive the server write timeout.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	ctx.Header("Content-Type", sse.ContentType)
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", reconnectDelay.Milliseconds())
+
+	sent := make(map[string]struct{}, len(missed))
+
+	for _, event := range missed {
+		c.send(ctx, event)
+		sent[event.ID] = struct{}{}
+	}
+
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(c.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-streamCtx.Done():
+			return
+		case <-c.cfg.Done:
+			return
+		case event, ok := <-live:
+			if !ok {
+				// The subscription was dropped for falling behind; the client resumes with Last-Event-ID.
+				return
+			}
+
+			if _, ok := sent[event.ID]; ok {
+				delete(sent, event.ID)
+
+				continue
+			}
+
+			c.send(ctx, event)
+		case <-heartbeat.C:
+			fmt.Fprint(ctx.Writer, ": heartbeat\n\n")
+		}
+
+		ctx.Writer.Flush()
+	}
+}
+
+func (c *Controller) send(ctx *gin.Context, event events.Event) {
+	ctx.Render(-1, sse.Event{
+		Id:    event.ID,
+		Event: event.Type.String(),
+		Data:  event.ToResponse(),
+	})
+}
+
+// acquire reserves a stream for the wallet, answering the request itself when a limit is reached.
+func (c *Controller) acquire(ctx *gin.Context, walletID string) (func(), bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.connections >= c.cfg.MaxConnections {
+		ctx.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		jsonlib.SendServiceUnavailableError(ctx)
+
+		return nil, false
+	}
+
+	if c.perWallet[walletID] >= c.cfg.MaxConnectionsPerWallet {
+		ctx.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		jsonlib.SendTooManyRequestsError(ctx, "too many event streams open for this wallet")
+
+		return nil, false
+	}
+
+	c.connections++
+	c.perWallet[walletID]++
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.connections--
+		c.perWallet[walletID]--
+
+		if c.perWallet[walletID] == 0 {
+			delete(c.perWallet, walletID)
+		}
+	}, true
+}
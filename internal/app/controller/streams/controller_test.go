@@ -0,0 +1,232 @@
+package streams_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	streamCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/streams"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/router"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	id    string
+	event string
+	data  wallet.WalletEvent
+}
+
+type fixture struct {
+	server   *httptest.Server
+	services router.Services
+	walletID string
+}
+
+func newFixture(t *testing.T, cfg streamCtrl.Config) fixture {
+	t.Helper()
+
+	require.NoError(t, types.RegisterValidations())
+	gin.SetMode(gin.TestMode)
+
+	store := memory.New(time.Now)
+	services := router.NewServices(router.Dependencies{
+		Wallets:      store.Wallets(),
+		Transactions: store.Transactions(),
+		Cache:        store.Cache(),
+		Locker:       store.Locker(),
+		Now:          time.Now,
+	})
+
+	engine := gin.New()
+	router.Register(engine.Group("api/v1"), services, router.WithStreamConfig(cfg))
+
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	created, err := services.Wallets.CreateWallet(context.Background(), models.CreateWalletRequest{
+		OwnerID:  "owner-1",
+		Currency: string(types.CurrencyUSD),
+		Status:   string(types.WalletStatusActive),
+	})
+	require.NoError(t, err)
+
+	return fixture{server: server, services: services, walletID: created.ID}
+}
+
+func (f fixture) open(t *testing.T, ctx context.Context, lastEventID string) (*http.Response, *bufio.Reader) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		f.server.URL+"/api/v1/wallets/"+f.walletID+"/events", nil)
+	require.NoError(t, err)
+
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+
+	return res, bufio.NewReader(res.Body)
+}
+
+func (f fixture) transact(t *testing.T, amount int, key string) models.Transaction {
+	t.Helper()
+
+	transaction, err := f.services.Transactions.CreateTransaction(context.Background(), models.CreateTransactionRequest{
+		WalletID:       f.walletID,
+		Amount:         amount,
+		Type:           string(types.TransactionTypeCredit),
+		IdempotencyKey: key,
+	})
+	require.NoError(t, err)
+
+	transaction, err = f.services.Transactions.UpdateTransactionStatus(context.Background(), transaction.ID,
+		string(types.TransactionStatusCompleted), nil)
+	require.NoError(t, err)
+
+	return transaction
+}
+
+// next reads the stream up to the next event, skipping the retry hint and heartbeats.
+func next(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+
+	var event sseEvent
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case line == "" && event.id != "":
+			return event
+		case strings.HasPrefix(line, "id:"):
+			event.id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "event:"):
+			event.event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &event.data))
+		}
+	}
+}
+
+func TestStreamWalletEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	f := newFixture(t, streamCtrl.DefaultConfig())
+
+	res, reader := f.open(t, ctx, "")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, res.Header.Get("Content-Type"), "text/event-stream")
+
+	transaction := f.transact(t, 100, "credit-1")
+
+	created := next(t, reader)
+	assert.Equal(t, types.EventTypeTransactionCreated.String(), created.event)
+	assert.Equal(t, transaction.ID, created.data.Transaction.ID)
+	assert.Equal(t, 0, *created.data.Balance)
+
+	completed := next(t, reader)
+	assert.Equal(t, types.EventTypeTransactionStatusUpdated.String(), completed.event)
+	assert.Equal(t, types.TransactionStatusCompleted, completed.data.Transaction.Status)
+	assert.Equal(t, 100, *completed.data.Balance)
+	assert.Equal(t, completed.id, completed.data.ID)
+}
+
+func TestStreamWalletEvents_Resume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	f := newFixture(t, streamCtrl.DefaultConfig())
+
+	first := f.transact(t, 100, "credit-1")
+	second := f.transact(t, 50, "credit-2")
+
+	history, err := f.services.Events.Since(ctx, f.walletID, "0")
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+
+	// Resuming after the first transaction completed replays only the second one.
+	res, reader := f.open(t, ctx, history[1].ID)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	replayed := next(t, reader)
+	assert.Equal(t, second.ID, replayed.data.Transaction.ID)
+	assert.Equal(t, types.TransactionStatusPending, replayed.data.Transaction.Status)
+
+	replayed = next(t, reader)
+	assert.Equal(t, second.ID, replayed.data.Transaction.ID)
+	assert.Equal(t, 150, *replayed.data.Balance)
+	assert.NotEqual(t, first.ID, replayed.data.Transaction.ID)
+
+	res, _ = f.open(t, ctx, "not-an-id")
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestStreamWalletEvents_Limits(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            streamCtrl.Config
+		expectedStatus int
+		expectedCode   types.ErrorCode
+	}{
+		{
+			name: "per wallet limit",
+			cfg: streamCtrl.Config{
+				HeartbeatInterval:       time.Second,
+				MaxConnections:          10,
+				MaxConnectionsPerWallet: 1,
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   types.ErrorCodeTooManyRequests,
+		},
+		{
+			name: "instance limit",
+			cfg: streamCtrl.Config{
+				HeartbeatInterval:       time.Second,
+				MaxConnections:          1,
+				MaxConnectionsPerWallet: 10,
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   types.ErrorCodeUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			f := newFixture(t, tt.cfg)
+
+			res, _ := f.open(t, ctx, "")
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			res, _ = f.open(t, ctx, "")
+			assert.Equal(t, tt.expectedStatus, res.StatusCode)
+			assert.Equal(t, "5", res.Header.Get("Retry-After"))
+
+			var body struct {
+				Code types.ErrorCode `json:"code"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, tt.expectedCode, body.Code)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package transactions
+
+import (
+	"context"
+
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	_ "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/etag"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/gin-gonic/gin"
+)
+
+// ApproveTransaction godoc
+//
+// @Summary      Approve transaction
+// @Description  Approve a transaction awaiting approval, which becomes pending. The approver is identified
+// @Description  by the X-Actor-ID header and must not be the maker of the transaction.
+// @ID approveTransaction
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        id          path    string                           true  "Transaction ID"
+// @Param        X-Actor-ID  header  string                           true  "ID of the approver"
+// @Param        review      body    wallet.ReviewTransactionRequest  true  "Reason for the approval"
+// @Success      200    {object}  wallet.TransactionResponse
+// @Header       200    {string}  ETag  "Transaction version"
+// @Failure      400    {object}  apierror.Error
+// @Failure      403    {object}  apierror.Error
+// @Failure      404    {object}  apierror.Error
+// @Failure      409    {object}  apierror.Error
+// @Failure      422    {object}  apierror.Error
+// @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
+// @Router       /v1/transactions/{id}/approve [post]
+func (c *Controller) ApproveTransaction(ctx *gin.Context) {
+	c.review(ctx, c.transactionSvc.ApproveTransaction)
+}
+
+// RejectTransaction godoc
+//
+// @Summary      Reject transaction
+// @Description  Reject a transaction awaiting approval. The approver is identified by the X-Actor-ID header
+// @Description  and must not be the maker of the transaction.
+// @ID rejectTransaction
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        id          path    string                           true  "Transaction ID"
+// @Param        X-Actor-ID  header  string                           true  "ID of the approver"
+// @Param        review      body    wallet.ReviewTransactionRequest  true  "Reason for the rejection"
+// @Success      200    {object}  wallet.TransactionResponse
+// @Header       200    {string}  ETag  "Transaction version"
+// @Failure      400    {object}  apierror.Error
+// @Failure      403    {object}  apierror.Error
+// @Failure      404    {object}  apierror.Error
+// @Failure      409    {object}  apierror.Error
+// @Failure      422    {object}  apierror.Error
+// @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
+// @Router       /v1/transactions/{id}/reject [post]
+func (c *Controller) RejectTransaction(ctx *gin.Context) {
+	c.review(ctx, c.transactionSvc.RejectTransaction)
+}
+
+// ListTransactionApprovals godoc
+//
+// @Summary      List transaction approvals
+// @Description  List the approval trail of a transaction, oldest entry first
+// @ID listTransactionApprovals
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Transaction ID"
+// @Success      200  {object}  wallet.TransactionApprovalsResponse
+// @Failure      400  {object}  apierror.Error
+// @Failure      404  {object}  apierror.Error
+// @Failure      500  {object}  apierror.Error
+// @Failure      503  {object}  apierror.Error
+// @Router       /v1/transactions/{id}/approvals [get]
+func (c *Controller) ListTransactionApprovals(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		jsonlib.SendBadRequestError(ctx, "Transaction ID is required")
+
+		return
+	}
+
+	approvals, err := c.transactionSvc.ListTransactionApprovals(ctx, id)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(200, wallet.TransactionApprovalsResponse{
+		Approvals: approvals.ToResponse(),
+	})
+}
+
+func (c *Controller) review(
+	ctx *gin.Context,
+	decide func(ctx context.Context, id, reason string) (svcModels.Transaction, error),
+) {
+	id := ctx.Param("id")
+	if id == "" {
+		jsonlib.SendBadRequestError(ctx, "Transaction ID is required")
+
+		return
+	}
+
+	var req wallet.ReviewTransactionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	transaction, err := decide(ctx, id, req.Reason)
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	etag.Set(ctx, transaction.Version)
+	ctx.JSON(200, wallet.TransactionResponse{
+		Transaction: transaction.ToResponse(),
+	})
+}
@@ -6,6 +6,7 @@ import (
 	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
 	_ "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
 	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/etag"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
 	"github.com/gin-gonic/gin"
@@ -13,11 +14,16 @@ import (
 
 type transactionService interface {
 	GetTransactionByID(ctx context.Context, id string) (svcModels.Transaction, error)
-	UpdateTransactionStatus(ctx context.Context, id string, status string) (svcModels.Transaction, error)
+	UpdateTransactionStatus(ctx context.Context, id string, status string, expectedVersion *int) (
+		svcModels.Transaction, error)
 	ListTransactions(ctx context.Context, query svcModels.QueryTransactions) (
 		svcModels.Transactions, *pagination.Pagination, error)
 	CreateTransaction(ctx context.Context, transaction svcModels.CreateTransactionRequest) (
 		svcModels.Transaction, error)
+	ApproveTransaction(ctx context.Context, id, reason string) (svcModels.Transaction, error)
+	RejectTransaction(ctx context.Context, id, reason string) (svcModels.Transaction, error)
+	ListTransactionApprovals(ctx context.Context, id string) (svcModels.TransactionApprovals, error)
+	QuoteTransaction(ctx context.Context, req svcModels.QuoteTransactionRequest) (svcModels.TransactionQuote, error)
 }
 
 type Controller struct {
@@ -40,10 +46,12 @@ func New(transactionSvc transactionService) *Controller {
 // @Produce      json
 // @Param        id   path      string  true  "Transaction ID"
 // @Success      200  {object}  wallet.TransactionResponse
+// @Header       200  {string}  ETag  "Transaction version"
 // @Failure      400  {object}  apierror.Error
 // @Failure      404  {object}  apierror.Error
 // @Failure      422  {object}  apierror.Error
 // @Failure      500  {object}  apierror.Error
+// @Failure      503  {object}  apierror.Error
 // @Router       /v1/transactions/{id} [get]
 func (c *Controller) GetTransactionByID(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -60,6 +68,7 @@ func (c *Controller) GetTransactionByID(ctx *gin.Context) {
 		return
 	}
 
+	etag.Set(ctx, transaction.Version)
 	ctx.JSON(200, wallet.TransactionResponse{
 		Transaction: transaction.ToResponse(),
 	})
@@ -74,12 +83,16 @@ func (c *Controller) GetTransactionByID(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id     path      string  true  "Transaction ID"
+// @Param        If-Match header  string  false "Expected transaction version as returned in the ETag header"
 // @Param        status body      string  true  "New status for the transaction"
 // @Success      200    {object}  wallet.TransactionResponse
+// @Header       200    {string}  ETag  "Transaction version"
 // @Failure      400    {object}  apierror.Error
 // @Failure      404    {object}  apierror.Error
+// @Failure      409    {object}  apierror.Error
 // @Failure      422    {object}  apierror.Error
 // @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
 // @Router       /v1/transactions/{id}/status [put]
 func (c *Controller) UpdateTransactionStatus(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -89,6 +102,13 @@ func (c *Controller) UpdateTransactionStatus(ctx *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := etag.ParseIfMatch(ctx)
+	if err != nil {
+		jsonlib.SendBadRequestError(ctx, err.Error())
+
+		return
+	}
+
 	var req wallet.UpdateTransactionStatusRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		jsonlib.SendApiValidationError(ctx, err)
@@ -96,13 +116,14 @@ func (c *Controller) UpdateTransactionStatus(ctx *gin.Context) {
 		return
 	}
 
-	transaction, err := c.transactionSvc.UpdateTransactionStatus(ctx, id, string(req.Status))
+	transaction, err := c.transactionSvc.UpdateTransactionStatus(ctx, id, string(req.Status), expectedVersion)
 	if err != nil {
 		jsonlib.SendGenericAPIError(ctx, err)
 
 		return
 	}
 
+	etag.Set(ctx, transaction.Version)
 	ctx.JSON(200, wallet.TransactionResponse{
 		Transaction: transaction.ToResponse(),
 	})
@@ -111,17 +132,18 @@ func (c *Controller) UpdateTransactionStatus(ctx *gin.Context) {
 // ListTransactions godoc
 //
 // @Summary      List transactions
-// @Description  List transactions with pagination
+// @Description  List transactions with filtering, sorting and pagination
 // @ID listTransactions
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
-// @Param        query  wallet.ListTransactionsRequest  true  "Query parameters"
+// @Param        query  query  wallet.ListTransactionsRequest  true  "Query parameters"
 // @Success      200    {object}  wallet.TransactionsResponse
 // @Failure      400    {object}  apierror.Error
 // @Failure      404    {object}  apierror.Error
 // @Failure      422    {object}  apierror.Error
 // @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
 // @Router       /v1/transactions [get]
 func (c *Controller) ListTransactions(ctx *gin.Context) {
 	var req wallet.ListTransactionsRequest
@@ -131,6 +153,12 @@ func (c *Controller) ListTransactions(ctx *gin.Context) {
 		return
 	}
 
+	if err := req.Validate(); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
 	transactions, pagination, err := c.transactionSvc.ListTransactions(ctx, svcModels.QueryTransactions{}.FromRequest(req))
 	if err != nil {
 		jsonlib.SendGenericAPIError(ctx, err)
@@ -149,17 +177,20 @@ func (c *Controller) ListTransactions(ctx *gin.Context) {
 // CreateTransaction godoc
 //
 // @Summary      Create transaction
-// @Description  Create a new transaction
+// @Description  Create a new transaction. Transactions that await approval need the X-Actor-ID of their maker.
 // @ID createTransaction
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
+// @Param        X-Actor-ID   header    string                           false  "ID of the maker"
 // @Param        transaction  body      wallet.CreateTransactionRequest  true  "Transaction data"
 // @Success      201    {object}  wallet.TransactionResponse
 // @Failure      400    {object}  apierror.Error
+// @Failure      403    {object}  apierror.Error
 // @Failure      404    {object}  apierror.Error
 // @Failure      422    {object}  apierror.Error
 // @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
 // @Router       /v1/transactions [post]
 func (c *Controller) CreateTransaction(ctx *gin.Context) {
 	var req wallet.CreateTransactionRequest
@@ -169,6 +200,12 @@ func (c *Controller) CreateTransaction(ctx *gin.Context) {
 		return
 	}
 
+	if err := req.Validate(); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
 	transaction, err := c.transactionSvc.CreateTransaction(ctx, svcModels.CreateTransactionRequest{}.FromRequest(req))
 	if err != nil {
 		jsonlib.SendGenericAPIError(ctx, err)
@@ -180,3 +217,39 @@ func (c *Controller) CreateTransaction(ctx *gin.Context) {
 		Transaction: transaction.ToResponse(),
 	})
 }
+
+// QuoteTransaction godoc
+//
+// @Summary      Quote transaction
+// @Description  Preview the fees a transaction would be charged, without creating it
+// @ID quoteTransaction
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        quote  body      wallet.QuoteTransactionRequest  true  "Transaction to quote"
+// @Success      200    {object}  wallet.TransactionQuoteResponse
+// @Failure      400    {object}  apierror.Error
+// @Failure      404    {object}  apierror.Error
+// @Failure      422    {object}  apierror.Error
+// @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
+// @Router       /v1/transactions/quote [post]
+func (c *Controller) QuoteTransaction(ctx *gin.Context) {
+	var req wallet.QuoteTransactionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		jsonlib.SendApiValidationError(ctx, err)
+
+		return
+	}
+
+	quote, err := c.transactionSvc.QuoteTransaction(ctx, svcModels.QuoteTransactionRequest{}.FromRequest(req))
+	if err != nil {
+		jsonlib.SendGenericAPIError(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(200, wallet.TransactionQuoteResponse{
+		Quote: quote.ToResponse(),
+	})
+}
@@ -6,6 +6,7 @@ import (
 	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
 	_ "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
 	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/etag"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
 	"github.com/gin-gonic/gin"
@@ -13,7 +14,7 @@ import (
 
 type walletService interface {
 	GetWalletByID(ctx context.Context, id string) (svcModels.Wallet, error)
-	UpdateWalletStatus(ctx context.Context, id, status string) (svcModels.Wallet, error)
+	UpdateWalletStatus(ctx context.Context, id, status string, expectedVersion *int) (svcModels.Wallet, error)
 	ListWallets(ctx context.Context, query svcModels.QueryWallets) (svcModels.Wallets, *pagination.Pagination, error)
 	CreateWallet(ctx context.Context, wallet svcModels.CreateWalletRequest) (svcModels.Wallet, error)
 	GetWalletWithBalance(ctx context.Context, id string) (svcModels.Wallet, error)
@@ -39,10 +40,12 @@ func New(walletSvc walletService) *Controller {
 // @Produce      json
 // @Param        id   path      string  true  "Wallet ID"
 // @Success      200  {object}  wallet.WalletResponse
+// @Header       200  {string}  ETag  "Wallet version"
 // @Failure      400  {object}  apierror.Error
 // @Failure      404  {object}  apierror.Error
 // @Failure      422  {object}  apierror.Error
 // @Failure      500  {object}  apierror.Error
+// @Failure      503  {object}  apierror.Error
 // @Router       /v1/wallets/{id} [get]
 func (c *Controller) GetWalletByID(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -59,6 +62,7 @@ func (c *Controller) GetWalletByID(ctx *gin.Context) {
 		return
 	}
 
+	etag.Set(ctx, walletResp.Version)
 	ctx.JSON(200, wallet.WalletResponse{
 		Wallet: walletResp.ToResponse(),
 	})
@@ -73,12 +77,16 @@ func (c *Controller) GetWalletByID(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id     path      string  true  "Wallet ID"
+// @Param        If-Match header  string  false "Expected wallet version as returned in the ETag header"
 // @Param        status body      string  true  "New wallet status"
 // @Success      200    {object}  wallet.WalletResponse
+// @Header       200    {string}  ETag  "Wallet version"
 // @Failure      400    {object}  apierror.Error
 // @Failure      404    {object}  apierror.Error
+// @Failure      409    {object}  apierror.Error
 // @Failure      422    {object}  apierror.Error
 // @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
 // @Router       /v1/wallets/{id}/status [patch]
 func (c *Controller) UpdateWalletStatus(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -88,6 +96,13 @@ func (c *Controller) UpdateWalletStatus(ctx *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := etag.ParseIfMatch(ctx)
+	if err != nil {
+		jsonlib.SendBadRequestError(ctx, err.Error())
+
+		return
+	}
+
 	req := wallet.UpdateWalletStatusRequest{}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		jsonlib.SendApiValidationError(ctx, err)
@@ -95,13 +110,14 @@ func (c *Controller) UpdateWalletStatus(ctx *gin.Context) {
 		return
 	}
 
-	walletResp, err := c.walletSvc.UpdateWalletStatus(ctx, id, string(req.Status))
+	walletResp, err := c.walletSvc.UpdateWalletStatus(ctx, id, string(req.Status), expectedVersion)
 	if err != nil {
 		jsonlib.SendGenericAPIError(ctx, err)
 
 		return
 	}
 
+	etag.Set(ctx, walletResp.Version)
 	ctx.JSON(200, wallet.WalletResponse{
 		Wallet: walletResp.ToResponse(),
 	})
@@ -115,10 +131,11 @@ func (c *Controller) UpdateWalletStatus(ctx *gin.Context) {
 // @Tags         wallets
 // @Accept       json
 // @Produce      json
-// @Param        query  wallet.ListWalletsRequest  false  "Query params"
+// @Param        query  query  wallet.ListWalletsRequest  false  "Query params"
 // @Success      200    {object}  wallet.WalletsResponse
 // @Failure      400    {object}  apierror.Error
 // @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
 // @Router       /v1/wallets [get]
 func (c *Controller) ListWallets(ctx *gin.Context) {
 	var query wallet.ListWalletsRequest
@@ -154,8 +171,10 @@ func (c *Controller) ListWallets(ctx *gin.Context) {
 // @Param        wallet body      wallet.CreateWalletRequest  true  "Wallet data"
 // @Success      201    {object}  wallet.WalletResponse
 // @Failure      400    {object}  apierror.Error
+// @Failure      409    {object}  apierror.Error
 // @Failure      422    {object}  apierror.Error
 // @Failure      500    {object}  apierror.Error
+// @Failure      503    {object}  apierror.Error
 // @Router       /v1/wallets [post]
 func (c *Controller) CreateWallet(ctx *gin.Context) {
 	var req wallet.CreateWalletRequest
@@ -191,6 +210,7 @@ func (c *Controller) CreateWallet(ctx *gin.Context) {
 // @Failure      404  {object}  apierror.Error
 // @Failure      422  {object}	apierror.Error
 // @Failure      500  {object}  apierror.Error
+// @Failure      503  {object}  apierror.Error
 // @Router       /v1/wallets/{id}/balance [get]
 func (c *Controller) GetWalletWithBalance(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+const (
+	// subscriberBuffer is how many events a subscriber may lag behind before it is disconnected.
+	subscriberBuffer = 64
+	// defaultHistorySize is how many recent events of each wallet are kept for resuming by default.
+	defaultHistorySize = 100
+)
+
+// Broker fans events out to subscribers within the process.
+type Broker struct {
+	mu          sync.Mutex
+	sequence    uint64
+	subscribers map[string]map[*subscription]struct{}
+	history     map[string][]Event
+	historySize int
+}
+
+// BrokerOption configures a Broker.
+type BrokerOption func(*Broker)
+
+// WithHistory sets how many recent events of each wallet are kept for Since. Zero keeps none, for
+// brokers that only fan out events whose history is kept elsewhere.
+func WithHistory(size int) BrokerOption {
+	return func(b *Broker) {
+		b.historySize = size
+	}
+}
+
+type subscription struct {
+	events chan Event
+	closed bool
+}
+
+func NewBroker(opts ...BrokerOption) *Broker {
+	b := &Broker{
+		subscribers: map[string]map[*subscription]struct{}{},
+		history:     map[string][]Event{},
+		historySize: defaultHistorySize,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Publish assigns the event the next sequence number and dispatches it.
+func (b *Broker) Publish(_ context.Context, event Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	event.ID = strconv.FormatUint(b.sequence, 10)
+	b.dispatch(event)
+
+	return nil
+}
+
+// Dispatch delivers an event that already has an ID to the current subscribers of its wallet. Subscribers
+// that fell too far behind are disconnected rather than allowed to block publishing; they can subscribe again.
+func (b *Broker) Dispatch(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.dispatch(event)
+}
+
+// dispatch records and delivers the event. The broker lock must be held.
+func (b *Broker) dispatch(event Event) {
+	if b.historySize > 0 {
+		history := append(b.history[event.WalletID], event)
+		if len(history) > b.historySize {
+			history = history[len(history)-b.historySize:]
+		}
+
+		b.history[event.WalletID] = history
+	}
+
+	for sub := range b.subscribers[event.WalletID] {
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(event.WalletID, sub)
+		}
+	}
+}
+
+func (b *Broker) Subscribe(ctx context.Context, walletID string) (<-chan Event, error) {
+	sub := &subscription{events: make(chan Event, subscriberBuffer)}
+
+	b.mu.Lock()
+	if b.subscribers[walletID] == nil {
+		b.subscribers[walletID] = map[*subscription]struct{}{}
+	}
+
+	b.subscribers[walletID][sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.remove(walletID, sub)
+	}()
+
+	return sub.events, nil
+}
+
+// Since returns the events published by this broker after afterID, from the recent events it kept.
+func (b *Broker) Since(_ context.Context, walletID, afterID string) ([]Event, error) {
+	after, err := strconv.ParseUint(afterID, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidEventID
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var res []Event
+
+	for _, event := range b.history[walletID] {
+		if id, _ := strconv.ParseUint(event.ID, 10, 64); id > after {
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// remove closes the subscription. The broker lock must be held.
+func (b *Broker) remove(walletID string, sub *subscription) {
+	if sub.closed {
+		return
+	}
+
+	sub.closed = true
+	close(sub.events)
+
+	delete(b.subscribers[walletID], sub)
+
+	if len(b.subscribers[walletID]) == 0 {
+		delete(b.subscribers, walletID)
+	}
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	pkg "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+)
+
+// ErrInvalidEventID is returned when resuming after an event ID the publisher could not have issued.
+var ErrInvalidEventID = errors.New("invalid event id")
+
+type Type string
+
+const (
+	TypeTransactionCreated       = Type(types.EventTypeTransactionCreated)
+	TypeTransactionStatusUpdated = Type(types.EventTypeTransactionStatusUpdated)
+)
+
+func (t Type) String() string {
+	return string(t)
+}
+
+// Event reports a change to a transaction. Events are scoped to the wallet the transaction belongs to.
+type Event struct {
+	// ID is assigned by the broker on publish and increases with every event of a wallet.
+	ID          string
+	Type        Type
+	WalletID    string
+	Transaction models.Transaction
+	// Balance is the wallet balance after the change, nil when it could not be determined.
+	Balance    *int
+	OccurredAt time.Time
+}
+
+func TransactionCreated(transaction models.Transaction, balance *int, at time.Time) Event {
+	return Event{
+		Type:        TypeTransactionCreated,
+		WalletID:    transaction.WalletID,
+		Transaction: transaction,
+		Balance:     balance,
+		OccurredAt:  at,
+	}
+}
+
+func TransactionStatusUpdated(transaction models.Transaction, balance *int, at time.Time) Event {
+	return Event{
+		Type:        TypeTransactionStatusUpdated,
+		WalletID:    transaction.WalletID,
+		Transaction: transaction,
+		Balance:     balance,
+		OccurredAt:  at,
+	}
+}
+
+func (e Event) ToResponse() pkg.WalletEvent {
+	return pkg.WalletEvent{
+		ID:          e.ID,
+		Type:        types.EventType(e.Type),
+		WalletID:    e.WalletID,
+		Transaction: e.Transaction.ToResponse(),
+		Balance:     e.Balance,
+		OccurredAt:  e.OccurredAt,
+	}
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+type Subscriber interface {
+	// Subscribe delivers the events of a wallet published from now on. The channel is closed once ctx is done.
+	Subscribe(ctx context.Context, walletID string) (<-chan Event, error)
+}
+
+type History interface {
+	// Since returns the retained events of a wallet published after the event with the given ID, oldest
+	// first. Events are retained for a limited time, so clients that were away too long miss some.
+	Since(ctx context.Context, walletID, afterID string) ([]Event, error)
+}
+
+// Bus publishes events and lets clients follow and resume them.
+type Bus interface {
+	Publisher
+	Subscriber
+	History
+}
@@ -0,0 +1,221 @@
+// Package fees computes the fees charged on transactions from a schedule of rules. Amounts are in minor
+// units and percentages in basis points, so fees are computed with integers only.
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+type Kind string
+
+const (
+	// KindFixed charges Fixed whatever the amount.
+	KindFixed Kind = "fixed"
+	// KindPercentage charges BasisPoints of the amount.
+	KindPercentage Kind = "percentage"
+	// KindTiered charges the Fixed and BasisPoints of the first tier the amount falls in.
+	KindTiered Kind = "tiered"
+)
+
+func GetKinds() []Kind {
+	return []Kind{KindFixed, KindPercentage, KindTiered}
+}
+
+// Tier is a band of amounts of a tiered rule, from the end of the previous tier up to UpTo included.
+type Tier struct {
+	// UpTo is the largest amount of the tier. Zero makes the tier unbounded, which only the last may be.
+	UpTo        int `mapstructure:"up_to"`
+	Fixed       int `mapstructure:"fixed"`
+	BasisPoints int `mapstructure:"basis_points"`
+}
+
+// Rule charges a fee on the transactions of a currency and type. Every matching rule of a schedule
+// charges its own fee.
+type Rule struct {
+	// Name identifies the fee in quotes and in the note of the fee transactions.
+	Name string `mapstructure:"name"`
+	// Currency and Type restrict the rule to transactions in that currency and of that type. Empty
+	// matches any.
+	Currency string `mapstructure:"currency"`
+	Type     string `mapstructure:"type"`
+	Kind     Kind   `mapstructure:"kind"`
+	// Fixed and BasisPoints apply to the fixed and percentage kinds respectively.
+	Fixed       int `mapstructure:"fixed"`
+	BasisPoints int `mapstructure:"basis_points"`
+	// Min and Max bound the fee. Zero leaves it unbounded.
+	Min   int    `mapstructure:"min"`
+	Max   int    `mapstructure:"max"`
+	Tiers []Tier `mapstructure:"tiers"`
+}
+
+// Fee is the fee a rule charges on a transaction.
+type Fee struct {
+	Rule   string
+	Amount int
+}
+
+type Fees []Fee
+
+func (f Fees) Total() int {
+	total := 0
+	for _, fee := range f {
+		total += fee.Amount
+	}
+
+	return total
+}
+
+// Schedule is the set of fee rules along with the wallets collecting the fees.
+type Schedule struct {
+	Rules []Rule
+	// IncomeWallets maps each currency to the ID of the wallet its fees are credited to.
+	IncomeWallets map[string]string
+}
+
+// NewSchedule returns a schedule of rules crediting fees to incomeWallets. Currencies are matched
+// regardless of case, as configuration keys lose theirs.
+func NewSchedule(rules []Rule, incomeWallets map[string]string) Schedule {
+	wallets := make(map[string]string, len(incomeWallets))
+	for currency, walletID := range incomeWallets {
+		wallets[strings.ToUpper(currency)] = walletID
+	}
+
+	return Schedule{Rules: rules, IncomeWallets: wallets}
+}
+
+// IncomeWallet returns the ID of the wallet collecting the fees charged in currency.
+func (s Schedule) IncomeWallet(currency string) (string, bool) {
+	walletID, found := s.IncomeWallets[strings.ToUpper(currency)]
+
+	return walletID, found
+}
+
+// Quote returns the fees charged on a transaction, leaving out rules that charge nothing.
+func (s Schedule) Quote(currency, transactionType string, amount int) Fees {
+	var fees Fees
+
+	for _, rule := range s.Rules {
+		if !rule.matches(currency, transactionType) {
+			continue
+		}
+
+		if fee := rule.charge(amount); fee > 0 {
+			fees = append(fees, Fee{Rule: rule.Name, Amount: fee})
+		}
+	}
+
+	return fees
+}
+
+func (r Rule) matches(currency, transactionType string) bool {
+	return (r.Currency == "" || strings.EqualFold(r.Currency, currency)) &&
+		(r.Type == "" || r.Type == transactionType)
+}
+
+func (r Rule) charge(amount int) int {
+	var fee int
+
+	switch r.Kind {
+	case KindFixed:
+		fee = r.Fixed
+	case KindPercentage:
+		fee = percentage(amount, r.BasisPoints)
+	case KindTiered:
+		for _, tier := range r.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = tier.Fixed + percentage(amount, tier.BasisPoints)
+
+				break
+			}
+		}
+	}
+
+	if r.Min > 0 && fee < r.Min {
+		fee = r.Min
+	}
+
+	if r.Max > 0 && fee > r.Max {
+		fee = r.Max
+	}
+
+	return fee
+}
+
+// percentage returns basisPoints of amount, rounded half up to the minor unit.
+func percentage(amount, basisPoints int) int {
+	return (amount*basisPoints + 5000) / 10000
+}
+
+// Validate reports every invalid rule at once, along with the currencies fees could be charged in
+// without an income wallet to credit them to.
+func (s Schedule) Validate() error {
+	var problems []string
+
+	check := func(ok bool, rule int, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf("rule %d: %s", rule, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	names := map[string]bool{}
+
+	for i, rule := range s.Rules {
+		check(rule.Name != "" && !names[rule.Name], i, "name must be set and unique, got %q", rule.Name)
+		names[rule.Name] = true
+
+		currency := types.Currency(strings.ToUpper(rule.Currency))
+		check(currency == "" || slices.Contains(types.GetCurrencies(), currency), i,
+			"currency must be one of %v, got %q", types.GetCurrencies(), rule.Currency)
+		check(rule.Type == "" || slices.Contains(types.GetTransactionTypes(), types.TransactionType(rule.Type)), i,
+			"type must be one of %v, got %q", types.GetTransactionTypes(), rule.Type)
+		check(slices.Contains(GetKinds(), rule.Kind), i, "kind must be one of %v, got %q", GetKinds(), rule.Kind)
+		check(rule.Fixed >= 0 && rule.Min >= 0 && rule.Max >= 0, i, "amounts must not be negative")
+		check(rule.BasisPoints >= 0 && rule.BasisPoints <= 10000, i, "basis_points must be between 0 and 10000")
+		check(rule.Max == 0 || rule.Min <= rule.Max, i, "min must not be greater than max")
+		check(rule.Kind != KindTiered || validTiers(rule.Tiers), i,
+			"tiers must have increasing up_to, end with an unbounded tier and charge no negative fee")
+
+		for _, currency := range rule.currencies() {
+			_, found := s.IncomeWallet(currency)
+			check(found, i, "needs an income wallet for %s", currency)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// currencies returns the currencies the rule charges fees in.
+func (r Rule) currencies() []string {
+	if r.Currency != "" {
+		return []string{strings.ToUpper(r.Currency)}
+	}
+
+	return types.Currencies(types.GetCurrencies()).String()
+}
+
+func validTiers(tiers []Tier) bool {
+	if len(tiers) == 0 || tiers[len(tiers)-1].UpTo != 0 {
+		return false
+	}
+
+	for i, tier := range tiers {
+		if tier.Fixed < 0 || tier.BasisPoints < 0 || tier.BasisPoints > 10000 {
+			return false
+		}
+
+		if i < len(tiers)-1 && (tier.UpTo <= 0 || (i > 0 && tier.UpTo <= tiers[i-1].UpTo)) {
+			return false
+		}
+	}
+
+	return true
+}
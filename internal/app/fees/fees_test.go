@@ -0,0 +1,97 @@
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Quote(t *testing.T) {
+	schedule := NewSchedule([]Rule{
+		{Name: "withdrawal", Currency: "USD", Type: "debit", Kind: KindPercentage, BasisPoints: 150, Min: 50, Max: 500},
+		{Name: "processing", Type: "debit", Kind: KindFixed, Fixed: 10},
+		{Name: "deposit", Currency: "eur", Type: "credit", Kind: KindTiered, Tiers: []Tier{
+			{UpTo: 10000, Fixed: 100},
+			{UpTo: 100000, Fixed: 50, BasisPoints: 25},
+			{BasisPoints: 10},
+		}},
+	}, map[string]string{"usd": "fees-usd", "EUR": "fees-eur"})
+
+	tests := []struct {
+		name     string
+		currency string
+		txType   string
+		amount   int
+		expected Fees
+	}{
+		{
+			name: "percentage below the minimum", currency: "USD", txType: "debit", amount: 1000,
+			expected: Fees{{Rule: "withdrawal", Amount: 50}, {Rule: "processing", Amount: 10}},
+		},
+		{
+			name: "percentage rounds half up", currency: "USD", txType: "debit", amount: 10033,
+			expected: Fees{{Rule: "withdrawal", Amount: 150}, {Rule: "processing", Amount: 10}},
+		},
+		{
+			name: "percentage above the maximum", currency: "USD", txType: "debit", amount: 1000000,
+			expected: Fees{{Rule: "withdrawal", Amount: 500}, {Rule: "processing", Amount: 10}},
+		},
+		{
+			name: "other currency", currency: "GBP", txType: "debit", amount: 1000,
+			expected: Fees{{Rule: "processing", Amount: 10}},
+		},
+		{
+			name: "first tier includes its bound", currency: "EUR", txType: "credit", amount: 10000,
+			expected: Fees{{Rule: "deposit", Amount: 100}},
+		},
+		{
+			name: "middle tier", currency: "EUR", txType: "credit", amount: 20000,
+			expected: Fees{{Rule: "deposit", Amount: 100}},
+		},
+		{
+			name: "unbounded tier", currency: "EUR", txType: "credit", amount: 500000,
+			expected: Fees{{Rule: "deposit", Amount: 500}},
+		},
+		{
+			name: "no matching rule", currency: "USD", txType: "credit", amount: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fees := schedule.Quote(tt.currency, tt.txType, tt.amount)
+
+			assert.Equal(t, tt.expected, fees)
+		})
+	}
+
+	walletID, found := schedule.IncomeWallet("USD")
+	require.True(t, found)
+	assert.Equal(t, "fees-usd", walletID)
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	require.NoError(t, NewSchedule([]Rule{
+		{Name: "withdrawal", Currency: "USD", Type: "debit", Kind: KindFixed, Fixed: 10},
+	}, map[string]string{"USD": "fees-usd"}).Validate())
+
+	err := NewSchedule([]Rule{
+		{Name: "any", Kind: KindFixed, Fixed: 10},
+		{Name: "any", Currency: "USD", Kind: "flat"},
+		{Name: "tiers", Currency: "USD", Kind: KindTiered, Tiers: []Tier{{UpTo: 100}, {UpTo: 50}}},
+		{Name: "bounds", Currency: "USD", Kind: KindPercentage, BasisPoints: 20000, Min: 10, Max: 5},
+	}, map[string]string{"USD": "fees-usd"}).Validate()
+	require.Error(t, err)
+
+	for _, problem := range []string{
+		"rule 0: needs an income wallet for EUR",
+		`rule 1: name must be set and unique, got "any"`,
+		`rule 1: kind must be one of [fixed percentage tiered], got "flat"`,
+		"rule 2: tiers must have increasing up_to",
+		"rule 3: basis_points must be between 0 and 10000",
+		"rule 3: min must not be greater than max",
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+}
@@ -0,0 +1,22 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataActorID is the metadata key of the caller identity, the gRPC counterpart of X-Actor-ID.
+const metadataActorID = "x-actor-id"
+
+// withActor stores the caller identity sent in the request metadata in the request context.
+func withActor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if ids := metadata.ValueFromIncomingContext(ctx, metadataActorID); len(ids) > 0 &&
+		ids[0] != "" && len(ids[0]) <= actor.MaxIDLength {
+		ctx = actor.WithID(ctx, ids[0])
+	}
+
+	return handler(ctx, req)
+}
@@ -0,0 +1,185 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	walletv1 "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/pb/wallet/v1"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//nolint:gosec
+func toWallet(w svcModels.Wallet) *walletv1.Wallet {
+	res := &walletv1.Wallet{
+		Id:        w.ID,
+		OwnerId:   w.OwnerID,
+		Currency:  w.Currency,
+		Status:    w.Status,
+		Version:   int32(w.Version),
+		CreatedAt: timestamppb.New(w.CreatedAt),
+		UpdatedAt: timestamppb.New(w.UpdatedAt),
+	}
+
+	if w.Balance != nil {
+		balance := int64(*w.Balance)
+		res.Balance = &balance
+	}
+
+	return res
+}
+
+func toWallets(wallets svcModels.Wallets) []*walletv1.Wallet {
+	res := make([]*walletv1.Wallet, 0, len(wallets))
+
+	for _, w := range wallets {
+		res = append(res, toWallet(w))
+	}
+
+	return res
+}
+
+//nolint:gosec
+func toTransaction(t svcModels.Transaction) *walletv1.Transaction {
+	return &walletv1.Transaction{
+		Id:        t.ID,
+		WalletId:  t.WalletID,
+		Amount:    int64(t.Amount),
+		Note:      t.Note,
+		Type:      t.Type,
+		Status:    t.Status,
+		Version:   int32(t.Version),
+		CreatedAt: timestamppb.New(t.CreatedAt),
+		UpdatedAt: timestamppb.New(t.UpdatedAt),
+	}
+}
+
+func toTransactions(transactions svcModels.Transactions) []*walletv1.Transaction {
+	res := make([]*walletv1.Transaction, 0, len(transactions))
+
+	for _, t := range transactions {
+		res = append(res, toTransaction(t))
+	}
+
+	return res
+}
+
+func toEvent(event events.Event) *walletv1.WatchTransactionsResponse {
+	res := &walletv1.WatchTransactionsResponse{
+		Id:          event.ID,
+		Type:        event.Type.String(),
+		Transaction: toTransaction(event.Transaction),
+		OccurredAt:  timestamppb.New(event.OccurredAt),
+	}
+
+	if event.Balance != nil {
+		balance := int64(*event.Balance)
+		res.Balance = &balance
+	}
+
+	return res
+}
+
+//nolint:gosec
+func toPagination(p *pagination.Pagination) *walletv1.Pagination {
+	return &walletv1.Pagination{
+		Total:      int32(p.Total),
+		Count:      int32(p.Count),
+		Page:       int32(p.Page),
+		PerPage:    int32(p.PerPage),
+		TotalPages: int32(p.TotalPages),
+		NextCursor: p.NextCursor,
+		PrevCursor: p.PrevCursor,
+	}
+}
+
+func fromPage(page *walletv1.Page) pagination.Paginator {
+	return pagination.Paginator{
+		Page:    intPtr(page.Page),
+		PerPage: intPtr(page.PerPage),
+		Limit:   intPtr(page.Limit),
+		After:   page.After,
+		Before:  page.Before,
+	}
+}
+
+func fromListWallets(req *walletv1.ListWalletsRequest) wallet.ListWalletsRequest {
+	var currencies types.Currencies
+	for _, currency := range req.GetCurrencies() {
+		currencies = append(currencies, types.Currency(currency))
+	}
+
+	return wallet.ListWalletsRequest{
+		IDs:        req.GetIds(),
+		OwnerIDs:   req.GetOwnerIds(),
+		Currencies: currencies,
+		Paginator:  fromPage(req.GetPage()),
+	}
+}
+
+func fromListTransactions(req *walletv1.ListTransactionsRequest) wallet.ListTransactionsRequest {
+	var statuses types.TransactionStatuses
+	for _, status := range req.GetStatuses() {
+		statuses = append(statuses, types.TransactionStatus(status))
+	}
+
+	var transactionTypes types.TransactionTypes
+	for _, transactionType := range req.GetTypes() {
+		transactionTypes = append(transactionTypes, types.TransactionType(transactionType))
+	}
+
+	var sort *types.TransactionSort
+	if req.Sort != nil {
+		value := types.TransactionSort(req.GetSort())
+		sort = &value
+	}
+
+	return wallet.ListTransactionsRequest{
+		IDs:           req.GetIds(),
+		WalletIDs:     req.GetWalletIds(),
+		Statuses:      statuses,
+		Types:         transactionTypes,
+		CreatedAtFrom: timePtr(req.GetCreatedAtFrom()),
+		CreatedAtTo:   timePtr(req.GetCreatedAtTo()),
+		UpdatedAtFrom: timePtr(req.GetUpdatedAtFrom()),
+		UpdatedAtTo:   timePtr(req.GetUpdatedAtTo()),
+		AmountMin:     int64Ptr(req.AmountMin),
+		AmountMax:     int64Ptr(req.AmountMax),
+		Note:          req.Note,
+		Sort:          sort,
+		Paginator:     fromPage(req.GetPage()),
+	}
+}
+
+func intPtr(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+
+	res := int(*value)
+
+	return &res
+}
+
+func int64Ptr(value *int64) *int {
+	if value == nil {
+		return nil
+	}
+
+	res := int(*value)
+
+	return &res
+}
+
+func timePtr(value *timestamppb.Timestamp) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	res := value.AsTime()
+
+	return &res
+}
@@ -0,0 +1,106 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies the service in the ErrorInfo detail attached to every error.
+const errorDomain = "wallet"
+
+// grpcCodes maps API error codes to gRPC codes. Codes missing here are reported as Internal.
+var grpcCodes = map[types.ErrorCode]codes.Code{
+	types.ErrorCodeBadRequest:          codes.InvalidArgument,
+	types.ErrorCodeValidation:          codes.InvalidArgument,
+	types.ErrorCodeInvalidCursor:       codes.InvalidArgument,
+	types.ErrorCodeNotFound:            codes.NotFound,
+	types.ErrorCodeWalletNotFound:      codes.NotFound,
+	types.ErrorCodeTransactionNotFound: codes.NotFound,
+	types.ErrorCodeDisputeNotFound:     codes.NotFound,
+	types.ErrorCodeInsufficientFunds:   codes.FailedPrecondition,
+	types.ErrorCodeWalletNotActive:     codes.FailedPrecondition,
+	types.ErrorCodeInvalidTransition:   codes.FailedPrecondition,
+	types.ErrorCodeApprovalRequired:    codes.FailedPrecondition,
+	types.ErrorCodeLinkedTransaction:   codes.FailedPrecondition,
+	types.ErrorCodePeriodNotOver:       codes.FailedPrecondition,
+	types.ErrorCodeNotDisputable:       codes.FailedPrecondition,
+	types.ErrorCodeDisputeClosed:       codes.FailedPrecondition,
+	types.ErrorCodeTransactionDenied:   codes.FailedPrecondition,
+	types.ErrorCodeFeeWalletUnusable:   codes.FailedPrecondition,
+	types.ErrorCodeNotApprover:         codes.PermissionDenied,
+	types.ErrorCodeSelfApproval:        codes.PermissionDenied,
+	types.ErrorCodeUnknownMaker:        codes.PermissionDenied,
+	types.ErrorCodeUnprocessable:       codes.FailedPrecondition,
+	types.ErrorCodeDuplicateWallet:     codes.AlreadyExists,
+	types.ErrorCodeDuplicateDispute:    codes.AlreadyExists,
+	types.ErrorCodeVersionConflict:     codes.Aborted,
+	types.ErrorCodeLockLost:            codes.Aborted,
+	types.ErrorCodeConflict:            codes.Aborted,
+	types.ErrorCodeTooManyRequests:     codes.ResourceExhausted,
+	types.ErrorCodeUnavailable:         codes.Unavailable,
+	types.ErrorCodeInternal:            codes.Internal,
+}
+
+// toStatus converts an error returned by the service layer into a gRPC status error.
+func toStatus(err error) error {
+	return newStatus(apierror.FromError(err))
+}
+
+func badRequest(message string) error {
+	return newStatus(apierror.NewBadRequestError(message))
+}
+
+// validate checks the binding tags of a request the same way the REST controllers do.
+func validate(ctx context.Context, req any) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return invalidRequest(ctx, err)
+	}
+
+	return nil
+}
+
+// invalidRequest reports validator errors field by field and any other error as a bad request.
+func invalidRequest(ctx context.Context, err error) error {
+	return newStatus(apierror.NewValidatorError(ctx, err))
+}
+
+func newStatus(apiErr *apierror.Error) error {
+	code, ok := grpcCodes[apiErr.Code]
+	if !ok {
+		code = codes.Internal
+	}
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: string(apiErr.Code), Domain: errorDomain},
+	}
+
+	if len(apiErr.Errors) > 0 {
+		badRequest := &errdetails.BadRequest{}
+
+		for _, fieldErr := range apiErr.Errors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       strings.TrimPrefix(fieldErr.Source, "/"),
+				Description: fieldErr.Message,
+			})
+		}
+
+		details = append(details, badRequest)
+	}
+
+	st := status.New(code, apiErr.Message)
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
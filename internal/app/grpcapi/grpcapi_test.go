@@ -0,0 +1,245 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/grpcapi"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/router"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	walletv1 "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/pb/wallet/v1"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type clients struct {
+	wallets      walletv1.WalletServiceClient
+	transactions walletv1.TransactionServiceClient
+}
+
+func newClients(t *testing.T, opts ...grpc.ServerOption) clients {
+	t.Helper()
+
+	require.NoError(t, types.RegisterValidations())
+
+	store := memory.New(time.Now)
+	broker := events.NewBroker()
+	services := router.NewServices(router.Dependencies{
+		Wallets:      store.Wallets(),
+		Transactions: store.Transactions(),
+		Cache:        store.Cache(),
+		Locker:       store.Locker(),
+		Events:       broker,
+		Now:          time.Now,
+	})
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpcapi.NewServer(services.Wallets, services.Transactions, broker, opts...)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return clients{
+		wallets:      walletv1.NewWalletServiceClient(conn),
+		transactions: walletv1.NewTransactionServiceClient(conn),
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	c := newClients(t)
+
+	created, err := c.wallets.CreateWallet(ctx, &walletv1.CreateWalletRequest{OwnerId: "owner-1", Currency: "USD"})
+	require.NoError(t, err)
+
+	walletID := created.GetWallet().GetId()
+
+	tests := []struct {
+		name           string
+		call           func() error
+		expectedCode   codes.Code
+		expectedReason types.ErrorCode
+		expectedFields []string
+	}{
+		{
+			name: "validation errors list the invalid fields",
+			call: func() error {
+				_, err := c.wallets.CreateWallet(ctx, &walletv1.CreateWalletRequest{Currency: "XXX"})
+
+				return err
+			},
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: types.ErrorCodeValidation,
+			expectedFields: []string{"ownerid", "currency"},
+		},
+		{
+			name: "unknown wallet",
+			call: func() error {
+				_, err := c.wallets.GetWallet(ctx, &walletv1.GetWalletRequest{Id: "missing"})
+
+				return err
+			},
+			expectedCode:   codes.NotFound,
+			expectedReason: types.ErrorCodeWalletNotFound,
+		},
+		{
+			name: "duplicate wallet",
+			call: func() error {
+				_, err := c.wallets.CreateWallet(ctx, &walletv1.CreateWalletRequest{OwnerId: "owner-1", Currency: "USD"})
+
+				return err
+			},
+			expectedCode:   codes.AlreadyExists,
+			expectedReason: types.ErrorCodeDuplicateWallet,
+		},
+		{
+			name: "insufficient funds",
+			call: func() error {
+				_, err := c.transactions.CreateTransaction(ctx, &walletv1.CreateTransactionRequest{
+					WalletId:       walletID,
+					Amount:         100,
+					Type:           string(types.TransactionTypeDebit),
+					IdempotencyKey: "debit-1",
+				})
+
+				return err
+			},
+			expectedCode:   codes.FailedPrecondition,
+			expectedReason: types.ErrorCodeInsufficientFunds,
+		},
+		{
+			name: "stale expected version",
+			call: func() error {
+				version := int32(7)
+				_, err := c.wallets.UpdateWalletStatus(ctx, &walletv1.UpdateWalletStatusRequest{
+					Id:              walletID,
+					Status:          string(types.WalletStatusInactive),
+					ExpectedVersion: &version,
+				})
+
+				return err
+			},
+			expectedCode:   codes.Aborted,
+			expectedReason: types.ErrorCodeVersionConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(tt.call())
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedCode, st.Code())
+
+			var fields []string
+
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.ErrorInfo:
+					assert.Equal(t, string(tt.expectedReason), d.GetReason())
+				case *errdetails.BadRequest:
+					for _, violation := range d.GetFieldViolations() {
+						fields = append(fields, violation.GetField())
+					}
+				}
+			}
+
+			assert.ElementsMatch(t, tt.expectedFields, fields)
+		})
+	}
+}
+
+func TestWatchTransactions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c := newClients(t)
+
+	created, err := c.wallets.CreateWallet(ctx, &walletv1.CreateWalletRequest{OwnerId: "owner-1", Currency: "USD"})
+	require.NoError(t, err)
+
+	walletID := created.GetWallet().GetId()
+
+	stream, err := c.transactions.WatchTransactions(ctx, &walletv1.WatchTransactionsRequest{WalletId: walletID})
+	require.NoError(t, err)
+
+	// Waiting for the headers guarantees the subscription exists before the transaction is created.
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	transaction, err := c.transactions.CreateTransaction(ctx, &walletv1.CreateTransactionRequest{
+		WalletId:       walletID,
+		Amount:         100,
+		Type:           string(types.TransactionTypeCredit),
+		IdempotencyKey: "credit-1",
+	})
+	require.NoError(t, err)
+
+	_, err = c.transactions.UpdateTransactionStatus(ctx, &walletv1.UpdateTransactionStatusRequest{
+		Id:     transaction.GetTransaction().GetId(),
+		Status: string(types.TransactionStatusCompleted),
+	})
+	require.NoError(t, err)
+
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, events.TypeTransactionCreated.String(), first.GetType())
+	assert.Equal(t, string(types.TransactionStatusPending), first.GetTransaction().GetStatus())
+
+	second, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, events.TypeTransactionStatusUpdated.String(), second.GetType())
+	assert.Equal(t, string(types.TransactionStatusCompleted), second.GetTransaction().GetStatus())
+	assert.Equal(t, int64(100), second.GetBalance())
+
+	missing, err := c.transactions.WatchTransactions(ctx, &walletv1.WatchTransactionsRequest{WalletId: "missing"})
+	require.NoError(t, err)
+
+	_, err = missing.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestEndStreams(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+	c := newClients(t, grpcapi.EndStreams(done))
+
+	created, err := c.wallets.CreateWallet(ctx, &walletv1.CreateWalletRequest{OwnerId: "owner-1", Currency: "USD"})
+	require.NoError(t, err)
+
+	stream, err := c.transactions.WatchTransactions(ctx,
+		&walletv1.WatchTransactionsRequest{WalletId: created.GetWallet().GetId()})
+	require.NoError(t, err)
+
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	close(done)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err), "watchers are told to watch again")
+
+	_, err = c.wallets.GetWallet(ctx, &walletv1.GetWalletRequest{Id: created.GetWallet().GetId()})
+	require.NoError(t, err, "unary calls are served until the server stops")
+}
@@ -0,0 +1,92 @@
+// Package grpcapi serves the wallet and transaction APIs over gRPC. It shares the service layer with
+// the REST controllers, so both APIs apply the same validation and business rules.
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	walletv1 "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/pb/wallet/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type walletService interface {
+	GetWalletByID(ctx context.Context, id string) (svcModels.Wallet, error)
+	UpdateWalletStatus(ctx context.Context, id, status string, expectedVersion *int) (svcModels.Wallet, error)
+	ListWallets(ctx context.Context, query svcModels.QueryWallets) (svcModels.Wallets, *pagination.Pagination, error)
+	CreateWallet(ctx context.Context, wallet svcModels.CreateWalletRequest) (svcModels.Wallet, error)
+	GetWalletWithBalance(ctx context.Context, id string) (svcModels.Wallet, error)
+}
+
+type transactionService interface {
+	GetTransactionByID(ctx context.Context, id string) (svcModels.Transaction, error)
+	UpdateTransactionStatus(ctx context.Context, id string, status string, expectedVersion *int) (
+		svcModels.Transaction, error)
+	ListTransactions(ctx context.Context, query svcModels.QueryTransactions) (
+		svcModels.Transactions, *pagination.Pagination, error)
+	CreateTransaction(ctx context.Context, transaction svcModels.CreateTransactionRequest) (
+		svcModels.Transaction, error)
+}
+
+type eventSubscriber interface {
+	Subscribe(ctx context.Context, walletID string) (<-chan events.Event, error)
+}
+
+// NewServer returns a gRPC server with the wallet and transaction services registered.
+func NewServer(
+	walletSvc walletService,
+	transactionSvc transactionService,
+	subscriber eventSubscriber,
+	opts ...grpc.ServerOption,
+) *grpc.Server {
+	server := grpc.NewServer(append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(withActor)}, opts...)...)
+
+	walletv1.RegisterWalletServiceServer(server, &walletServer{walletSvc: walletSvc})
+	walletv1.RegisterTransactionServiceServer(server, &transactionServer{
+		transactionSvc: transactionSvc,
+		walletSvc:      walletSvc,
+		subscriber:     subscriber,
+	})
+	reflection.Register(server)
+
+	return server
+}
+
+// errShuttingDown ends the streams cut short by a shutdown.
+var errShuttingDown = status.Error(codes.Unavailable, "server shutting down, watch again to resume")
+
+// EndStreams ends every open stream with Unavailable once done is closed, so that GracefulStop does not
+// wait on watchers until they cancel. Clients watch again and resume.
+func EndStreams(done <-chan struct{}) grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithCancelCause(stream.Context())
+		defer cancel(nil)
+
+		go func() {
+			select {
+			case <-done:
+				cancel(errShuttingDown)
+			case <-ctx.Done():
+			}
+		}()
+
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	})
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,147 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	walletv1 "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/pb/wallet/v1"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type transactionServer struct {
+	walletv1.UnimplementedTransactionServiceServer
+
+	transactionSvc transactionService
+	walletSvc      walletService
+	subscriber     eventSubscriber
+}
+
+func (s *transactionServer) GetTransaction(ctx context.Context, req *walletv1.GetTransactionRequest) (
+	*walletv1.GetTransactionResponse, error) {
+	if req.GetId() == "" {
+		return nil, badRequest("Transaction ID is required")
+	}
+
+	transaction, err := s.transactionSvc.GetTransactionByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.GetTransactionResponse{Transaction: toTransaction(transaction)}, nil
+}
+
+func (s *transactionServer) ListTransactions(ctx context.Context, req *walletv1.ListTransactionsRequest) (
+	*walletv1.ListTransactionsResponse, error) {
+	query := fromListTransactions(req)
+	if err := validate(ctx, &query); err != nil {
+		return nil, err
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, invalidRequest(ctx, err)
+	}
+
+	transactions, pagination, err := s.transactionSvc.ListTransactions(ctx,
+		svcModels.QueryTransactions{}.FromRequest(query))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.ListTransactionsResponse{
+		Transactions: toTransactions(transactions),
+		Pagination:   toPagination(pagination),
+	}, nil
+}
+
+func (s *transactionServer) CreateTransaction(ctx context.Context, req *walletv1.CreateTransactionRequest) (
+	*walletv1.CreateTransactionResponse, error) {
+	createReq := wallet.CreateTransactionRequest{
+		WalletID:       req.GetWalletId(),
+		Amount:         int(req.GetAmount()),
+		Note:           req.Note,
+		Type:           types.TransactionType(req.GetType()),
+		IdempotencyKey: req.GetIdempotencyKey(),
+	}
+	if err := validate(ctx, &createReq); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionSvc.CreateTransaction(ctx,
+		svcModels.CreateTransactionRequest{}.FromRequest(createReq))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.CreateTransactionResponse{Transaction: toTransaction(transaction)}, nil
+}
+
+func (s *transactionServer) UpdateTransactionStatus(ctx context.Context,
+	req *walletv1.UpdateTransactionStatusRequest) (*walletv1.UpdateTransactionStatusResponse, error) {
+	if req.GetId() == "" {
+		return nil, badRequest("Transaction ID is required")
+	}
+
+	updateReq := wallet.UpdateTransactionStatusRequest{
+		Status:  types.TransactionStatus(req.GetStatus()),
+		Version: intPtr(req.ExpectedVersion),
+	}
+	if err := validate(ctx, &updateReq); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionSvc.UpdateTransactionStatus(ctx, req.GetId(), string(updateReq.Status),
+		updateReq.Version)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.UpdateTransactionStatusResponse{Transaction: toTransaction(transaction)}, nil
+}
+
+// WatchTransactions streams the events of an existing wallet until the client cancels. A client that
+// falls too far behind, or whose stream EndStreams ends, is disconnected with Unavailable and should call
+// again.
+func (s *transactionServer) WatchTransactions(req *walletv1.WatchTransactionsRequest,
+	stream grpc.ServerStreamingServer[walletv1.WatchTransactionsResponse]) error {
+	ctx := stream.Context()
+
+	if req.GetWalletId() == "" {
+		return badRequest("Wallet ID is required")
+	}
+
+	if _, err := s.walletSvc.GetWalletByID(ctx, req.GetWalletId()); err != nil {
+		return toStatus(err)
+	}
+
+	events, err := s.subscriber.Subscribe(ctx, req.GetWalletId())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	// Headers are sent right away so clients know the subscription is in place before any event arrives.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for event := range events {
+		if err := stream.Send(toEvent(event)); err != nil {
+			return err
+		}
+	}
+
+	if errors.Is(context.Cause(ctx), errShuttingDown) {
+		return errShuttingDown
+	}
+
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	return status.Error(codes.Unavailable, "event stream interrupted, watch again to resume")
+}
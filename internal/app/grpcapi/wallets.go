@@ -0,0 +1,102 @@
+package grpcapi
+
+import (
+	"context"
+
+	svcModels "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	walletv1 "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/pb/wallet/v1"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+)
+
+type walletServer struct {
+	walletv1.UnimplementedWalletServiceServer
+
+	walletSvc walletService
+}
+
+func (s *walletServer) GetWallet(ctx context.Context, req *walletv1.GetWalletRequest) (
+	*walletv1.GetWalletResponse, error) {
+	if req.GetId() == "" {
+		return nil, badRequest("Wallet ID is required")
+	}
+
+	w, err := s.walletSvc.GetWalletByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.GetWalletResponse{Wallet: toWallet(w)}, nil
+}
+
+func (s *walletServer) ListWallets(ctx context.Context, req *walletv1.ListWalletsRequest) (
+	*walletv1.ListWalletsResponse, error) {
+	query := fromListWallets(req)
+	if err := validate(ctx, &query); err != nil {
+		return nil, err
+	}
+
+	wallets, pagination, err := s.walletSvc.ListWallets(ctx, svcModels.QueryWallets{}.FromRequest(query))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.ListWalletsResponse{
+		Wallets:    toWallets(wallets),
+		Pagination: toPagination(pagination),
+	}, nil
+}
+
+func (s *walletServer) CreateWallet(ctx context.Context, req *walletv1.CreateWalletRequest) (
+	*walletv1.CreateWalletResponse, error) {
+	createReq := wallet.CreateWalletRequest{
+		OwnerID:  req.GetOwnerId(),
+		Currency: types.Currency(req.GetCurrency()),
+	}
+	if err := validate(ctx, &createReq); err != nil {
+		return nil, err
+	}
+
+	w, err := s.walletSvc.CreateWallet(ctx, svcModels.CreateWalletRequest{}.FromRequest(createReq))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.CreateWalletResponse{Wallet: toWallet(w)}, nil
+}
+
+func (s *walletServer) UpdateWalletStatus(ctx context.Context, req *walletv1.UpdateWalletStatusRequest) (
+	*walletv1.UpdateWalletStatusResponse, error) {
+	if req.GetId() == "" {
+		return nil, badRequest("Wallet ID is required")
+	}
+
+	updateReq := wallet.UpdateWalletStatusRequest{
+		Status:  types.WalletStatus(req.GetStatus()),
+		Version: intPtr(req.ExpectedVersion),
+	}
+	if err := validate(ctx, &updateReq); err != nil {
+		return nil, err
+	}
+
+	w, err := s.walletSvc.UpdateWalletStatus(ctx, req.GetId(), string(updateReq.Status), updateReq.Version)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.UpdateWalletStatusResponse{Wallet: toWallet(w)}, nil
+}
+
+func (s *walletServer) GetWalletBalance(ctx context.Context, req *walletv1.GetWalletBalanceRequest) (
+	*walletv1.GetWalletBalanceResponse, error) {
+	if req.GetId() == "" {
+		return nil, badRequest("Wallet ID is required")
+	}
+
+	w, err := s.walletSvc.GetWalletWithBalance(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.GetWalletBalanceResponse{Wallet: toWallet(w)}, nil
+}
@@ -0,0 +1,100 @@
+// Package interest computes the interest wallets earn on their balance from a schedule of annual rates.
+// Interest accrues daily on the ACT/365 Fixed basis, in minor units, with integers only: the fraction of
+// a minor unit each day leaves is carried to the next day, so none is lost to rounding.
+package interest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+// DaysPerYear is the day count every year is taken to have, whatever its length.
+const DaysPerYear = 365
+
+// denominator turns a balance times an annual rate in basis points into a daily amount.
+const denominator = 10000 * DaysPerYear
+
+// Rate is the annual interest the wallets of a currency and product earn.
+type Rate struct {
+	Currency string `mapstructure:"currency"`
+	// Product restricts the rate to wallets of that product. Empty matches wallets without a product.
+	Product           string `mapstructure:"product"`
+	AnnualBasisPoints int    `mapstructure:"annual_basis_points"`
+}
+
+// Schedule is the set of interest rates. A wallet earns the rate of its currency and product, if any.
+type Schedule struct {
+	Rates []Rate
+}
+
+// NewSchedule returns a schedule of rates. Currencies are matched regardless of case, as configuration
+// keys lose theirs.
+func NewSchedule(rates []Rate) Schedule {
+	normalized := make([]Rate, 0, len(rates))
+	for _, rate := range rates {
+		rate.Currency = strings.ToUpper(rate.Currency)
+		normalized = append(normalized, rate)
+	}
+
+	return Schedule{Rates: normalized}
+}
+
+// Rate returns the annual rate in basis points wallets of currency and product earn.
+func (s Schedule) Rate(currency, product string) (int, bool) {
+	for _, rate := range s.Rates {
+		if strings.EqualFold(rate.Currency, currency) && rate.Product == product {
+			return rate.AnnualBasisPoints, true
+		}
+	}
+
+	return 0, false
+}
+
+// Accrue returns the interest a balance earns in a day at annualBasisPoints, along with the fraction of a
+// minor unit carried to the next day. carry is the fraction carried from the previous day. Balances that
+// are not positive earn nothing and keep the carry as is.
+func Accrue(balance, annualBasisPoints, carry int) (amount, nextCarry int) {
+	if balance <= 0 {
+		return 0, carry
+	}
+
+	total := carry + balance*annualBasisPoints
+
+	return total / denominator, total % denominator
+}
+
+// Validate reports every invalid rate at once.
+func (s Schedule) Validate() error {
+	var problems []string
+
+	check := func(ok bool, rate int, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf("rate %d: %s", rate, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	seen := map[[2]string]bool{}
+
+	for i, rate := range s.Rates {
+		currency := types.Currency(strings.ToUpper(rate.Currency))
+		check(slices.Contains(types.GetCurrencies(), currency), i,
+			"currency must be one of %v, got %q", types.GetCurrencies(), rate.Currency)
+		check(len(rate.Product) <= 32, i, "product must be at most 32 characters, got %q", rate.Product)
+		check(rate.AnnualBasisPoints >= 0 && rate.AnnualBasisPoints <= 10000, i,
+			"annual_basis_points must be between 0 and 10000")
+
+		key := [2]string{string(currency), rate.Product}
+		check(!seen[key], i, "duplicates the rate of %s %q", currency, rate.Product)
+		seen[key] = true
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
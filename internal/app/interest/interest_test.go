@@ -0,0 +1,103 @@
+package interest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccrue(t *testing.T) {
+	tests := []struct {
+		name              string
+		balance           int
+		annualBasisPoints int
+		carry             int
+		expectedAmount    int
+		expectedCarry     int
+	}{
+		{
+			name: "whole minor units", balance: 3650000, annualBasisPoints: 1000,
+			expectedAmount: 1000, expectedCarry: 0,
+		},
+		{
+			name: "fraction is carried", balance: 100000, annualBasisPoints: 500,
+			expectedAmount: 13, expectedCarry: 2550000,
+		},
+		{
+			name: "carry adds up to a minor unit", balance: 100000, annualBasisPoints: 500, carry: 2550000,
+			expectedAmount: 14, expectedCarry: 1450000,
+		},
+		{
+			name: "small balance only carries", balance: 10, annualBasisPoints: 100,
+			expectedAmount: 0, expectedCarry: 1000,
+		},
+		{
+			name: "overdrawn balance keeps the carry", balance: -500, annualBasisPoints: 500, carry: 42,
+			expectedAmount: 0, expectedCarry: 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, carry := Accrue(tt.balance, tt.annualBasisPoints, tt.carry)
+
+			assert.Equal(t, tt.expectedAmount, amount)
+			assert.Equal(t, tt.expectedCarry, carry)
+		})
+	}
+}
+
+func TestAccrue_LosesNothingOverAYear(t *testing.T) {
+	// 1000.00 at 3.65% earns exactly 36.50 in a year, however it splits into days.
+	total, carry := 0, 0
+
+	for range DaysPerYear {
+		var amount int
+		amount, carry = Accrue(100000, 365, carry)
+		total += amount
+	}
+
+	assert.Equal(t, 3650, total)
+	assert.Zero(t, carry)
+}
+
+func TestSchedule_Rate(t *testing.T) {
+	schedule := NewSchedule([]Rate{
+		{Currency: "usd", Product: "savings", AnnualBasisPoints: 350},
+		{Currency: "USD", AnnualBasisPoints: 50},
+	})
+
+	rate, found := schedule.Rate("USD", "savings")
+	require.True(t, found)
+	assert.Equal(t, 350, rate)
+
+	rate, found = schedule.Rate("USD", "")
+	require.True(t, found)
+	assert.Equal(t, 50, rate)
+
+	_, found = schedule.Rate("USD", "checking")
+	assert.False(t, found)
+
+	_, found = schedule.Rate("EUR", "savings")
+	assert.False(t, found)
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	require.NoError(t, NewSchedule([]Rate{{Currency: "USD", Product: "savings", AnnualBasisPoints: 350}}).Validate())
+
+	err := NewSchedule([]Rate{
+		{Currency: "XYZ", AnnualBasisPoints: 100},
+		{Currency: "USD", Product: "savings", AnnualBasisPoints: 20000},
+		{Currency: "usd", Product: "savings", AnnualBasisPoints: 100},
+	}).Validate()
+	require.Error(t, err)
+
+	for _, problem := range []string{
+		`rate 0: currency must be one of`,
+		"rate 1: annual_basis_points must be between 0 and 10000",
+		`rate 2: duplicates the rate of USD "savings"`,
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+}
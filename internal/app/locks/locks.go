@@ -0,0 +1,77 @@
+// Package locks serializes work on a key, such as a wallet, across the instances of the service.
+package locks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Backend string
+
+const (
+	// BackendRedis locks with redsync. Locks expire after their TTL, so a crashed holder cannot block a key.
+	BackendRedis Backend = "redis"
+	// BackendPostgres locks with advisory locks held on one connection, which keeps working while Redis is
+	// down.
+	BackendPostgres Backend = "postgres"
+	// BackendMemory locks within the process, for single instance deployments and tests.
+	BackendMemory Backend = "memory"
+)
+
+func GetBackends() []Backend {
+	return []Backend{BackendRedis, BackendPostgres, BackendMemory}
+}
+
+// Locker hands out exclusive locks on keys.
+type Locker interface {
+	// Lock blocks until the lock on key is held or ctx is done, and returns the function releasing it.
+	Lock(ctx context.Context, key string) (func(context.Context) error, error)
+}
+
+var lockWait = metrics.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "mutex_wait_seconds",
+	Help:    "Time spent acquiring distributed mutexes, by outcome.",
+	Buckets: []float64{.001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+}, "outcome")
+
+type instrumented struct {
+	locker Locker
+	wait   time.Duration
+}
+
+// Instrument traces and times lock acquisition. Callers whose context has no deadline wait at most
+// wait for a lock; the others wait as long as their deadline allows.
+func Instrument(locker Locker, wait time.Duration) Locker {
+	return &instrumented{locker: locker, wait: wait}
+}
+
+func (l *instrumented) Lock(ctx context.Context, key string) (_ func(context.Context) error, err error) {
+	ctx, span := tracing.Start(ctx, "locks.Lock", tracing.LockKey(key))
+	defer func() { tracing.End(span, err) }()
+
+	waitCtx := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		waitCtx, cancel = context.WithTimeout(ctx, l.wait)
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	unlock, err := l.locker.Lock(waitCtx, key)
+	if err != nil {
+		lockWait.WithLabelValues("failed").Observe(time.Since(start).Seconds())
+
+		return nil, fmt.Errorf("failed to acquire lock for key %s: %w", key, err)
+	}
+
+	lockWait.WithLabelValues("acquired").Observe(time.Since(start).Seconds())
+
+	return unlock, nil
+}
@@ -0,0 +1,129 @@
+package locks_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/locks"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockers(t *testing.T) {
+	lockers := map[string]func(t *testing.T) locks.Locker{
+		"in-process": func(*testing.T) locks.Locker { return locks.NewInProcess() },
+		"postgres": func(t *testing.T) locks.Locker {
+			dsn := os.Getenv("TEST_DATABASE_DSN")
+			if dsn == "" {
+				t.Skip("TEST_DATABASE_DSN is required")
+			}
+
+			db, err := sql.Open("pgx", dsn)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = db.Close() })
+
+			return locks.NewPostgres(db, "locks-test", 10*time.Millisecond)
+		},
+	}
+
+	for name, newLocker := range lockers {
+		t.Run(name, func(t *testing.T) {
+			locker := newLocker(t)
+			ctx := context.Background()
+
+			unlock, err := locker.Lock(ctx, "wallet-1")
+			require.NoError(t, err)
+
+			// Other keys are independent.
+			unlockOther, err := locker.Lock(ctx, "wallet-2")
+			require.NoError(t, err)
+			require.NoError(t, unlockOther(ctx))
+
+			// Waiting for a held key ends with the context.
+			waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			_, err = locker.Lock(waitCtx, "wallet-1")
+			require.Error(t, err)
+
+			acquired := make(chan struct{})
+
+			go func() {
+				defer close(acquired)
+
+				unlock, err := locker.Lock(ctx, "wallet-1")
+				if assert.NoError(t, err) {
+					assert.NoError(t, unlock(ctx))
+				}
+			}()
+
+			select {
+			case <-acquired:
+				t.Fatal("lock acquired while held")
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			require.NoError(t, unlock(ctx))
+
+			select {
+			case <-acquired:
+			case <-time.After(time.Second):
+				t.Fatal("lock not acquired after release")
+			}
+		})
+	}
+}
+
+func TestPostgres_HoldsLocksOnOneConnection(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is required")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	db.SetMaxOpenConns(2)
+
+	ctx := context.Background()
+	locker := locks.NewPostgres(db, "locks-test", 10*time.Millisecond)
+	// Another instance sharing the database.
+	other := locks.NewPostgres(db, "locks-test", 10*time.Millisecond)
+
+	for _, key := range []string{"idempotency:1", "owner-1", "wallet-1", "wallet-2"} {
+		unlock, err := locker.Lock(ctx, key)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = unlock(ctx) })
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	require.NoError(t, db.PingContext(queryCtx), "held locks leave the other connection to queries")
+
+	waitCtx, cancelWait := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancelWait()
+
+	_, err = other.Lock(waitCtx, "wallet-1")
+	require.ErrorIs(t, err, context.DeadlineExceeded, "other instances wait for the lock")
+}
+
+func TestInstrument_BoundsWaitWithoutDeadline(t *testing.T) {
+	inProcess := locks.NewInProcess()
+	locker := locks.Instrument(inProcess, 50*time.Millisecond)
+
+	unlock, err := inProcess.Lock(context.Background(), "wallet-1")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = unlock(context.Background()) })
+
+	start := time.Now()
+	_, err = locker.Lock(context.Background(), "wallet-1")
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,62 @@
+package locks
+
+import (
+	"context"
+	"sync"
+)
+
+// InProcess hands out one lock per key within the process. A key is forgotten once nobody holds or waits
+// for it, so locking ever new keys, such as idempotency keys, takes no lasting memory.
+type InProcess struct {
+	mu    sync.Mutex
+	locks map[string]*inProcessLock
+}
+
+type inProcessLock struct {
+	held chan struct{}
+	// refs counts the holder and the waiters of the lock.
+	refs int
+}
+
+func NewInProcess() *InProcess {
+	return &InProcess{locks: map[string]*inProcessLock{}}
+}
+
+func (l *InProcess) Lock(ctx context.Context, key string) (func(context.Context) error, error) {
+	l.mu.Lock()
+
+	lock, found := l.locks[key]
+	if !found {
+		lock = &inProcessLock{held: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+
+	lock.refs++
+
+	l.mu.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+		return func(context.Context) error {
+			<-lock.held
+			l.release(key, lock)
+
+			return nil
+		}, nil
+	case <-ctx.Done():
+		l.release(key, lock)
+
+		return nil, ctx.Err()
+	}
+}
+
+// release drops a reference to the lock on key, forgetting the key with the last one.
+func (l *InProcess) release(key string, lock *inProcessLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, key)
+	}
+}
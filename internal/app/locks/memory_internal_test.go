@@ -0,0 +1,35 @@
+package locks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInProcess_ForgetsReleasedKeys(t *testing.T) {
+	locker := NewInProcess()
+	ctx := context.Background()
+
+	for i := range 100 {
+		unlock, err := locker.Lock(ctx, fmt.Sprintf("idempotency:%d", i))
+		require.NoError(t, err)
+		require.NoError(t, unlock(ctx))
+	}
+
+	unlock, err := locker.Lock(ctx, "wallet-1")
+	require.NoError(t, err)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	_, err = locker.Lock(waitCtx, "wallet-1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, locker.locks, 1, "the held key is kept")
+
+	require.NoError(t, unlock(ctx))
+	assert.Empty(t, locker.locks, "keys nobody holds or waits for are forgotten")
+}
@@ -0,0 +1,102 @@
+package locks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Postgres locks keys with session level advisory locks, all held on one dedicated connection, so held
+// locks take no connections from the pool queries run on: a pool of two connections is enough. As waiting
+// on the shared connection would block every other key, locks are tried with pg_try_advisory_lock and
+// tried again every retryDelay while another instance holds them. Session locks are reentrant, so the
+// callers of the process wait for a key in turn on an in-process lock first. Postgres releases the locks
+// when the connection drops, which the fencing tokens of the wallets guard against; the next lock opens a
+// new connection.
+type Postgres struct {
+	db         *sql.DB
+	namespace  string
+	retryDelay time.Duration
+	local      *InProcess
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// NewPostgres returns a locker whose keys are prefixed with namespace, keeping them apart from other
+// advisory lock users of the database.
+func NewPostgres(db *sql.DB, namespace string, retryDelay time.Duration) *Postgres {
+	return &Postgres{db: db, namespace: namespace, retryDelay: retryDelay, local: NewInProcess()}
+}
+
+func (l *Postgres) Lock(ctx context.Context, key string) (func(context.Context) error, error) {
+	unlockLocal, err := l.local.Lock(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	key = l.namespace + ":" + key
+
+	for {
+		acquired, err := l.query(ctx, "SELECT pg_try_advisory_lock(hashtextextended($1, 0))", key)
+		if err != nil {
+			_ = unlockLocal(ctx)
+
+			return nil, err
+		}
+
+		if acquired {
+			break
+		}
+
+		select {
+		case <-time.After(l.retryDelay):
+		case <-ctx.Done():
+			_ = unlockLocal(ctx)
+
+			return nil, ctx.Err()
+		}
+	}
+
+	return func(ctx context.Context) error {
+		defer func() { _ = unlockLocal(ctx) }()
+
+		_, err := l.query(ctx, "SELECT pg_advisory_unlock(hashtextextended($1, 0))", key)
+
+		return err
+	}, nil
+}
+
+// query runs a statement answering yes or no on the lock connection, which it opens when there is none.
+// Only opening the connection is bounded by ctx: the statements do not wait, and cancelling one would
+// close the connection along with every lock held on it. A connection that fails is closed for good.
+func (l *Postgres) query(ctx context.Context, statement, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		conn, err := l.db.Conn(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to open lock connection: %w", err)
+		}
+
+		l.conn = conn
+	}
+
+	var answer bool
+
+	err := l.conn.QueryRowContext(context.WithoutCancel(ctx), statement, key).Scan(&answer)
+	if err != nil {
+		// Returning the connection to the pool would leave its locks held, so it is discarded instead.
+		_ = l.conn.Raw(func(any) error { return driver.ErrBadConn })
+		_ = l.conn.Close()
+		l.conn = nil
+
+		return false, fmt.Errorf("failed to query lock: %w", err)
+	}
+
+	return answer, nil
+}
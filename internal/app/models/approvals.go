@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	pkg "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+)
+
+// TransactionApproval records who asked for, approved or rejected a transaction awaiting approval.
+type TransactionApproval struct {
+	ID            string
+	TransactionID string
+	Action        string
+	ActorID       string
+	Reason        string
+	CreatedAt     time.Time
+}
+
+type TransactionApprovals []TransactionApproval
+
+func (a TransactionApproval) ToResponse() pkg.TransactionApproval {
+	return pkg.TransactionApproval{
+		ID:            a.ID,
+		TransactionID: a.TransactionID,
+		Action:        types.ApprovalAction(a.Action),
+		ActorID:       a.ActorID,
+		Reason:        a.Reason,
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+func (a TransactionApprovals) ToResponse() []pkg.TransactionApproval {
+	res := make([]pkg.TransactionApproval, 0, len(a))
+	for _, approval := range a {
+		res = append(res, approval.ToResponse())
+	}
+
+	return res
+}
+
+// Maker returns the actor who created the transaction, or an empty string if it was created anonymously.
+func (a TransactionApprovals) Maker() string {
+	for _, approval := range a {
+		if approval.Action == string(types.ApprovalActionRequested) {
+			return approval.ActorID
+		}
+	}
+
+	return ""
+}
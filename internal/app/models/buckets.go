@@ -0,0 +1,254 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	pkg "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+)
+
+// TransactionAllocation records the part of a debit taken from a balance bucket. Parts taken from credits
+// that expire name the credit, so that what is left of it is known once it expires.
+type TransactionAllocation struct {
+	ID            string
+	TransactionID string
+	WalletID      string
+	Bucket        string
+	CreditID      *string
+	Amount        int
+	CreatedAt     time.Time
+}
+
+type TransactionAllocations []TransactionAllocation
+
+// Lot is what is left of a credit that expires.
+type Lot struct {
+	CreditID  string
+	Bucket    string
+	Amount    int
+	ExpiresAt time.Time
+}
+
+// BalanceBucket is the part of a wallet balance funded by the credits of one bucket.
+type BalanceBucket struct {
+	Bucket  string
+	Balance int
+	// Available leaves out the credits that expired but were not yet written off.
+	Available int
+	// Expiring are the credits of the bucket that expire, soonest first.
+	Expiring []Lot
+}
+
+type BalanceBuckets []BalanceBucket
+
+// Buckets splits the balance of a wallet ledger by bucket, given the allocations of its debits. The
+// buckets add up to Balance: debits made before buckets existed carry no allocations and are taken
+// from cash, like those whose allocations are not in allocations yet.
+func (t Transactions) Buckets(allocations TransactionAllocations, now time.Time) BalanceBuckets {
+	// unallocated is what is left to allocate of every debit holding funds.
+	unallocated := map[string]int{}
+
+	for _, transaction := range t {
+		if transaction.Type == string(types.TransactionTypeDebit) && holdsFunds(transaction.Status) {
+			unallocated[transaction.ID] = transaction.Amount
+		}
+	}
+
+	balances := map[string]int{}
+	taken := map[string]int{}
+
+	for _, allocation := range allocations {
+		if _, found := unallocated[allocation.TransactionID]; !found {
+			continue
+		}
+
+		balances[allocation.Bucket] -= allocation.Amount
+		unallocated[allocation.TransactionID] -= allocation.Amount
+
+		if allocation.CreditID != nil {
+			taken[*allocation.CreditID] += allocation.Amount
+		}
+	}
+
+	for _, amount := range unallocated {
+		balances[string(types.BalanceBucketCash)] -= amount
+	}
+
+	var lots []Lot
+
+	for _, transaction := range t {
+		if transaction.Type != string(types.TransactionTypeCredit) ||
+			transaction.Status != string(types.TransactionStatusCompleted) {
+			continue
+		}
+
+		bucket := cmp.Or(transaction.Bucket, string(types.BalanceBucketCash))
+		balances[bucket] += transaction.Amount
+
+		if left := transaction.Amount - taken[transaction.ID]; transaction.ExpiresAt != nil && left > 0 {
+			lots = append(lots, Lot{
+				CreditID:  transaction.ID,
+				Bucket:    bucket,
+				Amount:    left,
+				ExpiresAt: *transaction.ExpiresAt,
+			})
+		}
+	}
+
+	slices.SortFunc(lots, func(a, b Lot) int {
+		return cmp.Or(a.ExpiresAt.Compare(b.ExpiresAt), cmp.Compare(a.CreditID, b.CreditID))
+	})
+
+	buckets := make(BalanceBuckets, 0, len(types.GetBalanceBuckets()))
+
+	for _, name := range types.GetBalanceBuckets() {
+		bucket := BalanceBucket{Bucket: name.String(), Balance: balances[name.String()]}
+		bucket.Available = bucket.Balance
+
+		for _, lot := range lots {
+			if lot.Bucket != bucket.Bucket {
+				continue
+			}
+
+			bucket.Expiring = append(bucket.Expiring, lot)
+
+			if !lot.ExpiresAt.After(now) {
+				bucket.Available -= lot.Amount
+			}
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
+
+// holdsFunds reports whether a debit in status takes from the balance.
+func holdsFunds(status string) bool {
+	return status != string(types.TransactionStatusFailed) && status != string(types.TransactionStatusRejected)
+}
+
+// Available is the part of the balance that can be spent.
+func (b BalanceBuckets) Available() int {
+	available := 0
+	for _, bucket := range b {
+		available += bucket.Available
+	}
+
+	return available
+}
+
+// Expired returns what is left of the credits that expired by now.
+func (b BalanceBuckets) Expired(now time.Time) []Lot {
+	var expired []Lot
+
+	for _, bucket := range b {
+		for _, lot := range bucket.Expiring {
+			if lot.Amount > 0 && !lot.ExpiresAt.After(now) {
+				expired = append(expired, lot)
+			}
+		}
+	}
+
+	return expired
+}
+
+// Allocate takes the amount of debit from the buckets in priority order, and from the credits of each
+// bucket that expire soonest before those that do not expire. Buckets left out of priority come last.
+// The buckets are reduced by what was taken, so that the next debit is allocated from what is left. It
+// reports false if the available balance does not cover the debit.
+func (b BalanceBuckets) Allocate(debit Transaction, priority []string, now time.Time) (
+	TransactionAllocations, bool) {
+	left := debit.Amount
+
+	var allocations TransactionAllocations
+
+	take := func(bucket *BalanceBucket, creditID *string, amount int) {
+		allocations = append(allocations, TransactionAllocation{
+			TransactionID: debit.ID,
+			WalletID:      debit.WalletID,
+			Bucket:        bucket.Bucket,
+			CreditID:      creditID,
+			Amount:        amount,
+		})
+
+		bucket.Balance -= amount
+		bucket.Available -= amount
+		left -= amount
+	}
+
+	for _, i := range b.inOrder(priority) {
+		bucket := &b[i]
+		lasting := bucket.Balance
+
+		for j := range bucket.Expiring {
+			lasting -= bucket.Expiring[j].Amount
+		}
+
+		for j := range bucket.Expiring {
+			lot := &bucket.Expiring[j]
+			if left == 0 || lot.Amount == 0 || !lot.ExpiresAt.After(now) {
+				continue
+			}
+
+			amount := min(left, lot.Amount)
+			take(bucket, &lot.CreditID, amount)
+			lot.Amount -= amount
+		}
+
+		if amount := min(left, lasting); amount > 0 {
+			take(bucket, nil, amount)
+		}
+	}
+
+	return allocations, left == 0
+}
+
+// inOrder returns the indexes of the buckets in priority order, followed by those left out of it.
+func (b BalanceBuckets) inOrder(priority []string) []int {
+	order := make([]int, 0, len(b))
+
+	for _, name := range priority {
+		if i := slices.IndexFunc(b, func(bucket BalanceBucket) bool { return bucket.Bucket == name }); i >= 0 {
+			order = append(order, i)
+		}
+	}
+
+	for i, bucket := range b {
+		if !slices.Contains(priority, bucket.Bucket) {
+			order = append(order, i)
+		}
+	}
+
+	return order
+}
+
+func (b BalanceBuckets) ToResponse() []pkg.BalanceBucket {
+	if len(b) == 0 {
+		return nil
+	}
+
+	res := make([]pkg.BalanceBucket, 0, len(b))
+
+	for _, bucket := range b {
+		response := pkg.BalanceBucket{
+			Bucket:    types.BalanceBucket(bucket.Bucket),
+			Balance:   bucket.Balance,
+			Available: bucket.Available,
+		}
+
+		for _, lot := range bucket.Expiring {
+			response.Expiring = append(response.Expiring, pkg.ExpiringCredit{
+				CreditID:  lot.CreditID,
+				Amount:    lot.Amount,
+				ExpiresAt: lot.ExpiresAt,
+			})
+		}
+
+		res = append(res, response)
+	}
+
+	return res
+}
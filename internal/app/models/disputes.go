@@ -0,0 +1,155 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	pkg "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/looplab/fsm"
+)
+
+// Dispute is a challenge by the wallet owner of a completed debit.
+type Dispute struct {
+	ID            string
+	TransactionID string
+	WalletID      string
+	Amount        int
+	Reason        string
+	Status        string
+	DueAt         time.Time
+	// ProvisionalCreditID is the credit given back while the dispute is open, if any.
+	ProvisionalCreditID *string
+	// SettlementID is the transaction that moved funds when the dispute was resolved, if any.
+	SettlementID   *string
+	ResolutionNote *string
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// Notes are the evidence attached to the dispute, when it is looked up.
+	Notes DisputeNotes `gorm:"-"`
+}
+
+type Disputes []Dispute
+
+// DisputeNote is a piece of evidence attached to a dispute.
+type DisputeNote struct {
+	ID        string
+	DisputeID string
+	ActorID   string
+	Note      string
+	CreatedAt time.Time
+}
+
+type DisputeNotes []DisputeNote
+
+// DisputeStates are the transitions of a dispute. Disputes are reviewed before they are resolved.
+var DisputeStates = fsm.Events{
+	{
+		Name: string(types.DisputeStatusUnderReview),
+		Src:  []string{string(types.DisputeStatusOpen)},
+		Dst:  string(types.DisputeStatusUnderReview),
+	},
+	{
+		Name: string(types.DisputeStatusWon),
+		Src:  []string{string(types.DisputeStatusUnderReview)},
+		Dst:  string(types.DisputeStatusWon),
+	},
+	{
+		Name: string(types.DisputeStatusLost),
+		Src:  []string{string(types.DisputeStatusUnderReview)},
+		Dst:  string(types.DisputeStatusLost),
+	},
+}
+
+// Resolved reports whether the dispute was won or lost, after which it no longer changes.
+func (d Dispute) Resolved() bool {
+	return d.Status == string(types.DisputeStatusWon) || d.Status == string(types.DisputeStatusLost)
+}
+
+func (d Dispute) ToResponse() pkg.Dispute {
+	return pkg.Dispute{
+		ID:                  d.ID,
+		TransactionID:       d.TransactionID,
+		WalletID:            d.WalletID,
+		Amount:              d.Amount,
+		Reason:              d.Reason,
+		Status:              types.DisputeStatus(d.Status),
+		DueAt:               d.DueAt,
+		ProvisionalCreditID: d.ProvisionalCreditID,
+		SettlementID:        d.SettlementID,
+		ResolutionNote:      d.ResolutionNote,
+		Notes:               d.Notes.toResponse(),
+		Version:             d.Version,
+		CreatedAt:           d.CreatedAt,
+		UpdatedAt:           d.UpdatedAt,
+	}
+}
+
+func (d Disputes) ToResponse() []pkg.Dispute {
+	res := make([]pkg.Dispute, 0, len(d))
+	for _, dispute := range d {
+		res = append(res, dispute.ToResponse())
+	}
+
+	return res
+}
+
+func (n DisputeNote) ToResponse() pkg.DisputeNote {
+	return pkg.DisputeNote{
+		ID:        n.ID,
+		DisputeID: n.DisputeID,
+		ActorID:   n.ActorID,
+		Note:      n.Note,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+// toResponse leaves out notes rather than rendering an empty list, as listed disputes carry none.
+func (n DisputeNotes) toResponse() []pkg.DisputeNote {
+	if len(n) == 0 {
+		return nil
+	}
+
+	res := make([]pkg.DisputeNote, 0, len(n))
+	for _, note := range n {
+		res = append(res, note.ToResponse())
+	}
+
+	return res
+}
+
+type QueryDisputes struct {
+	WalletIDs      []string
+	TransactionIDs []string
+	Statuses       []string
+	DueBefore      *time.Time
+
+	pagination.Paginator
+}
+
+func (q QueryDisputes) FromRequest(req pkg.ListDisputesRequest) QueryDisputes {
+	return QueryDisputes{
+		WalletIDs:      req.WalletIDs,
+		TransactionIDs: req.TransactionIDs,
+		Statuses:       req.Statuses.String(),
+		DueBefore:      req.DueBefore,
+		Paginator:      req.Paginator,
+	}
+}
+
+type OpenDisputeRequest struct {
+	TransactionID     string
+	Reason            string
+	ProvisionalCredit bool
+	DueAt             *time.Time
+}
+
+func (r OpenDisputeRequest) FromRequest(req pkg.OpenDisputeRequest) OpenDisputeRequest {
+	return OpenDisputeRequest{
+		TransactionID:     req.TransactionID,
+		Reason:            req.Reason,
+		ProvisionalCredit: req.ProvisionalCredit,
+		DueAt:             req.DueAt,
+	}
+}
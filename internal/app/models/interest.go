@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+// DateLayout is the layout of the days interest accrues on.
+const DateLayout = "2006-01-02"
+
+// InterestAccrual is the interest a wallet earned on a day, until it is paid out by the monthly interest
+// credit in TransactionID.
+type InterestAccrual struct {
+	WalletID string `gorm:"primaryKey"`
+	// AccruedOn is the day, in DateLayout, the interest accrued on.
+	AccruedOn string `gorm:"primaryKey"`
+	// Balance is the balance at the end of the day the interest accrued on.
+	Balance           int
+	AnnualBasisPoints int
+	Amount            int
+	// Carry is the fraction of a minor unit left over, carried to the next day.
+	Carry         int
+	TransactionID *string
+	CreatedAt     time.Time
+}
+
+type InterestAccruals []InterestAccrual
+
+func (a InterestAccruals) Total() int {
+	total := 0
+	for _, accrual := range a {
+		total += accrual.Amount
+	}
+
+	return total
+}
+
+// BalanceAt returns the balance of a wallet ledger at end, as the ledger records it now: debits count from
+// their creation and credits from their completion, leaving out those that failed or were rejected since.
+func (t Transactions) BalanceAt(end time.Time) int {
+	balance := 0
+
+	for _, transaction := range t {
+		switch {
+		case transaction.Type == string(types.TransactionTypeCredit) &&
+			transaction.Status == string(types.TransactionStatusCompleted) && transaction.UpdatedAt.Before(end):
+			balance += transaction.Amount
+		case transaction.Type == string(types.TransactionTypeDebit) &&
+			holdsFunds(transaction.Status) && transaction.CreatedAt.Before(end):
+			balance -= transaction.Amount
+		}
+	}
+
+	return balance
+}
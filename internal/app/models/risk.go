@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	pkg "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+)
+
+// RiskDecision records the outcome of the risk rules run before a transaction was created.
+type RiskDecision struct {
+	ID       string
+	WalletID string
+	// TransactionID is the transaction created, which denied transactions have none of.
+	TransactionID  *string
+	IdempotencyKey string
+	Type           string
+	Amount         int
+	Decision       string
+	CreatedAt      time.Time
+	// Hits are the rules that did not allow the transaction.
+	Hits RiskRuleHits `gorm:"-"`
+}
+
+type RiskDecisions []RiskDecision
+
+// RiskRuleHit is the verdict of a rule that did not allow a transaction.
+type RiskRuleHit struct {
+	ID         string
+	DecisionID string
+	Rule       string
+	Decision   string
+	Reason     string
+}
+
+type RiskRuleHits []RiskRuleHit
+
+func (d RiskDecision) ToResponse() pkg.RiskDecision {
+	return pkg.RiskDecision{
+		ID:             d.ID,
+		WalletID:       d.WalletID,
+		TransactionID:  d.TransactionID,
+		IdempotencyKey: d.IdempotencyKey,
+		Type:           types.TransactionType(d.Type),
+		Amount:         d.Amount,
+		Decision:       types.RiskDecision(d.Decision),
+		Hits:           d.Hits.ToResponse(),
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func (d RiskDecisions) ToResponse() []pkg.RiskDecision {
+	res := make([]pkg.RiskDecision, 0, len(d))
+	for _, decision := range d {
+		res = append(res, decision.ToResponse())
+	}
+
+	return res
+}
+
+func (h RiskRuleHit) ToResponse() pkg.RiskRuleHit {
+	return pkg.RiskRuleHit{
+		Rule:     h.Rule,
+		Decision: types.RiskDecision(h.Decision),
+		Reason:   h.Reason,
+	}
+}
+
+func (h RiskRuleHits) ToResponse() []pkg.RiskRuleHit {
+	res := make([]pkg.RiskRuleHit, 0, len(h))
+	for _, hit := range h {
+		res = append(res, hit.ToResponse())
+	}
+
+	return res
+}
+
+type QueryRiskDecisions struct {
+	WalletIDs      []string
+	TransactionIDs []string
+	Decisions      []string
+
+	pagination.Paginator
+}
+
+func (q QueryRiskDecisions) FromRequest(req pkg.ListRiskDecisionsRequest) QueryRiskDecisions {
+	return QueryRiskDecisions{
+		WalletIDs:      req.WalletIDs,
+		TransactionIDs: req.TransactionIDs,
+		Decisions:      req.Decisions.String(),
+		Paginator:      req.Paginator,
+	}
+}
@@ -1,8 +1,11 @@
 package models
 
 import (
+	"cmp"
+	"context"
 	"time"
 
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 	pkg "github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
@@ -10,14 +13,27 @@ import (
 )
 
 type Transaction struct {
-	ID        string
-	WalletID  string
+	ID       string
+	WalletID string
+	// ParentID links a fee transaction to the transaction it was charged on. Linked transactions follow
+	// the status of their parent.
+	ParentID  *string
 	Amount    int
 	Note      *string
 	Type      string
 	Status    string
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// Bucket is the balance bucket a credit funds. Debits leave it empty, as they may take from several.
+	Bucket string
+	// ExpiresAt is when what is left of a credit is written off.
+	ExpiresAt *time.Time
+	// System marks the postings the service makes on its own account: the credits and reversals of
+	// disputes, the write-offs of expired credit and interest payouts. The velocity rule does not count them.
+	System bool
+	// Fees are the linked fee transactions created along with the transaction.
+	Fees Transactions `gorm:"-" json:",omitempty"`
 }
 
 type Transactions []Transaction
@@ -26,15 +42,29 @@ func (t Transaction) ToResponse() pkg.Transaction {
 	return pkg.Transaction{
 		ID:        t.ID,
 		WalletID:  t.WalletID,
+		ParentID:  t.ParentID,
 		Amount:    t.Amount,
 		Note:      t.Note,
 		Type:      types.TransactionType(t.Type),
 		Status:    types.TransactionStatus(t.Status),
+		Bucket:    types.BalanceBucket(t.Bucket),
+		ExpiresAt: t.ExpiresAt,
+		Version:   t.Version,
 		CreatedAt: t.CreatedAt,
 		UpdatedAt: t.UpdatedAt,
+		Fees:      t.Fees.toResponse(),
 	}
 }
 
+// toResponse leaves out fees rather than rendering an empty list, as most transactions carry none.
+func (t Transactions) toResponse() []pkg.Transaction {
+	if len(t) == 0 {
+		return nil
+	}
+
+	return t.ToResponse()
+}
+
 func (t Transactions) ToResponse() []pkg.Transaction {
 	res := make([]pkg.Transaction, 0, len(t))
 	for _, transaction := range t {
@@ -51,6 +81,12 @@ type QueryTransactions struct {
 	Types         []string
 	CreatedAtFrom *time.Time
 	CreatedAtTo   *time.Time
+	UpdatedAtFrom *time.Time
+	UpdatedAtTo   *time.Time
+	AmountMin     *int
+	AmountMax     *int
+	NoteContains  *string
+	Sort          types.TransactionSort
 
 	pagination.Paginator
 }
@@ -63,20 +99,35 @@ func (q QueryTransactions) FromRequest(req pkg.ListTransactionsRequest) QueryTra
 		Types:         req.Types.String(),
 		CreatedAtFrom: req.CreatedAtFrom,
 		CreatedAtTo:   req.CreatedAtTo,
+		UpdatedAtFrom: req.UpdatedAtFrom,
+		UpdatedAtTo:   req.UpdatedAtTo,
+		AmountMin:     req.AmountMin,
+		AmountMax:     req.AmountMax,
+		NoteContains:  req.Note,
+		Sort:          sortOrDefault(req.Sort),
 		Paginator:     req.Paginator,
 	}
 }
 
+func sortOrDefault(sort *types.TransactionSort) types.TransactionSort {
+	if sort == nil {
+		return types.TransactionSortCreatedAtDesc
+	}
+
+	return *sort
+}
+
 func (t Transactions) Balance() int {
 	balance := 0
 
 	for _, transaction := range t {
-		if transaction.Status == string(types.TransactionStatusFailed) {
+		if transaction.Status == string(types.TransactionStatusFailed) ||
+			transaction.Status == string(types.TransactionStatusRejected) {
 			continue
 		}
 
 		if transaction.Type == string(types.TransactionTypeCredit) &&
-			transaction.Status != string(types.TransactionStatusPending) { //nolint:wsl
+			transaction.Status == string(types.TransactionStatusCompleted) { //nolint:wsl
 
 			balance += transaction.Amount
 		} else if transaction.Type == string(types.TransactionTypeDebit) {
@@ -90,10 +141,20 @@ func (t Transactions) Balance() int {
 var (
 	TransactionStates = fsm.Events{
 		{
-			Name: string(types.TransactionStatusPending),
+			Name: string(types.TransactionStatusAwaitingApproval),
 			Src:  []string{""},
+			Dst:  string(types.TransactionStatusAwaitingApproval),
+		},
+		{
+			Name: string(types.TransactionStatusPending),
+			Src:  []string{"", string(types.TransactionStatusAwaitingApproval)},
 			Dst:  string(types.TransactionStatusPending),
 		},
+		{
+			Name: string(types.TransactionStatusRejected),
+			Src:  []string{string(types.TransactionStatusAwaitingApproval)},
+			Dst:  string(types.TransactionStatusRejected),
+		},
 		{
 			Name: string(types.TransactionStatusCompleted),
 			Src:  []string{string(types.TransactionStatusPending)},
@@ -113,6 +174,18 @@ type CreateTransactionRequest struct {
 	Note           *string
 	Type           string
 	IdempotencyKey string
+	// Adjustment marks a manual correction, which always awaits approval.
+	Adjustment bool
+	// Bucket is the balance bucket a credit funds, cash when empty.
+	Bucket    string
+	ExpiresAt *time.Time
+	// System marks a posting the service makes on its own account, such as the credits and reversals of
+	// disputes, which is charged no fees, is not screened by the risk rules and may take the balance below
+	// zero. It is never set from an API request.
+	System bool
+	// Record, when set, is called with the stored transaction in the database transaction that stores it,
+	// so that callers can record it atomically. Like System, it is only set by internal callers.
+	Record func(ctx context.Context, transaction Transaction) error
 }
 
 func (r CreateTransactionRequest) FromRequest(req pkg.CreateTransactionRequest) CreateTransactionRequest {
@@ -122,15 +195,80 @@ func (r CreateTransactionRequest) FromRequest(req pkg.CreateTransactionRequest)
 		Note:           req.Note,
 		Type:           req.Type.String(),
 		IdempotencyKey: req.IdempotencyKey,
+		Adjustment:     req.Adjustment,
+		Bucket:         stringOrEmpty(req.Bucket),
+		ExpiresAt:      req.ExpiresAt,
 	}
 }
 
+func stringOrEmpty(bucket *types.BalanceBucket) string {
+	if bucket == nil {
+		return ""
+	}
+
+	return bucket.String()
+}
+
+type QuoteTransactionRequest struct {
+	WalletID string
+	Amount   int
+	Type     string
+}
+
+func (r QuoteTransactionRequest) FromRequest(req pkg.QuoteTransactionRequest) QuoteTransactionRequest {
+	return QuoteTransactionRequest{
+		WalletID: req.WalletID,
+		Amount:   req.Amount,
+		Type:     req.Type.String(),
+	}
+}
+
+// TransactionQuote is the preview of the fees a transaction would be charged.
+type TransactionQuote struct {
+	WalletID string
+	Currency string
+	Type     string
+	Amount   int
+	Fees     fees.Fees
+}
+
+func (q TransactionQuote) ToResponse() pkg.TransactionQuote {
+	res := pkg.TransactionQuote{
+		WalletID:  q.WalletID,
+		Currency:  types.Currency(q.Currency),
+		Type:      types.TransactionType(q.Type),
+		Amount:    q.Amount,
+		Fees:      make([]pkg.Fee, 0, len(q.Fees)),
+		TotalFees: q.Fees.Total(),
+	}
+
+	for _, fee := range q.Fees {
+		res.Fees = append(res.Fees, pkg.Fee{Rule: fee.Rule, Amount: fee.Amount})
+	}
+
+	res.BalanceChange = q.Amount - res.TotalFees
+	if q.Type == string(types.TransactionTypeDebit) {
+		res.BalanceChange = -q.Amount - res.TotalFees
+	}
+
+	return res
+}
+
 func (r CreateTransactionRequest) ToTransaction() Transaction {
-	return Transaction{
+	transaction := Transaction{
 		WalletID: r.WalletID,
 		Amount:   r.Amount,
 		Note:     r.Note,
 		Type:     r.Type,
 		Status:   string(types.TransactionStatusPending),
+		Version:  1,
+		System:   r.System,
+	}
+
+	if r.Type == string(types.TransactionTypeCredit) {
+		transaction.Bucket = cmp.Or(r.Bucket, string(types.BalanceBucketCash))
+		transaction.ExpiresAt = r.ExpiresAt
 	}
+
+	return transaction
 }
@@ -11,11 +11,16 @@ import (
 
 type Wallets []Wallet
 type Wallet struct {
-	ID        string
-	OwnerID   string
-	Currency  string
-	Status    string
-	Balance   *int `gorm:"-"`
+	ID       string
+	OwnerID  string
+	Currency string
+	// Product sets the rules the wallet follows, such as the interest it earns. Empty for plain wallets.
+	Product string
+	Status  string
+	Balance *int `gorm:"-"`
+	// Buckets split the balance by bucket, when it is looked up.
+	Buckets   BalanceBuckets `gorm:"-"`
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt
@@ -26,8 +31,11 @@ func (w Wallet) ToResponse() pkg.Wallet {
 		ID:        w.ID,
 		OwnerID:   w.OwnerID,
 		Currency:  types.Currency(w.Currency),
+		Product:   w.Product,
 		Status:    types.WalletStatus(w.Status),
 		Balance:   w.Balance,
+		Buckets:   w.Buckets.ToResponse(),
+		Version:   w.Version,
 		CreatedAt: w.CreatedAt,
 		UpdatedAt: w.UpdatedAt,
 	}
@@ -47,6 +55,7 @@ type QueryWallets struct {
 	IDs        []string
 	OwnerIDs   []string
 	Currencies []string
+	Products   []string
 
 	pagination.Paginator
 }
@@ -56,6 +65,7 @@ func (q QueryWallets) FromRequest(req pkg.ListWalletsRequest) QueryWallets {
 		IDs:        req.IDs,
 		OwnerIDs:   req.OwnerIDs,
 		Currencies: req.Currencies.String(),
+		Products:   req.Products,
 		Paginator:  req.Paginator,
 	}
 }
@@ -63,6 +73,7 @@ func (q QueryWallets) FromRequest(req pkg.ListWalletsRequest) QueryWallets {
 type CreateWalletRequest struct {
 	OwnerID  string
 	Currency string
+	Product  string
 	Status   string
 }
 
@@ -70,6 +81,7 @@ func (c CreateWalletRequest) FromRequest(req pkg.CreateWalletRequest) CreateWall
 	return CreateWalletRequest{
 		OwnerID:  req.OwnerID,
 		Currency: req.Currency.String(),
+		Product:  req.Product,
 		Status:   string(types.WalletStatusActive),
 	}
 }
@@ -78,6 +90,8 @@ func (c CreateWalletRequest) ToWallet() Wallet {
 	return Wallet{
 		OwnerID:  c.OwnerID,
 		Currency: c.Currency,
+		Product:  c.Product,
 		Status:   c.Status,
+		Version:  1,
 	}
 }
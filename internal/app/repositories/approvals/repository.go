@@ -0,0 +1,44 @@
+package approvals
+
+import (
+	"context"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"gorm.io/gorm"
+)
+
+// Repository stores the approval trail of transactions. Entries are only ever added.
+type Repository struct {
+	dblib.TxManager
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		TxManager: dblib.NewTxManager(db),
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, approval models.TransactionApproval) (
+	models.TransactionApproval, error) {
+	if err := r.DB(ctx).Create(&approval).Error; err != nil {
+		return models.TransactionApproval{}, err
+	}
+
+	return approval, nil
+}
+
+// ListByTransaction returns the approval trail of a transaction, oldest entry first.
+func (r *Repository) ListByTransaction(ctx context.Context, transactionID string) (
+	models.TransactionApprovals, error) {
+	var approvals models.TransactionApprovals
+
+	if err := r.DB(ctx).
+		Where("transaction_id = ?", transactionID).
+		Order("created_at ASC, id ASC").
+		Find(&approvals).Error; err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
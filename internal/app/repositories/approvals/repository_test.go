@@ -0,0 +1,59 @@
+package approvals_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/approvals"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/repotest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRepository_ListByTransaction(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		_, err := wallets.New(db).Create(t.Context(), models.Wallet{
+			ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: "active", Version: 1,
+		})
+		require.NoError(t, err)
+
+		for _, id := range []string{"txn-1", "txn-2"} {
+			_, err := transactions.New(db).Create(t.Context(), models.Transaction{
+				ID: id, WalletID: "wallet-1", Amount: 100, Type: string(types.TransactionTypeDebit),
+				Status: string(types.TransactionStatusAwaitingApproval), Version: 1,
+			})
+			require.NoError(t, err)
+		}
+
+		repo := approvals.New(db)
+		start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		for i, approval := range []models.TransactionApproval{
+			{ID: "approval-1", TransactionID: "txn-1", Action: "requested", ActorID: "maker", Reason: "adjustment"},
+			{ID: "approval-2", TransactionID: "txn-2", Action: "requested", ActorID: "maker", Reason: "adjustment"},
+			{ID: "approval-3", TransactionID: "txn-1", Action: "approved", ActorID: "checker", Reason: "verified"},
+		} {
+			approval.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+
+			_, err := repo.Create(t.Context(), approval)
+			require.NoError(t, err)
+		}
+
+		trail, err := repo.ListByTransaction(t.Context(), "txn-1")
+		require.NoError(t, err)
+		require.Len(t, trail, 2)
+		assert.Equal(t, "approval-1", trail[0].ID)
+		assert.Equal(t, "approval-3", trail[1].ID)
+		assert.Equal(t, "maker", trail.Maker())
+
+		_, err = repo.Create(t.Context(), models.TransactionApproval{
+			ID: "approval-4", TransactionID: "missing", Action: "approved", ActorID: "checker", Reason: "verified",
+		})
+		require.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+	})
+}
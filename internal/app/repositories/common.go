@@ -1,6 +1,9 @@
 package repositories
 
 import (
+	"errors"
+	"slices"
+
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
 	"gorm.io/gorm"
 )
@@ -10,6 +13,18 @@ const (
 	MaxPerPage      = 10000
 )
 
+// ErrVersionConflict is returned when an update did not match the expected row version,
+// meaning the row was changed by someone else since it was read.
+var ErrVersionConflict = errors.New("resource was modified by another request")
+
+// ErrStaleFence is returned when a write carries a wallet fencing token that is no longer current,
+// meaning the wallet lock expired and was taken by another writer.
+var ErrStaleFence = errors.New("wallet lock was lost to another writer")
+
+// ErrInvalidSort is returned when a listing is asked for an ordering outside its whitelist. Sort fields end
+// up in ORDER BY clauses, so they are checked here as well as at the API boundary.
+var ErrInvalidSort = errors.New("invalid sort field")
+
 func Paginate(paginator pagination.Paginator) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		page := 1
@@ -61,3 +76,175 @@ func CountTotal(db *gorm.DB, paginator pagination.Paginator, count int) (int64,
 
 	return total, err
 }
+
+// UpdateVersioned saves all fields of model only if the stored row still has the given version.
+// The caller is expected to have already bumped the version on model.
+func UpdateVersioned(db *gorm.DB, model any, expectedVersion int) error {
+	result := db.Model(model).Where("version = ?", expectedVersion).Select("*").Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
+
+// KeysetOrder describes how a keyset-paginated listing is sorted. The ID column is always appended as a
+// tie-breaker, so an empty Column orders by ID alone.
+type KeysetOrder struct {
+	// Name identifies the ordering inside cursors, so a cursor cannot be replayed under another sort.
+	Name       string
+	Column     string
+	Descending bool
+	// ParseKey turns the Column value stored in a cursor back into a query argument.
+	ParseKey func(key string) (any, error)
+}
+
+// IDKeysetOrder orders by ID, newest first. IDs are ULIDs, so this ordering is stable while rows are inserted.
+var IDKeysetOrder = KeysetOrder{Descending: true}
+
+// FindByCursor runs a keyset-paginated query, which needs neither OFFSET nor COUNT(*) and does not skip or
+// repeat rows while new ones are inserted. cursorOf builds the cursor pointing at an item.
+func FindByCursor[T any](db *gorm.DB, paginator pagination.Paginator, order KeysetOrder,
+	cursorOf func(T) pagination.Cursor) ([]T, *pagination.Pagination, error) {
+	limit := CursorLimit(paginator)
+
+	backwards := paginator.Before != nil
+	// Walking backwards flips the order; the page is reversed again once fetched.
+	descending := order.Descending != backwards
+
+	db, err := applyCursor(db, paginator, order, descending)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []T
+	if err := db.Order(keysetOrderClause(order.Column, descending)).Limit(limit + 1).Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	if backwards {
+		slices.Reverse(items)
+	}
+
+	return items, CursorPagination(items, cursorOf, paginator, order, limit, hasMore), nil
+}
+
+// CursorLimit returns the page size requested for a keyset-paginated listing.
+func CursorLimit(paginator pagination.Paginator) int {
+	if paginator.Limit != nil {
+		return min(*paginator.Limit, MaxPerPage)
+	}
+
+	return DefaultPageSize
+}
+
+// CursorPagination describes a keyset page. items must be in listing order and hasMore tells whether
+// further items exist in the direction that was walked.
+func CursorPagination[T any](items []T, cursorOf func(T) pagination.Cursor, paginator pagination.Paginator,
+	order KeysetOrder, limit int, hasMore bool) *pagination.Pagination {
+	next, prev := cursorsFor(items, cursorOf, paginator, hasMore)
+	if next != nil {
+		next.Sort = order.Name
+	}
+
+	if prev != nil {
+		prev.Sort = order.Name
+	}
+
+	return pagination.NewCursorPagination(len(items), limit, next, prev)
+}
+
+func applyCursor(db *gorm.DB, paginator pagination.Paginator, order KeysetOrder, descending bool) (*gorm.DB, error) {
+	cursor, err := ParseCursor(paginator, order)
+	if err != nil || cursor == nil {
+		return db, err
+	}
+
+	operator := ">"
+	if descending {
+		operator = "<"
+	}
+
+	if order.Column == "" {
+		return db.Where("id "+operator+" ?", cursor.ID), nil
+	}
+
+	key, err := order.ParseKey(cursor.Key)
+	if err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+
+	return db.Where("("+order.Column+", id) "+operator+" (?, ?)", key, cursor.ID), nil
+}
+
+// ParseCursor decodes the after or before cursor of paginator. It returns nil when neither is set, and
+// pagination.ErrInvalidCursor when the cursor is malformed or was issued for a different ordering.
+func ParseCursor(paginator pagination.Paginator, order KeysetOrder) (*pagination.Cursor, error) {
+	encoded := paginator.After
+	if paginator.Before != nil {
+		encoded = paginator.Before
+	}
+
+	if encoded == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	cursor, err := pagination.DecodeCursor(*encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Sort != order.Name {
+		return nil, pagination.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func keysetOrderClause(column string, descending bool) string {
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	if column == "" {
+		return "id " + direction
+	}
+
+	return column + " " + direction + ", id " + direction
+}
+
+func cursorsFor[T any](items []T, cursorOf func(T) pagination.Cursor, paginator pagination.Paginator, hasMore bool) (
+	*pagination.Cursor, *pagination.Cursor) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	var next, prev *pagination.Cursor
+
+	// Walking forwards, more items means a next page and having started from a cursor means a previous one.
+	// Walking backwards the two are swapped.
+	hasNext := hasMore || paginator.Before != nil
+	hasPrev := paginator.After != nil || (paginator.Before != nil && hasMore)
+
+	if hasNext {
+		cursor := cursorOf(items[len(items)-1])
+		next = &cursor
+	}
+
+	if hasPrev {
+		cursor := cursorOf(items[0])
+		prev = &cursor
+	}
+
+	return next, prev
+}
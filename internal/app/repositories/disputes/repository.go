@@ -0,0 +1,133 @@
+package disputes
+
+import (
+	"context"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"gorm.io/gorm"
+)
+
+// Repository stores disputes along with their notes. Notes are only ever added.
+type Repository struct {
+	dblib.TxManager
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		TxManager: dblib.NewTxManager(db),
+	}
+}
+
+// Create stores a dispute. It fails with gorm.ErrDuplicatedKey when the transaction is already disputed.
+func (r *Repository) Create(ctx context.Context, dispute models.Dispute) (models.Dispute, error) {
+	if err := r.DB(ctx).Create(&dispute).Error; err != nil {
+		return models.Dispute{}, err
+	}
+
+	return dispute, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id string) (models.Dispute, error) {
+	var dispute models.Dispute
+	if err := r.DB(ctx).First(&dispute, "id = ?", id).Error; err != nil {
+		return models.Dispute{}, err
+	}
+
+	return dispute, nil
+}
+
+func (r *Repository) GetByTransactionID(ctx context.Context, transactionID string) (models.Dispute, error) {
+	var dispute models.Dispute
+	if err := r.DB(ctx).First(&dispute, "transaction_id = ?", transactionID).Error; err != nil {
+		return models.Dispute{}, err
+	}
+
+	return dispute, nil
+}
+
+func (r *Repository) Update(ctx context.Context, dispute models.Dispute) (models.Dispute, error) {
+	expectedVersion := dispute.Version
+	dispute.Version++
+
+	if err := repositories.UpdateVersioned(r.DB(ctx), &dispute, expectedVersion); err != nil {
+		return models.Dispute{}, err
+	}
+
+	return dispute, nil
+}
+
+func (r *Repository) List(ctx context.Context, query models.QueryDisputes) (
+	[]models.Dispute, *pagination.Pagination, error) {
+	var disputes []models.Dispute
+
+	queryBuilder := r.DB(ctx).Model(&models.Dispute{})
+	applyFilters(queryBuilder, query)
+
+	if query.IsCursor() {
+		return repositories.FindByCursor(queryBuilder, query.Paginator, repositories.IDKeysetOrder,
+			func(dispute models.Dispute) pagination.Cursor {
+				return pagination.Cursor{ID: dispute.ID}
+			})
+	}
+
+	paginator := repositories.GetPaginator(query.Paginator)
+
+	if err := queryBuilder.Scopes(repositories.Paginate(paginator)).Find(&disputes).Error; err != nil {
+		return nil, nil, err
+	}
+
+	total, err := repositories.CountTotal(queryBuilder, paginator, len(disputes))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return disputes, pagination.NewPagination(
+		*paginator.Page,
+		len(disputes),
+		int(total),
+		*paginator.PerPage,
+	), nil
+}
+
+func applyFilters(db *gorm.DB, query models.QueryDisputes) {
+	if len(query.WalletIDs) > 0 {
+		db.Where("wallet_id IN ?", query.WalletIDs)
+	}
+
+	if len(query.TransactionIDs) > 0 {
+		db.Where("transaction_id IN ?", query.TransactionIDs)
+	}
+
+	if len(query.Statuses) > 0 {
+		db.Where("status IN ?", query.Statuses)
+	}
+
+	if query.DueBefore != nil {
+		db.Where("due_at < ?", dblib.TimeArg(db, *query.DueBefore))
+	}
+}
+
+func (r *Repository) CreateNote(ctx context.Context, note models.DisputeNote) (models.DisputeNote, error) {
+	if err := r.DB(ctx).Create(&note).Error; err != nil {
+		return models.DisputeNote{}, err
+	}
+
+	return note, nil
+}
+
+// ListNotes returns the notes of a dispute, oldest first.
+func (r *Repository) ListNotes(ctx context.Context, disputeID string) (models.DisputeNotes, error) {
+	var notes models.DisputeNotes
+
+	if err := r.DB(ctx).
+		Where("dispute_id = ?", disputeID).
+		Order("created_at ASC, id ASC").
+		Find(&notes).Error; err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
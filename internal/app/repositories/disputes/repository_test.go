@@ -0,0 +1,99 @@
+package disputes_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/disputes"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/repotest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		_, err := wallets.New(db).Create(t.Context(), models.Wallet{
+			ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: "active", Version: 1,
+		})
+		require.NoError(t, err)
+
+		for _, id := range []string{"txn-1", "txn-2"} {
+			_, err := transactions.New(db).Create(t.Context(), models.Transaction{
+				ID: id, WalletID: "wallet-1", Amount: 100, Type: string(types.TransactionTypeDebit),
+				Status: string(types.TransactionStatusCompleted), Version: 1,
+			})
+			require.NoError(t, err)
+		}
+
+		repo := disputes.New(db)
+		due := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		for i, dispute := range []models.Dispute{
+			{ID: "dispute-1", TransactionID: "txn-1", Status: string(types.DisputeStatusOpen)},
+			{ID: "dispute-2", TransactionID: "txn-2", Status: string(types.DisputeStatusUnderReview)},
+		} {
+			dispute.WalletID = "wallet-1"
+			dispute.Amount = 100
+			dispute.Reason = "not received"
+			dispute.DueAt = due.AddDate(0, 0, i)
+			dispute.Version = 1
+
+			_, err := repo.Create(t.Context(), dispute)
+			require.NoError(t, err)
+		}
+
+		_, err = repo.Create(t.Context(), models.Dispute{
+			ID: "dispute-3", TransactionID: "txn-1", WalletID: "wallet-1", Status: string(types.DisputeStatusOpen),
+			DueAt: due, Version: 1,
+		})
+		require.ErrorIs(t, err, gorm.ErrDuplicatedKey, "a transaction is disputed once")
+
+		found, err := repo.GetByTransactionID(t.Context(), "txn-2")
+		require.NoError(t, err)
+		assert.Equal(t, "dispute-2", found.ID)
+
+		found.Status = string(types.DisputeStatusWon)
+		updated, err := repo.Update(t.Context(), found)
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Version)
+
+		_, err = repo.Update(t.Context(), found)
+		require.ErrorIs(t, err, repositories.ErrVersionConflict)
+
+		dueBefore := due.Add(time.Hour)
+		listed, page, err := repo.List(t.Context(), models.QueryDisputes{
+			Statuses:  []string{string(types.DisputeStatusOpen), string(types.DisputeStatusWon)},
+			DueBefore: &dueBefore,
+		})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "dispute-1", listed[0].ID)
+		assert.Equal(t, 1, page.Total)
+
+		start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		for i, note := range []models.DisputeNote{
+			{ID: "note-2", DisputeID: "dispute-1", ActorID: "agent-1", Note: "receipt"},
+			{ID: "note-1", DisputeID: "dispute-1", ActorID: "agent-2", Note: "tracking number"},
+		} {
+			note.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+
+			_, err := repo.CreateNote(t.Context(), note)
+			require.NoError(t, err)
+		}
+
+		notes, err := repo.ListNotes(t.Context(), "dispute-1")
+		require.NoError(t, err)
+		require.Len(t, notes, 2)
+		assert.Equal(t, "note-2", notes[0].ID)
+
+		_, err = repo.CreateNote(t.Context(), models.DisputeNote{ID: "note-3", DisputeID: "missing", Note: "x"})
+		require.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+	})
+}
@@ -0,0 +1,91 @@
+package interest
+
+import (
+	"context"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"gorm.io/gorm"
+)
+
+// Repository stores the interest wallets accrue daily. Days are compared as DateLayout text, which
+// orders them by date.
+type Repository struct {
+	dblib.TxManager
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		TxManager: dblib.NewTxManager(db),
+	}
+}
+
+// CreateAccrual stores the accrual of a wallet for a day. It fails with gorm.ErrDuplicatedKey when the
+// day already accrued.
+func (r *Repository) CreateAccrual(ctx context.Context, accrual models.InterestAccrual) (
+	models.InterestAccrual, error) {
+	if err := r.DB(ctx).Create(&accrual).Error; err != nil {
+		return models.InterestAccrual{}, err
+	}
+
+	return accrual, nil
+}
+
+// LastAccrual returns the latest accrual of a wallet for a day before the given one.
+func (r *Repository) LastAccrual(ctx context.Context, walletID, before string) (models.InterestAccrual, error) {
+	var accrual models.InterestAccrual
+
+	if err := r.DB(ctx).
+		Where("wallet_id = ? AND accrued_on < ?", walletID, before).
+		Order("accrued_on DESC").
+		First(&accrual).Error; err != nil {
+		return models.InterestAccrual{}, err
+	}
+
+	return accrual, nil
+}
+
+// ListUnpostedWallets returns the IDs of the wallets with interest accrued from from up to to excluded
+// that was not paid out yet.
+func (r *Repository) ListUnpostedWallets(ctx context.Context, from, to string) ([]string, error) {
+	var walletIDs []string
+
+	if err := unposted(r.DB(ctx).Model(&models.InterestAccrual{}), from, to).
+		Distinct("wallet_id").
+		Order("wallet_id ASC").
+		Pluck("wallet_id", &walletIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return walletIDs, nil
+}
+
+// ListUnpostedAccruals returns the accruals of a wallet from from up to to excluded that were not paid
+// out yet, oldest first.
+func (r *Repository) ListUnpostedAccruals(ctx context.Context, walletID, from, to string) (
+	models.InterestAccruals, error) {
+	var accruals models.InterestAccruals
+
+	if err := unposted(r.DB(ctx), from, to).
+		Where("wallet_id = ?", walletID).
+		Order("accrued_on ASC").
+		Find(&accruals).Error; err != nil {
+		return nil, err
+	}
+
+	return accruals, nil
+}
+
+// MarkAccrualsPosted records that the accruals of a wallet for days were paid out by transactionID.
+func (r *Repository) MarkAccrualsPosted(ctx context.Context, walletID string, days []string,
+	transactionID string) error {
+	return r.DB(ctx).Model(&models.InterestAccrual{}).
+		Where("wallet_id = ? AND accrued_on IN ? AND transaction_id IS NULL", walletID, days).
+		Update("transaction_id", transactionID).Error
+}
+
+// unposted narrows db to the accruals from from up to to excluded still to be paid out. Days that earned
+// nothing have nothing to pay out.
+func unposted(db *gorm.DB, from, to string) *gorm.DB {
+	return db.Where("accrued_on >= ? AND accrued_on < ? AND amount > 0 AND transaction_id IS NULL", from, to)
+}
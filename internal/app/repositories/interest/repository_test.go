@@ -0,0 +1,75 @@
+package interest_test
+
+import (
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/interest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/repotest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRepository_Accruals(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		for _, id := range []string{"wallet-1", "wallet-2"} {
+			_, err := wallets.New(db).Create(t.Context(), models.Wallet{
+				ID: id, OwnerID: id, Currency: "USD", Product: "savings", Status: "active", Version: 1,
+			})
+			require.NoError(t, err)
+		}
+
+		repo := interest.New(db)
+
+		for _, accrual := range []models.InterestAccrual{
+			{WalletID: "wallet-1", AccruedOn: "2026-08-31", Amount: 5, Carry: 10},
+			{WalletID: "wallet-1", AccruedOn: "2026-09-01", Amount: 3, Carry: 20},
+			{WalletID: "wallet-1", AccruedOn: "2026-09-02", Amount: 0, Carry: 30},
+			{WalletID: "wallet-1", AccruedOn: "2026-09-30", Amount: 4, Carry: 40},
+			{WalletID: "wallet-2", AccruedOn: "2026-09-15", Amount: 0, Carry: 50},
+		} {
+			_, err := repo.CreateAccrual(t.Context(), accrual)
+			require.NoError(t, err)
+		}
+
+		_, err := repo.CreateAccrual(t.Context(), models.InterestAccrual{WalletID: "wallet-1", AccruedOn: "2026-09-01"})
+		require.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+		last, err := repo.LastAccrual(t.Context(), "wallet-1", "2026-09-30")
+		require.NoError(t, err)
+		assert.Equal(t, "2026-09-02", last.AccruedOn)
+		assert.Equal(t, 30, last.Carry)
+
+		_, err = repo.LastAccrual(t.Context(), "wallet-2", "2026-09-15")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		walletIDs, err := repo.ListUnpostedWallets(t.Context(), "2026-09-01", "2026-10-01")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"wallet-1"}, walletIDs)
+
+		accruals, err := repo.ListUnpostedAccruals(t.Context(), "wallet-1", "2026-09-01", "2026-10-01")
+		require.NoError(t, err)
+		require.Len(t, accruals, 2)
+		assert.Equal(t, "2026-09-01", accruals[0].AccruedOn)
+		assert.Equal(t, 7, accruals.Total())
+
+		_, err = transactions.New(db).Create(t.Context(), models.Transaction{
+			ID: "txn-1", WalletID: "wallet-1", Amount: 7, Type: string(types.TransactionTypeCredit),
+			Status: string(types.TransactionStatusCompleted), Version: 1,
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.MarkAccrualsPosted(t.Context(), "wallet-1", []string{"2026-09-01", "2026-09-30"}, "txn-1"))
+
+		walletIDs, err = repo.ListUnpostedWallets(t.Context(), "2026-09-01", "2026-10-01")
+		require.NoError(t, err)
+		assert.Empty(t, walletIDs)
+
+		walletIDs, err = repo.ListUnpostedWallets(t.Context(), "2026-08-01", "2026-09-01")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"wallet-1"}, walletIDs)
+	})
+}
@@ -0,0 +1,60 @@
+// Package repotest runs repository tests against every supported database: a fresh SQLite file always,
+// and Postgres when TEST_DATABASE_DSN is set.
+package repotest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/database/migrations"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Run runs test once per database, each time on a migrated database without rows.
+func Run(t *testing.T, test func(t *testing.T, db *gorm.DB)) {
+	t.Helper()
+
+	for _, driver := range dblib.GetDrivers() {
+		t.Run(string(driver), func(t *testing.T) {
+			test(t, open(t, driver))
+		})
+	}
+}
+
+func open(t *testing.T, driver dblib.Driver) *gorm.DB {
+	t.Helper()
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "wallet.db")
+
+	if driver == dblib.DriverPostgres {
+		dsn = os.Getenv("TEST_DATABASE_DSN")
+		if dsn == "" {
+			t.Skip("TEST_DATABASE_DSN is required")
+		}
+	}
+
+	db, err := dblib.Open(driver, dsn, &gorm.Config{Logger: gormlogger.Discard, TranslateError: true})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	provider, err := migrations.NewProvider(sqlDB, driver)
+	require.NoError(t, err)
+
+	_, err = provider.Up(t.Context())
+	require.NoError(t, err)
+
+	// The Postgres database is shared by the tests, so rows left by earlier ones are removed.
+	if driver == dblib.DriverPostgres {
+		require.NoError(t, db.Exec("TRUNCATE risk_rule_hits, risk_decisions, dispute_notes, disputes, interest_accruals, "+
+			"transaction_allocations, transaction_approvals, transactions, wallets").Error)
+	}
+
+	return db
+}
@@ -0,0 +1,139 @@
+package risk
+
+import (
+	"context"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"gorm.io/gorm"
+)
+
+// Repository stores the decisions of the risk rules along with the rules hit. Decisions are only ever
+// added.
+type Repository struct {
+	dblib.TxManager
+}
+
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		TxManager: dblib.NewTxManager(db),
+	}
+}
+
+// Create stores a decision along with its hits.
+func (r *Repository) Create(ctx context.Context, decision models.RiskDecision) (models.RiskDecision, error) {
+	err := r.Tx(ctx, func(ctx context.Context) error {
+		if err := r.DB(ctx).Create(&decision).Error; err != nil {
+			return err
+		}
+
+		for i := range decision.Hits {
+			decision.Hits[i].DecisionID = decision.ID
+
+			if err := r.DB(ctx).Create(&decision.Hits[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.RiskDecision{}, err
+	}
+
+	return decision, nil
+}
+
+// List returns decisions along with their hits.
+func (r *Repository) List(ctx context.Context, query models.QueryRiskDecisions) (
+	models.RiskDecisions, *pagination.Pagination, error) {
+	var decisions models.RiskDecisions
+
+	queryBuilder := r.DB(ctx).Model(&models.RiskDecision{})
+	applyFilters(queryBuilder, query)
+
+	if query.IsCursor() {
+		page, meta, err := repositories.FindByCursor(queryBuilder, query.Paginator, repositories.IDKeysetOrder,
+			func(decision models.RiskDecision) pagination.Cursor {
+				return pagination.Cursor{ID: decision.ID}
+			})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		decisions, err = r.withHits(ctx, page)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return decisions, meta, nil
+	}
+
+	paginator := repositories.GetPaginator(query.Paginator)
+
+	if err := queryBuilder.Scopes(repositories.Paginate(paginator)).Find(&decisions).Error; err != nil {
+		return nil, nil, err
+	}
+
+	total, err := repositories.CountTotal(queryBuilder, paginator, len(decisions))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decisions, err = r.withHits(ctx, decisions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return decisions, pagination.NewPagination(
+		*paginator.Page,
+		len(decisions),
+		int(total),
+		*paginator.PerPage,
+	), nil
+}
+
+func applyFilters(db *gorm.DB, query models.QueryRiskDecisions) {
+	if len(query.WalletIDs) > 0 {
+		db.Where("wallet_id IN ?", query.WalletIDs)
+	}
+
+	if len(query.TransactionIDs) > 0 {
+		db.Where("transaction_id IN ?", query.TransactionIDs)
+	}
+
+	if len(query.Decisions) > 0 {
+		db.Where("decision IN ?", query.Decisions)
+	}
+}
+
+// withHits attaches their hits to decisions, ordered by rule.
+func (r *Repository) withHits(ctx context.Context, decisions models.RiskDecisions) (models.RiskDecisions, error) {
+	if len(decisions) == 0 {
+		return decisions, nil
+	}
+
+	ids := make([]string, 0, len(decisions))
+	for _, decision := range decisions {
+		ids = append(ids, decision.ID)
+	}
+
+	var hits models.RiskRuleHits
+
+	if err := r.DB(ctx).Where("decision_id IN ?", ids).Order("rule ASC").Find(&hits).Error; err != nil {
+		return nil, err
+	}
+
+	byDecision := map[string]models.RiskRuleHits{}
+	for _, hit := range hits {
+		byDecision[hit.DecisionID] = append(byDecision[hit.DecisionID], hit)
+	}
+
+	for i := range decisions {
+		decisions[i].Hits = byDecision[decisions[i].ID]
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,93 @@
+package risk_test
+
+import (
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/repotest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/risk"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		_, err := wallets.New(db).Create(t.Context(), models.Wallet{
+			ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: "active", Version: 1,
+		})
+		require.NoError(t, err)
+
+		_, err = transactions.New(db).Create(t.Context(), models.Transaction{
+			ID: "txn-1", WalletID: "wallet-1", Amount: 900, Type: string(types.TransactionTypeDebit),
+			Status: string(types.TransactionStatusAwaitingApproval), Version: 1,
+		})
+		require.NoError(t, err)
+
+		repo := risk.New(db)
+		transactionID := "txn-1"
+
+		for _, decision := range []models.RiskDecision{
+			{
+				ID: "decision-1", TransactionID: &transactionID, IdempotencyKey: "key-1",
+				Amount: 900, Decision: string(types.RiskDecisionReview),
+				Hits: models.RiskRuleHits{
+					{ID: "hit-1", Rule: "velocity", Decision: string(types.RiskDecisionReview), Reason: "burst"},
+					{ID: "hit-2", Rule: "amount_threshold", Decision: string(types.RiskDecisionReview), Reason: "large"},
+				},
+			},
+			{
+				ID: "decision-2", IdempotencyKey: "key-2", Amount: 100, Decision: string(types.RiskDecisionDeny),
+				Hits: models.RiskRuleHits{
+					{ID: "hit-3", Rule: "blocklist", Decision: string(types.RiskDecisionDeny), Reason: "listed"},
+				},
+			},
+			{ID: "decision-3", IdempotencyKey: "key-3", Amount: 50, Decision: string(types.RiskDecisionAllow)},
+		} {
+			decision.WalletID = "wallet-1"
+			decision.Type = string(types.TransactionTypeDebit)
+
+			_, err := repo.Create(t.Context(), decision)
+			require.NoError(t, err)
+		}
+
+		listed, page, err := repo.List(t.Context(), models.QueryRiskDecisions{
+			Decisions: []string{string(types.RiskDecisionReview), string(types.RiskDecisionDeny)},
+		})
+		require.NoError(t, err)
+		require.Len(t, listed, 2)
+		assert.Equal(t, 2, page.Total)
+
+		byID := map[string]models.RiskDecision{}
+		for _, decision := range listed {
+			byID[decision.ID] = decision
+		}
+
+		require.Len(t, byID["decision-1"].Hits, 2)
+		assert.Equal(t, "amount_threshold", byID["decision-1"].Hits[0].Rule)
+		assert.Equal(t, "decision-1", byID["decision-1"].Hits[0].DecisionID)
+		require.Len(t, byID["decision-2"].Hits, 1)
+
+		listed, _, err = repo.List(t.Context(), models.QueryRiskDecisions{
+			TransactionIDs: []string{"txn-1"},
+			Paginator:      pagination.Paginator{Limit: ptr(2)},
+		})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "decision-1", listed[0].ID)
+		assert.Len(t, listed[0].Hits, 2)
+
+		_, err = repo.Create(t.Context(), models.RiskDecision{
+			ID: "decision-4", WalletID: "missing", IdempotencyKey: "key-4", Decision: string(types.RiskDecisionAllow),
+		})
+		require.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+	})
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
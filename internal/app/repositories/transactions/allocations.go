@@ -0,0 +1,58 @@
+package transactions
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+func (r *Repository) CreateAllocation(ctx context.Context, allocation models.TransactionAllocation) (
+	models.TransactionAllocation, error) {
+	if err := r.DB(ctx).Create(&allocation).Error; err != nil {
+		return models.TransactionAllocation{}, err
+	}
+
+	return allocation, nil
+}
+
+// ListAllocations returns the allocations of the debits of a wallet, oldest first.
+func (r *Repository) ListAllocations(ctx context.Context, walletID string) (models.TransactionAllocations, error) {
+	var allocations models.TransactionAllocations
+
+	if err := r.DB(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("created_at ASC, id ASC").
+		Find(&allocations).Error; err != nil {
+		return nil, err
+	}
+
+	return allocations, nil
+}
+
+// ListExpiredCredits returns up to limit completed credits that expired by at and still have some of
+// their amount left, soonest expired first.
+func (r *Repository) ListExpiredCredits(ctx context.Context, at time.Time, limit int) (
+	models.Transactions, error) {
+	var transactions models.Transactions
+
+	db := r.DB(ctx)
+
+	if err := db.
+		Where("type = ? AND status = ? AND expires_at <= ?",
+			types.TransactionTypeCredit, types.TransactionStatusCompleted, dblib.TimeArg(db, at)).
+		Where(`amount > COALESCE((
+			SELECT SUM(a.amount) FROM transaction_allocations a
+			JOIN transactions d ON d.id = a.transaction_id
+			WHERE a.credit_id = transactions.id AND d.status NOT IN ?), 0)`,
+			[]types.TransactionStatus{types.TransactionStatusFailed, types.TransactionStatusRejected}).
+		Order("expires_at ASC, id ASC").
+		Limit(limit).
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
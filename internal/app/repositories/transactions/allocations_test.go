@@ -0,0 +1,84 @@
+package transactions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/repotest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRepository_ListExpiredCredits(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db)
+
+		for _, credit := range []struct {
+			id        string
+			amount    int
+			status    types.TransactionStatus
+			expiresIn time.Duration
+		}{
+			{"promo-1", 100, types.TransactionStatusCompleted, time.Hour},
+			{"promo-2", 100, types.TransactionStatusCompleted, 3 * time.Hour},
+			{"promo-3", 50, types.TransactionStatusPending, time.Hour},
+			{"promo-4", 100, types.TransactionStatusCompleted, 0},
+			{"promo-5", 10, types.TransactionStatusCompleted, 30 * time.Minute},
+		} {
+			_, err := repo.Create(t.Context(), models.Transaction{
+				ID: credit.id, WalletID: "wallet-1", Amount: credit.amount, Bucket: string(types.BalanceBucketPromo),
+				ExpiresAt: ptr(start.Add(credit.expiresIn)), Type: string(types.TransactionTypeCredit),
+				Status: string(credit.status), Version: 1,
+			})
+			require.NoError(t, err)
+		}
+
+		for i, debit := range []struct {
+			id       string
+			status   types.TransactionStatus
+			creditID string
+			amount   int
+		}{
+			{"debit-1", types.TransactionStatusCompleted, "promo-4", 100},
+			{"debit-2", types.TransactionStatusFailed, "promo-1", 40},
+			{"debit-3", types.TransactionStatusPending, "promo-1", 30},
+		} {
+			_, err := repo.Create(t.Context(), models.Transaction{
+				ID: debit.id, WalletID: "wallet-1", Amount: debit.amount,
+				Type: string(types.TransactionTypeDebit), Status: string(debit.status), Version: 1,
+			})
+			require.NoError(t, err)
+
+			_, err = repo.CreateAllocation(t.Context(), models.TransactionAllocation{
+				ID: "allocation-" + debit.id, TransactionID: debit.id, WalletID: "wallet-1",
+				Bucket: string(types.BalanceBucketPromo), CreditID: ptr(debit.creditID), Amount: debit.amount,
+				CreatedAt: start.Add(time.Duration(i) * time.Minute),
+			})
+			require.NoError(t, err)
+		}
+
+		expired, err := repo.ListExpiredCredits(t.Context(), start.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"promo-5", "promo-1"}, ids(expired),
+			"pending and used up credits are left out, and failed debits give back what they took")
+
+		expired, err = repo.ListExpiredCredits(t.Context(), start.Add(2*time.Hour), 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"promo-5"}, ids(expired))
+
+		allocations, err := repo.ListAllocations(t.Context(), "wallet-1")
+		require.NoError(t, err)
+		require.Len(t, allocations, 3)
+		assert.Equal(t, "allocation-debit-1", allocations[0].ID)
+		assert.Equal(t, "promo-4", *allocations[0].CreditID)
+
+		_, err = repo.CreateAllocation(t.Context(), models.TransactionAllocation{
+			ID: "allocation-missing", TransactionID: "debit-1", WalletID: "wallet-1",
+			Bucket: string(types.BalanceBucketPromo), CreditID: ptr("missing"), Amount: 1,
+		})
+		require.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+	})
+}
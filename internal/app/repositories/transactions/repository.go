@@ -1,12 +1,19 @@
 package transactions
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 	"gorm.io/gorm"
 )
 
@@ -38,7 +45,10 @@ func (r *Repository) GetByID(ctx context.Context, id string) (models.Transaction
 }
 
 func (r *Repository) Update(ctx context.Context, transaction models.Transaction) (models.Transaction, error) {
-	if err := r.DB(ctx).Save(&transaction).Error; err != nil {
+	expectedVersion := transaction.Version
+	transaction.Version++
+
+	if err := repositories.UpdateVersioned(r.DB(ctx), &transaction, expectedVersion); err != nil {
 		return models.Transaction{}, err
 	}
 
@@ -47,15 +57,24 @@ func (r *Repository) Update(ctx context.Context, transaction models.Transaction)
 
 func (r *Repository) List(ctx context.Context, query models.QueryTransactions) (
 	[]models.Transaction, *pagination.Pagination, error) {
+	query.Sort = cmp.Or(query.Sort, types.TransactionSortCreatedAtDesc)
+	if !slices.Contains(types.GetTransactionSorts(), query.Sort) {
+		return nil, nil, fmt.Errorf("%w: %q", repositories.ErrInvalidSort, query.Sort)
+	}
+
 	var transactions []models.Transaction
 
 	queryBuilder := r.DB(ctx).Model(&models.Transaction{})
 	applyFilters(queryBuilder, query)
 
+	if query.IsCursor() {
+		return repositories.FindByCursor(queryBuilder, query.Paginator, KeysetOrder(query.Sort), CursorFor(query.Sort))
+	}
+
 	paginator := repositories.GetPaginator(query.Paginator)
 
 	if err := queryBuilder.
-		Order("created_at DESC").
+		Order(orderClause(query.Sort)).
 		Scopes(repositories.Paginate(paginator)).
 		Find(&transactions).Error; err != nil {
 		return []models.Transaction{}, &pagination.Pagination{}, err
@@ -87,6 +106,39 @@ func (r *Repository) ListAllTransactions(ctx context.Context, walletID string) (
 	return transactions, nil
 }
 
+// ListLinked returns the transactions linked to a parent transaction, oldest first.
+func (r *Repository) ListLinked(ctx context.Context, parentID string) (models.Transactions, error) {
+	var transactions models.Transactions
+
+	if err := r.DB(ctx).
+		Where("parent_id = ?", parentID).
+		Order("created_at ASC, id ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// CountByOwnerSince counts the transactions of the wallets of an owner created since a time. Linked
+// transactions, such as fees, and system postings are not counted.
+func (r *Repository) CountByOwnerSince(ctx context.Context, ownerID string, since time.Time) (int, error) {
+	var count int64
+
+	db := r.DB(ctx)
+	if err := db.Model(&models.Transaction{}).
+		Joins("JOIN wallets ON wallets.id = transactions.wallet_id").
+		Where("wallets.owner_id = ?", ownerID).
+		Where("transactions.parent_id IS NULL").
+		Where("transactions.system = ?", false).
+		Where("transactions.created_at >= ?", dblib.TimeArg(db, since)).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
 func applyFilters(db *gorm.DB, query models.QueryTransactions) {
 	if len(query.IDs) > 0 {
 		db = db.Where("id IN ?", query.IDs)
@@ -105,10 +157,96 @@ func applyFilters(db *gorm.DB, query models.QueryTransactions) {
 	}
 
 	if query.CreatedAtFrom != nil {
-		db = db.Where("created_at >= ?", *query.CreatedAtFrom)
+		db = db.Where("created_at >= ?", dblib.TimeArg(db, *query.CreatedAtFrom))
 	}
 
 	if query.CreatedAtTo != nil {
-		db = db.Where("created_at <= ?", *query.CreatedAtTo)
+		db = db.Where("created_at <= ?", dblib.TimeArg(db, *query.CreatedAtTo))
+	}
+
+	applyRangeFilters(db, query)
+}
+
+func applyRangeFilters(db *gorm.DB, query models.QueryTransactions) {
+	if query.UpdatedAtFrom != nil {
+		db = db.Where("updated_at >= ?", dblib.TimeArg(db, *query.UpdatedAtFrom))
+	}
+
+	if query.UpdatedAtTo != nil {
+		db = db.Where("updated_at <= ?", dblib.TimeArg(db, *query.UpdatedAtTo))
+	}
+
+	if query.AmountMin != nil {
+		db = db.Where("amount >= ?", *query.AmountMin)
+	}
+
+	if query.AmountMax != nil {
+		db = db.Where("amount <= ?", *query.AmountMax)
+	}
+
+	if query.NoteContains != nil {
+		pattern := "%" + escapeLike(*query.NoteContains) + "%"
+
+		// SQLite's LIKE already ignores case, but has no default escape character. On Postgres the search
+		// is served by the pg_trgm index on note.
+		if dblib.IsSQLite(db) {
+			db = db.Where(`note LIKE ? ESCAPE '\'`, pattern)
+		} else {
+			db = db.Where("note ILIKE ?", pattern)
+		}
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// orderClause is only ever built from the whitelisted types.TransactionSort values, checked by List.
+func orderClause(sort types.TransactionSort) string {
+	direction := "ASC"
+	if sort.Descending() {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, id %s", sort.Column(), direction, direction)
+}
+
+// KeysetOrder describes the keyset ordering of a transaction listing sorted by sort.
+func KeysetOrder(sort types.TransactionSort) repositories.KeysetOrder {
+	order := repositories.KeysetOrder{
+		Name:       sort.String(),
+		Column:     sort.Column(),
+		Descending: sort.Descending(),
+		ParseKey: func(key string) (any, error) {
+			return time.Parse(time.RFC3339Nano, key)
+		},
+	}
+
+	if sort.Column() == "amount" {
+		order.ParseKey = func(key string) (any, error) {
+			return strconv.Atoi(key)
+		}
+	}
+
+	return order
+}
+
+// CursorFor returns a function building the cursor of a transaction in a listing sorted by sort.
+func CursorFor(sort types.TransactionSort) func(models.Transaction) pagination.Cursor {
+	return func(transaction models.Transaction) pagination.Cursor {
+		cursor := pagination.Cursor{ID: transaction.ID}
+
+		switch sort.Column() {
+		case "amount":
+			cursor.Key = strconv.Itoa(transaction.Amount)
+		case "updated_at":
+			cursor.Key = transaction.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		default:
+			cursor.Key = transaction.CreatedAt.UTC().Format(time.RFC3339Nano)
+		}
+
+		return cursor
 	}
 }
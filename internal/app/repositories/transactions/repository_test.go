@@ -0,0 +1,364 @@
+package transactions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/repotest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var start = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// seed creates a wallet with a transaction per hour since start, of 100, 200, ... with the given notes.
+func seed(t *testing.T, db *gorm.DB, notes ...string) *transactions.Repository {
+	t.Helper()
+
+	_, err := wallets.New(db).Create(t.Context(), models.Wallet{
+		ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: "active", Version: 1,
+	})
+	require.NoError(t, err)
+
+	repo := transactions.New(db)
+
+	for i, note := range notes {
+		createdAt := start.Add(time.Duration(i) * time.Hour)
+
+		_, err := repo.Create(t.Context(), models.Transaction{
+			ID:        "txn-" + string(rune('a'+i)),
+			WalletID:  "wallet-1",
+			Amount:    (i + 1) * 100,
+			Note:      &note,
+			Type:      string(types.TransactionTypeCredit),
+			Status:    string(types.TransactionStatusCompleted),
+			Version:   1,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		})
+		require.NoError(t, err)
+	}
+
+	return repo
+}
+
+func ids(transactions []models.Transaction) []string {
+	result := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		result = append(result, transaction.ID)
+	}
+
+	return result
+}
+
+func TestRepository_ListFilters(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db, "Coffee", "rent 100%", "coffee beans", "salary", "coffee_beans")
+
+		tests := []struct {
+			name     string
+			query    models.QueryTransactions
+			expected []string
+		}{
+			{
+				name:     "IDs",
+				query:    models.QueryTransactions{IDs: []string{"txn-a", "txn-c"}},
+				expected: []string{"txn-a", "txn-c"},
+			},
+			{
+				name: "created_at range given in another time zone",
+				query: models.QueryTransactions{
+					CreatedAtFrom: ptr(start.Add(time.Hour).In(time.FixedZone("UTC+5", 5*60*60))),
+					CreatedAtTo:   ptr(start.Add(2 * time.Hour).In(time.FixedZone("UTC-3", -3*60*60))),
+				},
+				expected: []string{"txn-b", "txn-c"},
+			},
+			{
+				name:     "amount range",
+				query:    models.QueryTransactions{AmountMin: ptr(200), AmountMax: ptr(300)},
+				expected: []string{"txn-b", "txn-c"},
+			},
+			{
+				name:     "amount bounds are inclusive",
+				query:    models.QueryTransactions{AmountMin: ptr(200), AmountMax: ptr(200)},
+				expected: []string{"txn-b"},
+			},
+			{
+				name: "updated_at bounds are inclusive",
+				query: models.QueryTransactions{
+					UpdatedAtFrom: ptr(start.Add(time.Hour)),
+					UpdatedAtTo:   ptr(start.Add(time.Hour)),
+				},
+				expected: []string{"txn-b"},
+			},
+			{
+				name:     "note search ignores case",
+				query:    models.QueryTransactions{NoteContains: ptr("COFFEE")},
+				expected: []string{"txn-a", "txn-c", "txn-e"},
+			},
+			{
+				name:     "note search matches % literally",
+				query:    models.QueryTransactions{NoteContains: ptr("0%")},
+				expected: []string{"txn-b"},
+			},
+			{
+				name:     "note search matches _ literally",
+				query:    models.QueryTransactions{NoteContains: ptr("e_b")},
+				expected: []string{"txn-e"},
+			},
+			{
+				name:     "note search matches a lone % literally",
+				query:    models.QueryTransactions{NoteContains: ptr("%")},
+				expected: []string{"txn-b"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.query.Sort = types.TransactionSortCreatedAtAsc
+
+				found, _, err := repo.List(t.Context(), tt.query)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, ids(found))
+			})
+		}
+	})
+}
+
+func TestRepository_ListRejectsInvalidSort(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db, "a")
+
+		for _, sort := range []types.TransactionSort{"note", "-wallet_id", "amount; DROP TABLE transactions"} {
+			for _, paginator := range []pagination.Paginator{{}, {Limit: ptr(1)}} {
+				_, _, err := repo.List(t.Context(), models.QueryTransactions{Sort: sort, Paginator: paginator})
+				require.ErrorIs(t, err, repositories.ErrInvalidSort)
+			}
+		}
+
+		found, _, err := repo.List(t.Context(), models.QueryTransactions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"txn-a"}, ids(found), "an empty sort falls back to the default")
+	})
+}
+
+func TestRepository_ListByCursor(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db, "a", "b", "c", "d", "e")
+
+		for _, sort := range []types.TransactionSort{types.TransactionSortAmountDesc, types.TransactionSortCreatedAtDesc} {
+			t.Run(sort.String(), func(t *testing.T) {
+				query := models.QueryTransactions{Sort: sort, Paginator: pagination.Paginator{Limit: ptr(2)}}
+
+				var walked []string
+
+				for {
+					found, page, err := repo.List(t.Context(), query)
+					require.NoError(t, err)
+
+					walked = append(walked, ids(found)...)
+
+					if page.NextCursor == nil {
+						break
+					}
+
+					query.After = page.NextCursor
+				}
+
+				assert.Equal(t, []string{"txn-e", "txn-d", "txn-c", "txn-b", "txn-a"}, walked)
+			})
+		}
+	})
+}
+
+func TestRepository_ListByCursorEqualKeys(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db)
+
+		for _, id := range []string{"txn-c", "txn-a", "txn-e", "txn-b", "txn-d"} {
+			_, err := repo.Create(t.Context(), models.Transaction{
+				ID: id, WalletID: "wallet-1", Amount: 100,
+				Type: string(types.TransactionTypeCredit), Status: string(types.TransactionStatusCompleted), Version: 1,
+				CreatedAt: start, UpdatedAt: start,
+			})
+			require.NoError(t, err)
+		}
+
+		tests := []struct {
+			sort     types.TransactionSort
+			expected []string
+		}{
+			{sort: types.TransactionSortAmountDesc, expected: []string{"txn-e", "txn-d", "txn-c", "txn-b", "txn-a"}},
+			{sort: types.TransactionSortAmountAsc, expected: []string{"txn-a", "txn-b", "txn-c", "txn-d", "txn-e"}},
+			{sort: types.TransactionSortCreatedAtDesc, expected: []string{"txn-e", "txn-d", "txn-c", "txn-b", "txn-a"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.sort.String(), func(t *testing.T) {
+				query := models.QueryTransactions{Sort: tt.sort, Paginator: pagination.Paginator{Limit: ptr(2)}}
+
+				var (
+					walked []string
+					last   *pagination.Pagination
+				)
+
+				for {
+					found, page, err := repo.List(t.Context(), query)
+					require.NoError(t, err)
+
+					walked = append(walked, ids(found)...)
+					last = page
+
+					if page.NextCursor == nil {
+						break
+					}
+
+					query.After = page.NextCursor
+				}
+
+				assert.Equal(t, tt.expected, walked, "ties on the sort key are broken by id")
+
+				query.After = nil
+				walked = nil
+
+				for cursor := last.PrevCursor; cursor != nil; {
+					query.Before = cursor
+
+					found, page, err := repo.List(t.Context(), query)
+					require.NoError(t, err)
+
+					walked = append(ids(found), walked...)
+					cursor = page.PrevCursor
+				}
+
+				assert.Equal(t, tt.expected[:len(tt.expected)-1], walked, "walking back from the last page")
+			})
+		}
+	})
+}
+
+func TestRepository_ListByCursorInvalid(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db, "a", "b", "c")
+
+		_, page, err := repo.List(t.Context(), models.QueryTransactions{
+			Sort: types.TransactionSortAmountDesc, Paginator: pagination.Paginator{Limit: ptr(1)},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, page.NextCursor)
+
+		badKey := pagination.Cursor{ID: "txn-b", Key: "not-a-number", Sort: "-amount"}.Encode()
+
+		tests := []struct {
+			name  string
+			query models.QueryTransactions
+		}{
+			{
+				name:  "garbage after",
+				query: models.QueryTransactions{Paginator: pagination.Paginator{After: ptr("garbage")}},
+			},
+			{
+				name:  "garbage before",
+				query: models.QueryTransactions{Paginator: pagination.Paginator{Before: ptr("garbage")}},
+			},
+			{
+				name: "cursor issued for another sort",
+				query: models.QueryTransactions{
+					Sort: types.TransactionSortCreatedAtDesc, Paginator: pagination.Paginator{After: page.NextCursor},
+				},
+			},
+			{
+				name: "sort key of the wrong type",
+				query: models.QueryTransactions{
+					Sort: types.TransactionSortAmountDesc, Paginator: pagination.Paginator{After: &badKey},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, _, err := repo.List(t.Context(), tt.query)
+				require.ErrorIs(t, err, pagination.ErrInvalidCursor)
+			})
+		}
+	})
+}
+
+func TestRepository_ListAllTransactions(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db, "a", "b", "c")
+
+		ledger, err := repo.ListAllTransactions(t.Context(), "wallet-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"txn-c", "txn-b", "txn-a"}, ids(ledger))
+		assert.Equal(t, 600, ledger.Balance())
+	})
+}
+
+func TestRepository_ListLinked(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db, "a", "b")
+
+		for i, id := range []string{"fee-2", "fee-1"} {
+			_, err := repo.Create(t.Context(), models.Transaction{
+				ID: id, WalletID: "wallet-1", ParentID: ptr("txn-a"), Amount: 10,
+				Type: string(types.TransactionTypeDebit), Status: string(types.TransactionStatusPending), Version: 1,
+				CreatedAt: start.Add(time.Duration(i) * time.Minute),
+			})
+			require.NoError(t, err)
+		}
+
+		linked, err := repo.ListLinked(t.Context(), "txn-a")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"fee-2", "fee-1"}, ids(linked))
+
+		linked, err = repo.ListLinked(t.Context(), "txn-b")
+		require.NoError(t, err)
+		assert.Empty(t, linked)
+
+		_, err = repo.Create(t.Context(), models.Transaction{
+			ID: "fee-3", WalletID: "wallet-1", ParentID: ptr("missing"), Amount: 10,
+			Type: string(types.TransactionTypeDebit), Status: string(types.TransactionStatusPending), Version: 1,
+		})
+		require.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+	})
+}
+
+func TestRepository_CountByOwnerSince(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := seed(t, db, "a", "b", "c")
+
+		_, err := repo.Create(t.Context(), models.Transaction{
+			ID: "fee-1", WalletID: "wallet-1", ParentID: ptr("txn-c"), Amount: 10,
+			Type: string(types.TransactionTypeDebit), Status: string(types.TransactionStatusPending), Version: 1,
+			CreatedAt: start.Add(3 * time.Hour),
+		})
+		require.NoError(t, err)
+
+		_, err = repo.Create(t.Context(), models.Transaction{
+			ID: "expiry-1", WalletID: "wallet-1", Amount: 10, System: true,
+			Type: string(types.TransactionTypeDebit), Status: string(types.TransactionStatusCompleted), Version: 1,
+			CreatedAt: start.Add(3 * time.Hour),
+		})
+		require.NoError(t, err)
+
+		count, err := repo.CountByOwnerSince(t.Context(), "owner-1", start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count, "counts from since on, leaving out linked transactions and system postings")
+
+		count, err = repo.CountByOwnerSince(t.Context(), "owner-2", start)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -38,13 +38,52 @@ func (r *Repository) GetByID(ctx context.Context, id string) (models.Wallet, err
 }
 
 func (r *Repository) Update(ctx context.Context, wallet models.Wallet) (models.Wallet, error) {
-	if err := r.DB(ctx).Save(&wallet).Error; err != nil {
+	expectedVersion := wallet.Version
+	wallet.Version++
+
+	if err := repositories.UpdateVersioned(r.DB(ctx), &wallet, expectedVersion); err != nil {
 		return models.Wallet{}, err
 	}
 
 	return wallet, nil
 }
 
+// AdvanceFence hands out the next fencing token of the wallet. It is taken right after locking the
+// wallet, so a later holder of the lock always carries a greater token.
+func (r *Repository) AdvanceFence(ctx context.Context, walletID string) (int64, error) {
+	var token int64
+
+	result := r.DB(ctx).
+		Raw("UPDATE wallets SET fence_token = fence_token + 1 WHERE id = ? RETURNING fence_token", walletID).
+		Scan(&token)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return token, nil
+}
+
+// CheckFence fails with repositories.ErrStaleFence unless token is the current fencing token of the
+// wallet. Called inside a transaction it keeps the wallet row locked until commit, so the token cannot
+// be advanced before the writes it guards are committed.
+func (r *Repository) CheckFence(ctx context.Context, walletID string, token int64) error {
+	result := r.DB(ctx).Exec("UPDATE wallets SET fence_token = fence_token WHERE id = ? AND fence_token = ?",
+		walletID, token)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrStaleFence
+	}
+
+	return nil
+}
+
 func (r *Repository) List(ctx context.Context, query models.QueryWallets) (
 	[]models.Wallet, *pagination.Pagination, error) {
 	var wallets []models.Wallet
@@ -52,6 +91,13 @@ func (r *Repository) List(ctx context.Context, query models.QueryWallets) (
 	queryBuilder := r.DB(ctx).Model(&models.Wallet{})
 	applyFilters(queryBuilder, query)
 
+	if query.IsCursor() {
+		return repositories.FindByCursor(queryBuilder, query.Paginator, repositories.IDKeysetOrder,
+			func(wallet models.Wallet) pagination.Cursor {
+				return pagination.Cursor{ID: wallet.ID}
+			})
+	}
+
 	paginator := repositories.GetPaginator(query.Paginator)
 
 	if err := queryBuilder.Scopes(repositories.Paginate(paginator)).Find(&wallets).Error; err != nil {
@@ -83,4 +129,8 @@ func applyFilters(db *gorm.DB, query models.QueryWallets) {
 	if len(query.Currencies) > 0 {
 		db.Where("currency IN ?", query.Currencies)
 	}
+
+	if len(query.Products) > 0 {
+		db.Where("product IN ?", query.Products)
+	}
 }
@@ -0,0 +1,135 @@
+package wallets_test
+
+import (
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/repotest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newWallet(id, ownerID, currency string) models.Wallet {
+	return models.Wallet{ID: id, OwnerID: ownerID, Currency: currency, Status: "active", Version: 1}
+}
+
+func TestRepository_CreateAndGet(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := wallets.New(db)
+
+		created, err := repo.Create(t.Context(), newWallet("wallet-1", "owner-1", "USD"))
+		require.NoError(t, err)
+
+		found, err := repo.GetByID(t.Context(), "wallet-1")
+		require.NoError(t, err)
+		assert.Equal(t, created.OwnerID, found.OwnerID)
+		assert.Equal(t, 1, found.Version)
+
+		_, err = repo.Create(t.Context(), newWallet("wallet-2", "owner-1", "USD"))
+		require.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+		savings := newWallet("wallet-2", "owner-1", "USD")
+		savings.Product = "savings"
+		_, err = repo.Create(t.Context(), savings)
+		require.NoError(t, err)
+
+		_, err = repo.GetByID(t.Context(), "missing")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestRepository_UpdateChecksVersion(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := wallets.New(db)
+
+		wallet, err := repo.Create(t.Context(), newWallet("wallet-1", "owner-1", "USD"))
+		require.NoError(t, err)
+
+		wallet.Status = "inactive"
+		updated, err := repo.Update(t.Context(), wallet)
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Version)
+
+		_, err = repo.Update(t.Context(), wallet)
+		require.ErrorIs(t, err, repositories.ErrVersionConflict)
+	})
+}
+
+func TestRepository_List(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := wallets.New(db)
+
+		for _, wallet := range []models.Wallet{
+			newWallet("wallet-1", "owner-1", "USD"),
+			newWallet("wallet-2", "owner-1", "EUR"),
+			newWallet("wallet-3", "owner-2", "USD"),
+			{ID: "wallet-4", OwnerID: "owner-2", Currency: "USD", Product: "savings", Status: "active", Version: 1},
+		} {
+			_, err := repo.Create(t.Context(), wallet)
+			require.NoError(t, err)
+		}
+
+		found, page, err := repo.List(t.Context(), models.QueryWallets{
+			OwnerIDs:   []string{"owner-1"},
+			Currencies: []string{"USD", "EUR"},
+		})
+		require.NoError(t, err)
+		assert.Len(t, found, 2)
+		assert.Equal(t, 2, page.Total)
+
+		found, _, err = repo.List(t.Context(), models.QueryWallets{Products: []string{"savings"}})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "wallet-4", found[0].ID)
+
+		limit := 2
+		found, page, err = repo.List(t.Context(), models.QueryWallets{
+			Paginator: pagination.Paginator{Limit: &limit},
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, []string{"wallet-4", "wallet-3"}, []string{found[0].ID, found[1].ID})
+		require.NotNil(t, page.NextCursor)
+		assert.Nil(t, page.PrevCursor)
+
+		found, page, err = repo.List(t.Context(), models.QueryWallets{
+			Paginator: pagination.Paginator{Limit: &limit, After: page.NextCursor},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"wallet-2", "wallet-1"}, []string{found[0].ID, found[1].ID})
+		assert.Nil(t, page.NextCursor)
+		require.NotNil(t, page.PrevCursor)
+
+		found, _, err = repo.List(t.Context(), models.QueryWallets{
+			Paginator: pagination.Paginator{Limit: &limit, Before: page.PrevCursor},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"wallet-4", "wallet-3"}, []string{found[0].ID, found[1].ID})
+	})
+}
+
+func TestRepository_Fence(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, db *gorm.DB) {
+		repo := wallets.New(db)
+
+		_, err := repo.Create(t.Context(), newWallet("wallet-1", "owner-1", "USD"))
+		require.NoError(t, err)
+
+		first, err := repo.AdvanceFence(t.Context(), "wallet-1")
+		require.NoError(t, err)
+
+		second, err := repo.AdvanceFence(t.Context(), "wallet-1")
+		require.NoError(t, err)
+		assert.Greater(t, second, first)
+
+		require.NoError(t, repo.CheckFence(t.Context(), "wallet-1", second))
+		require.ErrorIs(t, repo.CheckFence(t.Context(), "wallet-1", first), repositories.ErrStaleFence)
+
+		_, err = repo.AdvanceFence(t.Context(), "missing")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
@@ -0,0 +1,124 @@
+// Package risk decides, before a transaction is created, whether it goes through, is held for review or
+// is denied. Every rule of an engine is run and the most severe decision wins.
+package risk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+// Input is what a rule knows of the transaction about to be created.
+type Input struct {
+	Wallet  models.Wallet
+	Request models.CreateTransactionRequest
+	Now     time.Time
+}
+
+// Verdict is the decision of a single rule along with why it was taken. The zero Verdict allows.
+type Verdict struct {
+	Decision types.RiskDecision
+	Reason   string
+}
+
+// Allow is the verdict of a rule the transaction does not hit.
+var Allow = Verdict{Decision: types.RiskDecisionAllow}
+
+// Rule is a check run before every transaction is created. Rules run while the wallet is locked, so they
+// should be quick. An error fails the transaction as unavailable rather than letting it through.
+type Rule interface {
+	// Name identifies the rule in the audit trail.
+	Name() string
+	Evaluate(ctx context.Context, in Input) (Verdict, error)
+}
+
+// Hit is the verdict of a rule that did not allow the transaction.
+type Hit struct {
+	Rule string
+	Verdict
+}
+
+// Assessment is the decision on a transaction and the rules that led to it.
+type Assessment struct {
+	Decision types.RiskDecision
+	Hits     []Hit
+}
+
+// Reason lists the rules hit along with their reasons.
+func (a Assessment) Reason() string {
+	reasons := make([]string, 0, len(a.Hits))
+	for _, hit := range a.Hits {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", hit.Rule, hit.Reason))
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// Engine runs a set of rules.
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Assess runs every rule on the transaction. It allows transactions no rule hits.
+func (e *Engine) Assess(ctx context.Context, in Input) (Assessment, error) {
+	assessment := Assessment{Decision: types.RiskDecisionAllow}
+
+	for _, rule := range e.rules {
+		verdict, err := rule.Evaluate(ctx, in)
+		if err != nil {
+			return Assessment{}, fmt.Errorf("risk rule %s: %w", rule.Name(), err)
+		}
+
+		if verdict.Decision == types.RiskDecisionAllow || verdict.Decision == "" {
+			continue
+		}
+
+		if !slices.Contains(types.GetRiskDecisions(), verdict.Decision) {
+			return Assessment{}, fmt.Errorf("risk rule %s: unknown decision %q", rule.Name(), verdict.Decision)
+		}
+
+		assessment.Hits = append(assessment.Hits, Hit{Rule: rule.Name(), Verdict: verdict})
+
+		if severity(verdict.Decision) > severity(assessment.Decision) {
+			assessment.Decision = verdict.Decision
+		}
+	}
+
+	return assessment, nil
+}
+
+func severity(decision types.RiskDecision) int {
+	switch decision {
+	case types.RiskDecisionDeny:
+		return 2
+	case types.RiskDecisionReview:
+		return 1
+	case types.RiskDecisionAllow:
+	}
+
+	return 0
+}
+
+// OwnerScoped reports whether a rule of the engine counts the transactions of every wallet of the owner,
+// whose verdict only holds while the owner's transactions are created one at a time.
+func (e *Engine) OwnerScoped() bool {
+	return slices.ContainsFunc(e.rules, func(rule Rule) bool {
+		velocity, ok := rule.(Velocity)
+
+		return ok && velocity.Limit > 0
+	})
+}
+
+// Len returns the number of rules of the engine.
+func (e *Engine) Len() int {
+	return len(e.rules)
+}
@@ -0,0 +1,179 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type counter struct {
+	count int
+	err   error
+	since time.Time
+}
+
+func (c *counter) CountByOwnerSince(_ context.Context, _ string, since time.Time) (int, error) {
+	c.since = since
+
+	return c.count, c.err
+}
+
+type custom struct {
+	verdict Verdict
+}
+
+func (r custom) Name() string {
+	return "custom"
+}
+
+func (r custom) Evaluate(context.Context, Input) (Verdict, error) {
+	return r.verdict, nil
+}
+
+func input(amount int, walletAge time.Duration) Input {
+	return Input{
+		Wallet:  models.Wallet{ID: "wallet-1", OwnerID: "owner-1", CreatedAt: now.Add(-walletAge)},
+		Request: models.CreateTransactionRequest{WalletID: "wallet-1", Amount: amount},
+		Now:     now,
+	}
+}
+
+func TestRules(t *testing.T) {
+	review, deny := types.RiskDecisionReview, types.RiskDecisionDeny
+
+	tests := []struct {
+		name     string
+		rule     Rule
+		in       Input
+		expected types.RiskDecision
+	}{
+		{name: "at the threshold", rule: AmountThreshold{Amount: 500, Decision: review}, in: input(500, 0)},
+		{
+			name: "above the threshold", rule: AmountThreshold{Amount: 500, Decision: review}, in: input(501, 0),
+			expected: review,
+		},
+		{name: "threshold off", rule: AmountThreshold{Decision: review}, in: input(1000, 0)},
+		{
+			name: "below the velocity limit", rule: Velocity{Limit: 3, Window: time.Minute, Decision: deny,
+				Counter: &counter{count: 2}}, in: input(10, 0),
+		},
+		{
+			name: "at the velocity limit", rule: Velocity{Limit: 3, Window: time.Minute, Decision: deny,
+				Counter: &counter{count: 3}}, in: input(10, 0), expected: deny,
+		},
+		{
+			name: "new wallet above the amount", rule: NewWallet{CoolingPeriod: 24 * time.Hour, Amount: 100,
+				Decision: review}, in: input(101, time.Hour), expected: review,
+		},
+		{
+			name: "new wallet within the amount", rule: NewWallet{CoolingPeriod: 24 * time.Hour, Amount: 100,
+				Decision: review}, in: input(100, time.Hour),
+		},
+		{
+			name: "wallet past the cooling period", rule: NewWallet{CoolingPeriod: 24 * time.Hour, Decision: review},
+			in: input(1000, 24*time.Hour),
+		},
+		{
+			name: "blocklisted owner", rule: Blocklist{Decision: deny, IDs: map[string]bool{"owner-1": true}},
+			in: input(10, 0), expected: deny,
+		},
+		{name: "not blocklisted", rule: Blocklist{Decision: deny, IDs: map[string]bool{"owner-2": true}}, in: input(10, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := tt.rule.Evaluate(context.Background(), tt.in)
+			require.NoError(t, err)
+
+			if tt.expected == "" {
+				assert.Equal(t, Allow, verdict)
+
+				return
+			}
+
+			assert.Equal(t, tt.expected, verdict.Decision)
+			assert.NotEmpty(t, verdict.Reason)
+		})
+	}
+}
+
+func TestVelocity_CountsWithinWindow(t *testing.T) {
+	transactions := &counter{count: 1}
+
+	_, err := Velocity{Limit: 3, Window: time.Minute, Counter: transactions}.Evaluate(context.Background(),
+		input(10, 0))
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Minute), transactions.since)
+}
+
+func TestEngine_OwnerScoped(t *testing.T) {
+	assert.False(t, NewEngine(AmountThreshold{Amount: 100}).OwnerScoped())
+	assert.False(t, NewEngine(Velocity{}).OwnerScoped(), "a velocity rule without a limit is off")
+	assert.True(t, NewEngine(AmountThreshold{Amount: 100}, Velocity{Limit: 3, Window: time.Minute}).OwnerScoped())
+}
+
+func TestEngine_Assess(t *testing.T) {
+	ctx := context.Background()
+
+	assessment, err := NewEngine().Assess(ctx, input(10, 0))
+	require.NoError(t, err)
+	assert.Equal(t, types.RiskDecisionAllow, assessment.Decision, "no rules allow everything")
+
+	engine := NewEngine(
+		AmountThreshold{Amount: 100, Decision: types.RiskDecisionReview},
+		custom{verdict: Verdict{Decision: types.RiskDecisionDeny, Reason: "suspicious"}},
+		custom{verdict: Verdict{}},
+	)
+
+	assessment, err = engine.Assess(ctx, input(500, 0))
+	require.NoError(t, err)
+	assert.Equal(t, types.RiskDecisionDeny, assessment.Decision, "the most severe decision wins")
+	require.Len(t, assessment.Hits, 2)
+	assert.Equal(t, "amount_threshold: amount above 100; custom: suspicious", assessment.Reason())
+
+	_, err = NewEngine(custom{verdict: Verdict{Decision: "maybe"}}).Assess(ctx, input(10, 0))
+	require.ErrorContains(t, err, `unknown decision "maybe"`)
+
+	failure := errors.New("database down")
+	_, err = NewEngine(Velocity{Limit: 1, Window: time.Minute, Counter: &counter{err: failure}}).Assess(ctx,
+		input(10, 0))
+	require.ErrorIs(t, err, failure)
+}
+
+func TestPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# fraud ring\nowner-1\n\n  wallet-9  \n"), 0o600))
+
+	policy := Policy{
+		AmountThreshold: AmountThreshold{Decision: types.RiskDecisionReview},
+		Velocity:        Velocity{Limit: 5, Window: time.Minute, Decision: types.RiskDecisionDeny},
+		NewWallet:       NewWallet{Decision: types.RiskDecisionReview},
+		Blocklist:       Blocklist{File: path, Decision: types.RiskDecisionDeny},
+	}
+	require.NoError(t, policy.Validate())
+
+	rules, err := policy.Rules(&counter{})
+	require.NoError(t, err)
+	require.Len(t, rules, 2, "only the rules turned on")
+	assert.Equal(t, "velocity", rules[0].Name())
+
+	blocklist, ok := rules[1].(Blocklist)
+	require.True(t, ok)
+	assert.Equal(t, map[string]bool{"owner-1": true, "wallet-9": true}, blocklist.IDs)
+
+	policy.Blocklist.File = filepath.Join(t.TempDir(), "missing.txt")
+	policy.Velocity.Decision = types.RiskDecisionAllow
+	err = policy.Validate()
+	require.ErrorContains(t, err, `velocity: decision must be review or deny, got "allow"`)
+	require.ErrorContains(t, err, "blocklist: failed to open blocklist")
+}
@@ -0,0 +1,229 @@
+package risk
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+// AmountThreshold hits transactions above Amount.
+type AmountThreshold struct {
+	// Amount is the largest amount let through. Zero turns the rule off.
+	Amount   int                `mapstructure:"amount"`
+	Decision types.RiskDecision `mapstructure:"decision"`
+}
+
+func (r AmountThreshold) Name() string {
+	return "amount_threshold"
+}
+
+func (r AmountThreshold) Evaluate(_ context.Context, in Input) (Verdict, error) {
+	if r.Amount == 0 || in.Request.Amount <= r.Amount {
+		return Allow, nil
+	}
+
+	return Verdict{Decision: r.Decision, Reason: fmt.Sprintf("amount above %d", r.Amount)}, nil
+}
+
+// TransactionCounter counts the transactions of the wallets of an owner created since a time. The
+// transactions linked to another, such as fees, and the system postings of the service are not counted.
+type TransactionCounter interface {
+	CountByOwnerSince(ctx context.Context, ownerID string, since time.Time) (int, error)
+}
+
+// Velocity hits bursts of transactions from the wallets of one owner. Its count only holds if the
+// transactions of an owner are created one at a time, which the transaction service ensures while it is on.
+type Velocity struct {
+	// Limit is the number of transactions an owner may make within Window. Zero turns the rule off.
+	Limit    int                `mapstructure:"limit"`
+	Window   time.Duration      `mapstructure:"window"`
+	Decision types.RiskDecision `mapstructure:"decision"`
+
+	Counter TransactionCounter `mapstructure:"-"`
+}
+
+func (r Velocity) Name() string {
+	return "velocity"
+}
+
+func (r Velocity) Evaluate(ctx context.Context, in Input) (Verdict, error) {
+	if r.Limit == 0 {
+		return Allow, nil
+	}
+
+	count, err := r.Counter.CountByOwnerSince(ctx, in.Wallet.OwnerID, in.Now.Add(-r.Window))
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	if count < r.Limit {
+		return Allow, nil
+	}
+
+	return Verdict{
+		Decision: r.Decision,
+		Reason:   fmt.Sprintf("owner made %d transactions in the last %s", count, r.Window),
+	}, nil
+}
+
+// NewWallet hits transactions above Amount on wallets younger than CoolingPeriod.
+type NewWallet struct {
+	// CoolingPeriod is how long wallets count as new. Zero turns the rule off.
+	CoolingPeriod time.Duration `mapstructure:"cooling_period"`
+	// Amount is the largest amount new wallets may move. Zero hits every transaction of new wallets.
+	Amount   int                `mapstructure:"amount"`
+	Decision types.RiskDecision `mapstructure:"decision"`
+}
+
+func (r NewWallet) Name() string {
+	return "new_wallet"
+}
+
+func (r NewWallet) Evaluate(_ context.Context, in Input) (Verdict, error) {
+	isNew := in.Now.Before(in.Wallet.CreatedAt.Add(r.CoolingPeriod))
+	if r.CoolingPeriod == 0 || !isNew || in.Request.Amount <= r.Amount {
+		return Allow, nil
+	}
+
+	return Verdict{
+		Decision: r.Decision,
+		Reason:   fmt.Sprintf("amount above %d on a wallet opened less than %s ago", r.Amount, r.CoolingPeriod),
+	}, nil
+}
+
+// Blocklist hits the transactions of listed wallets and owners.
+type Blocklist struct {
+	// File lists a wallet or owner ID per line. Blank lines and lines starting with # are skipped. Empty
+	// turns the rule off.
+	File     string             `mapstructure:"file"`
+	Decision types.RiskDecision `mapstructure:"decision"`
+
+	IDs map[string]bool `mapstructure:"-"`
+}
+
+func (r Blocklist) Name() string {
+	return "blocklist"
+}
+
+func (r Blocklist) Evaluate(_ context.Context, in Input) (Verdict, error) {
+	switch {
+	case r.IDs[in.Wallet.ID]:
+		return Verdict{Decision: r.Decision, Reason: "wallet is blocklisted"}, nil
+	case r.IDs[in.Wallet.OwnerID]:
+		return Verdict{Decision: r.Decision, Reason: "owner is blocklisted"}, nil
+	default:
+		return Allow, nil
+	}
+}
+
+// Load reads the IDs listed in File.
+func (r Blocklist) Load() (Blocklist, error) {
+	if r.File == "" {
+		return r, nil
+	}
+
+	file, err := os.Open(r.File)
+	if err != nil {
+		return Blocklist{}, fmt.Errorf("failed to open blocklist: %w", err)
+	}
+	defer file.Close()
+
+	r.IDs = map[string]bool{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			r.IDs[line] = true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Blocklist{}, fmt.Errorf("failed to read blocklist %s: %w", r.File, err)
+	}
+
+	return r, nil
+}
+
+// Policy configures the built-in rules. Rules are off unless their threshold, limit, period or file is set.
+type Policy struct {
+	AmountThreshold AmountThreshold `mapstructure:"amount_threshold"`
+	Velocity        Velocity        `mapstructure:"velocity"`
+	NewWallet       NewWallet       `mapstructure:"new_wallet"`
+	Blocklist       Blocklist       `mapstructure:"blocklist"`
+}
+
+// Rules returns the built-in rules turned on, with the blocklist read from its file. counter counts the
+// transactions of owners for the velocity rule.
+func (p Policy) Rules(counter TransactionCounter) ([]Rule, error) {
+	var rules []Rule
+
+	if p.AmountThreshold.Amount > 0 {
+		rules = append(rules, p.AmountThreshold)
+	}
+
+	if p.Velocity.Limit > 0 {
+		velocity := p.Velocity
+		velocity.Counter = counter
+		rules = append(rules, velocity)
+	}
+
+	if p.NewWallet.CoolingPeriod > 0 {
+		rules = append(rules, p.NewWallet)
+	}
+
+	if p.Blocklist.File != "" {
+		blocklist, err := p.Blocklist.Load()
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, blocklist)
+	}
+
+	return rules, nil
+}
+
+// Validate reports every invalid setting at once.
+func (p Policy) Validate() error {
+	var problems []string
+
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+	decision := func(rule string, decision types.RiskDecision) {
+		check(decision == types.RiskDecisionReview || decision == types.RiskDecisionDeny,
+			"%s: decision must be review or deny, got %q", rule, decision)
+	}
+
+	check(p.AmountThreshold.Amount >= 0, "amount_threshold: amount must not be negative")
+	decision("amount_threshold", p.AmountThreshold.Decision)
+
+	check(p.Velocity.Limit >= 0, "velocity: limit must not be negative")
+	check(p.Velocity.Limit == 0 || p.Velocity.Window > 0, "velocity: window must be a positive duration")
+	decision("velocity", p.Velocity.Decision)
+
+	check(p.NewWallet.CoolingPeriod >= 0, "new_wallet: cooling_period must not be negative")
+	check(p.NewWallet.Amount >= 0, "new_wallet: amount must not be negative")
+	decision("new_wallet", p.NewWallet.Decision)
+
+	decision("blocklist", p.Blocklist.Decision)
+
+	if _, err := p.Blocklist.Load(); err != nil {
+		check(false, "blocklist: %v", err)
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	disputeCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/disputes"
+	interestCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/interest"
+	riskCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/risk"
+	streamCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/streams"
+	transactionCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/transactions"
+	walletCtrl "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/controller/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/interest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/locks"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/risk"
+	disputeSvc "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/disputes"
+	transactionSvc "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	walletSvc "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/wallets"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type WalletRepository interface {
+	Create(ctx context.Context, wallet models.Wallet) (models.Wallet, error)
+	GetByID(ctx context.Context, id string) (models.Wallet, error)
+	Update(ctx context.Context, wallet models.Wallet) (models.Wallet, error)
+	List(ctx context.Context, query models.QueryWallets) ([]models.Wallet, *pagination.Pagination, error)
+	AdvanceFence(ctx context.Context, walletID string) (int64, error)
+	CheckFence(ctx context.Context, walletID string, token int64) error
+}
+
+type TransactionRepository interface {
+	Tx(ctx context.Context, do func(ctx context.Context) error) error
+	Create(ctx context.Context, transaction models.Transaction) (models.Transaction, error)
+	GetByID(ctx context.Context, id string) (models.Transaction, error)
+	Update(ctx context.Context, transaction models.Transaction) (models.Transaction, error)
+	List(ctx context.Context, query models.QueryTransactions) ([]models.Transaction, *pagination.Pagination, error)
+	ListAllTransactions(ctx context.Context, walletID string) (models.Transactions, error)
+	ListLinked(ctx context.Context, parentID string) (models.Transactions, error)
+	CreateAllocation(ctx context.Context, allocation models.TransactionAllocation) (
+		models.TransactionAllocation, error)
+	ListAllocations(ctx context.Context, walletID string) (models.TransactionAllocations, error)
+	ListExpiredCredits(ctx context.Context, at time.Time, limit int) (models.Transactions, error)
+	CountByOwnerSince(ctx context.Context, ownerID string, since time.Time) (int, error)
+}
+
+type ApprovalRepository interface {
+	Create(ctx context.Context, approval models.TransactionApproval) (models.TransactionApproval, error)
+	ListByTransaction(ctx context.Context, transactionID string) (models.TransactionApprovals, error)
+}
+
+type InterestRepository interface {
+	CreateAccrual(ctx context.Context, accrual models.InterestAccrual) (models.InterestAccrual, error)
+	LastAccrual(ctx context.Context, walletID, before string) (models.InterestAccrual, error)
+	ListUnpostedWallets(ctx context.Context, from, to string) ([]string, error)
+	ListUnpostedAccruals(ctx context.Context, walletID, from, to string) (models.InterestAccruals, error)
+	MarkAccrualsPosted(ctx context.Context, walletID string, days []string, transactionID string) error
+}
+
+type DisputeRepository interface {
+	Create(ctx context.Context, dispute models.Dispute) (models.Dispute, error)
+	GetByID(ctx context.Context, id string) (models.Dispute, error)
+	GetByTransactionID(ctx context.Context, transactionID string) (models.Dispute, error)
+	Update(ctx context.Context, dispute models.Dispute) (models.Dispute, error)
+	List(ctx context.Context, query models.QueryDisputes) ([]models.Dispute, *pagination.Pagination, error)
+	CreateNote(ctx context.Context, note models.DisputeNote) (models.DisputeNote, error)
+	ListNotes(ctx context.Context, disputeID string) (models.DisputeNotes, error)
+}
+
+type RiskRepository interface {
+	Create(ctx context.Context, decision models.RiskDecision) (models.RiskDecision, error)
+	List(ctx context.Context, query models.QueryRiskDecisions) (models.RiskDecisions, *pagination.Pagination, error)
+}
+
+type Cache interface {
+	GetBalance(ctx context.Context, walletID string) (*int, error)
+	SetBalance(ctx context.Context, walletID string, balance int) error
+	GetIdempotentTransaction(ctx context.Context, idempotencyKey string) (*models.Transaction, error)
+	SetIdempotentTransaction(ctx context.Context, idempotencyKey string, transaction models.Transaction) error
+}
+
+// Dependencies are the storage backends the API is served from. Postgres and Redis back it in
+// production, the in-memory store in tests and local development.
+type Dependencies struct {
+	Wallets      WalletRepository
+	Transactions TransactionRepository
+	// Approvals keeps the approval trail of transactions. Without it no transaction awaits approval.
+	Approvals ApprovalRepository
+	// Interest keeps the interest wallets accrue. Without it no interest accrues.
+	Interest InterestRepository
+	Disputes DisputeRepository
+	// Risk keeps the decisions of the risk rules. Without it no risk rules run.
+	Risk  RiskRepository
+	Cache Cache
+	// Locker serializes the changes of each wallet. Defaults to an in-process locker, which only
+	// serializes the requests of the same instance.
+	Locker locks.Locker
+	// Events carries transaction events to streaming clients. Defaults to an in-process broker, which
+	// only serves clients of the same instance.
+	Events events.Bus
+	// Logger receives the service logs. Defaults to discarding them.
+	Logger *zap.Logger
+	// ApprovalPolicy decides which transactions await approval and who may approve them.
+	ApprovalPolicy transactionSvc.ApprovalPolicy
+	// FeeSchedule sets the fees charged on transactions. No fees are charged by default.
+	FeeSchedule fees.Schedule
+	// BucketPolicy sets the order debits spend the balance buckets in and how long promotional credit
+	// lasts. By default debits spend promotional credit before cash, as transactionSvc.DefaultBucketPriority
+	// says, and promotional credit lasts until its expiry, if any.
+	BucketPolicy transactionSvc.BucketPolicy
+	// InterestSchedule sets the annual interest rates wallets earn. No wallet earns interest by default.
+	InterestSchedule interest.Schedule
+	// DisputeResponseWindow is how long disputes opened without a deadline stay due. Defaults to
+	// disputes.DefaultResponseWindow.
+	DisputeResponseWindow time.Duration
+	// RiskRules run before every transaction is created. None run by default.
+	RiskRules []risk.Rule
+	Now       func() time.Time
+}
+
+// Services are the application services shared by the REST and gRPC APIs.
+type Services struct {
+	Wallets      *walletSvc.Service
+	Transactions *transactionSvc.Service
+	Disputes     *disputeSvc.Service
+	Events       events.Bus
+}
+
+func NewServices(deps Dependencies) Services {
+	now := deps.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	bus := deps.Events
+	if bus == nil {
+		bus = events.NewBroker()
+	}
+
+	locker := deps.Locker
+	if locker == nil {
+		locker = locks.NewInProcess()
+	}
+
+	logger := deps.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	transactionOpts := []transactionSvc.Option{
+		transactionSvc.WithPublisher(bus), transactionSvc.WithLogger(logger), transactionSvc.WithFencing(deps.Wallets),
+		transactionSvc.WithFees(deps.FeeSchedule, deps.Transactions),
+		transactionSvc.WithBuckets(deps.BucketPolicy, deps.Transactions),
+	}
+
+	if deps.Approvals != nil {
+		transactionOpts = append(transactionOpts, transactionSvc.WithApprovals(deps.ApprovalPolicy, deps.Approvals))
+	}
+
+	if deps.Interest != nil {
+		transactionOpts = append(transactionOpts,
+			transactionSvc.WithInterest(deps.InterestSchedule, deps.Wallets, deps.Interest))
+	}
+
+	if deps.Risk != nil {
+		transactionOpts = append(transactionOpts,
+			transactionSvc.WithRisk(risk.NewEngine(deps.RiskRules...), deps.Risk))
+	}
+
+	transactionService := transactionSvc.NewService(deps.Wallets, deps.Transactions, deps.Cache, locker, now,
+		transactionOpts...)
+	walletService := walletSvc.NewService(transactionService, deps.Wallets, deps.Cache, now)
+	disputeService := disputeSvc.NewService(transactionService, deps.Disputes, locker, now,
+		deps.DisputeResponseWindow)
+
+	return Services{
+		Wallets:      walletService,
+		Transactions: transactionService,
+		Disputes:     disputeService,
+		Events:       bus,
+	}
+}
+
+type options struct {
+	streams streamCtrl.Config
+}
+
+// Option configures the routes.
+type Option func(*options)
+
+// WithStreamConfig sets the heartbeat and connection limits of event streams.
+func WithStreamConfig(cfg streamCtrl.Config) Option {
+	return func(o *options) {
+		o.streams = cfg
+	}
+}
+
+// Register mounts the wallet, transaction, interest, dispute and risk routes on the group.
+func Register(routerGroup *gin.RouterGroup, services Services, opts ...Option) {
+	o := options{streams: streamCtrl.DefaultConfig()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	addWalletRoutes(routerGroup, walletCtrl.New(services.Wallets),
+		streamCtrl.New(services.Wallets, services.Events, o.streams))
+	addTransactionRoutes(routerGroup, transactionCtrl.New(services.Transactions))
+	addInterestRoutes(routerGroup, interestCtrl.New(services.Transactions))
+	addDisputeRoutes(routerGroup, disputeCtrl.New(services.Disputes))
+	addRiskRoutes(routerGroup, riskCtrl.New(services.Transactions))
+}
+
+func addWalletRoutes(
+	routerGroup *gin.RouterGroup,
+	walletController *walletCtrl.Controller,
+	streamController *streamCtrl.Controller,
+) {
+	routerGroup.GET("/wallets", walletController.ListWallets)
+	routerGroup.POST("/wallets", walletController.CreateWallet)
+	routerGroup.GET("/wallets/:id", walletController.GetWalletByID)
+	routerGroup.PATCH("/wallets/:id/status", walletController.UpdateWalletStatus)
+	routerGroup.GET("/wallets/:id/balance", walletController.GetWalletWithBalance)
+	routerGroup.GET("/wallets/:id/events", streamController.StreamWalletEvents)
+}
+
+func addTransactionRoutes(routerGroup *gin.RouterGroup, transactionController *transactionCtrl.Controller) {
+	routerGroup.GET("/transactions", transactionController.ListTransactions)
+	routerGroup.POST("/transactions", transactionController.CreateTransaction)
+	routerGroup.POST("/transactions/quote", transactionController.QuoteTransaction)
+	routerGroup.GET("/transactions/:id", transactionController.GetTransactionByID)
+	routerGroup.PATCH("/transactions/:id/status", transactionController.UpdateTransactionStatus)
+	routerGroup.POST("/transactions/:id/approve", transactionController.ApproveTransaction)
+	routerGroup.POST("/transactions/:id/reject", transactionController.RejectTransaction)
+	routerGroup.GET("/transactions/:id/approvals", transactionController.ListTransactionApprovals)
+}
+
+func addInterestRoutes(routerGroup *gin.RouterGroup, interestController *interestCtrl.Controller) {
+	routerGroup.POST("/interest/accruals", interestController.AccrueInterest)
+	routerGroup.POST("/interest/payouts", interestController.PostInterest)
+}
+
+func addDisputeRoutes(routerGroup *gin.RouterGroup, disputeController *disputeCtrl.Controller) {
+	routerGroup.GET("/disputes", disputeController.ListDisputes)
+	routerGroup.POST("/disputes", disputeController.OpenDispute)
+	routerGroup.GET("/disputes/:id", disputeController.GetDispute)
+	routerGroup.PATCH("/disputes/:id/status", disputeController.UpdateDisputeStatus)
+	routerGroup.POST("/disputes/:id/notes", disputeController.AddDisputeNote)
+}
+
+func addRiskRoutes(routerGroup *gin.RouterGroup, riskController *riskCtrl.Controller) {
+	routerGroup.GET("/risk/decisions", riskController.ListRiskDecisions)
+}
@@ -0,0 +1,412 @@
+package disputes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/looplab/fsm"
+	"gorm.io/gorm"
+)
+
+// DefaultResponseWindow is how long a dispute stays due when it is opened without a deadline.
+const DefaultResponseWindow = 30 * 24 * time.Hour
+
+type disputeRepo interface {
+	Create(ctx context.Context, dispute models.Dispute) (models.Dispute, error)
+	GetByID(ctx context.Context, id string) (models.Dispute, error)
+	GetByTransactionID(ctx context.Context, transactionID string) (models.Dispute, error)
+	Update(ctx context.Context, dispute models.Dispute) (models.Dispute, error)
+	List(ctx context.Context, query models.QueryDisputes) ([]models.Dispute, *pagination.Pagination, error)
+	CreateNote(ctx context.Context, note models.DisputeNote) (models.DisputeNote, error)
+	ListNotes(ctx context.Context, disputeID string) (models.DisputeNotes, error)
+}
+
+// transactionService moves the funds of disputes, so they are checked, held for approval, fenced and
+// published like any other transaction.
+type transactionService interface {
+	GetTransactionByID(ctx context.Context, id string) (models.Transaction, error)
+	CreateTransaction(ctx context.Context, req models.CreateTransactionRequest) (models.Transaction, error)
+	UpdateTransactionStatus(ctx context.Context, id string, status string, expectedVersion *int) (
+		models.Transaction, error)
+}
+
+type locker interface {
+	Lock(ctx context.Context, key string) (func(context.Context) error, error)
+}
+
+type Service struct {
+	transactionService transactionService
+	db                 disputeRepo
+	locker             locker
+	now                func() time.Time
+	responseWindow     time.Duration
+}
+
+// NewService returns a dispute service. Disputes opened without a deadline are due responseWindow after
+// opening, or DefaultResponseWindow when it is zero.
+func NewService(transactionService transactionService, db disputeRepo, locker locker, now func() time.Time,
+	responseWindow time.Duration) *Service {
+	if responseWindow <= 0 {
+		responseWindow = DefaultResponseWindow
+	}
+
+	return &Service{
+		transactionService: transactionService,
+		db:                 db,
+		locker:             locker,
+		now:                now,
+		responseWindow:     responseWindow,
+	}
+}
+
+// OpenDispute opens a dispute of a completed debit. With a provisional credit the disputed amount is
+// given back to the wallet right away, unless the credit awaits approval.
+func (s *Service) OpenDispute(ctx context.Context, req models.OpenDisputeRequest) (_ models.Dispute, err error) {
+	ctx, span := tracing.Start(ctx, "disputes.OpenDispute", tracing.TransactionID(req.TransactionID))
+	defer func() { tracing.End(span, err) }()
+
+	transaction, err := s.transactionService.GetTransactionByID(ctx, req.TransactionID)
+	if err != nil {
+		return models.Dispute{}, err
+	}
+
+	span.SetAttributes(tracing.WalletID(transaction.WalletID))
+
+	if transaction.Type != string(types.TransactionTypeDebit) ||
+		transaction.Status != string(types.TransactionStatusCompleted) || transaction.ParentID != nil {
+		return models.Dispute{}, services.ErrNotDisputable
+	}
+
+	unlock, err := s.lock(ctx, transaction.ID)
+	if err != nil {
+		return models.Dispute{}, err
+	}
+	defer unlock(ctx)
+
+	_, err = s.db.GetByTransactionID(ctx, transaction.ID)
+	if err == nil {
+		return models.Dispute{}, services.ErrDuplicateDispute
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Dispute{}, services.FromRepository(err, nil)
+	}
+
+	dispute := models.Dispute{
+		ID:            ulid.GenerateID(s.now()),
+		TransactionID: transaction.ID,
+		WalletID:      transaction.WalletID,
+		Amount:        transaction.Amount,
+		Reason:        req.Reason,
+		Status:        string(types.DisputeStatusOpen),
+		DueAt:         s.now().Add(s.responseWindow).UTC(),
+		Version:       1,
+	}
+
+	if req.DueAt != nil {
+		dispute.DueAt = req.DueAt.UTC()
+	}
+
+	// The credit is keyed on the disputed transaction, so opening again after a failure reuses it.
+	if req.ProvisionalCredit {
+		credit, err := s.move(ctx, transaction.WalletID, types.TransactionTypeCredit, transaction.Amount,
+			"dispute:"+transaction.ID+":provisional-credit",
+			"provisional credit for dispute of transaction "+transaction.ID, nil)
+		if err != nil {
+			return models.Dispute{}, err
+		}
+
+		dispute.ProvisionalCreditID = &credit.ID
+	}
+
+	dispute, err = s.db.Create(ctx, dispute)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return models.Dispute{}, services.ErrDuplicateDispute
+	}
+
+	if err != nil {
+		return models.Dispute{}, services.FromRepository(err, nil)
+	}
+
+	return dispute, nil
+}
+
+// GetDispute returns a dispute along with its notes.
+func (s *Service) GetDispute(ctx context.Context, id string) (models.Dispute, error) {
+	dispute, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return models.Dispute{}, services.FromRepository(err, services.ErrDisputeNotFound)
+	}
+
+	dispute.Notes, err = s.db.ListNotes(ctx, id)
+	if err != nil {
+		return models.Dispute{}, services.FromRepository(err, nil)
+	}
+
+	return dispute, nil
+}
+
+func (s *Service) ListDisputes(ctx context.Context, query models.QueryDisputes) (
+	models.Disputes, *pagination.Pagination, error) {
+	disputes, pagination, err := s.db.List(ctx, query)
+	if err != nil {
+		return nil, nil, services.FromRepository(err, nil)
+	}
+
+	return disputes, pagination, nil
+}
+
+// UpdateDisputeStatus moves the dispute to status. A won dispute without provisional credit refunds the
+// disputed amount, and a lost one takes back the provisional credit, if any, even if that takes the balance
+// below zero. The note explains the resolution, or is added to the evidence when the dispute goes under
+// review. When expectedVersion is set the update is rejected with repositories.ErrVersionConflict unless it
+// matches the current version.
+func (s *Service) UpdateDisputeStatus(ctx context.Context, id, status string, note *string,
+	expectedVersion *int) (_ models.Dispute, err error) {
+	ctx, span := tracing.Start(ctx, "disputes.UpdateDisputeStatus")
+	defer func() { tracing.End(span, err) }()
+
+	dispute, unlock, err := s.getLocked(ctx, id)
+	if err != nil {
+		return models.Dispute{}, err
+	}
+	defer unlock(ctx)
+
+	span.SetAttributes(tracing.TransactionID(dispute.TransactionID), tracing.WalletID(dispute.WalletID))
+
+	if expectedVersion != nil && *expectedVersion != dispute.Version {
+		return models.Dispute{}, repositories.ErrVersionConflict
+	}
+
+	if dispute.Status == status {
+		return dispute, nil
+	}
+
+	if fsm.NewFSM(dispute.Status, models.DisputeStates, nil).Cannot(status) {
+		return models.Dispute{}, fmt.Errorf("%w from %s to %s", services.ErrInvalidTransition, dispute.Status, status)
+	}
+
+	dispute, err = s.settle(ctx, dispute, status)
+	if err != nil {
+		return models.Dispute{}, err
+	}
+
+	dispute.Status = status
+
+	if dispute.Resolved() {
+		dispute.ResolutionNote = note
+	}
+
+	dispute, err = s.db.Update(ctx, dispute)
+	if err != nil {
+		return models.Dispute{}, services.FromRepository(err, services.ErrDisputeNotFound)
+	}
+
+	if !dispute.Resolved() && note != nil {
+		if _, err := s.addNote(ctx, dispute.ID, *note); err != nil {
+			return models.Dispute{}, err
+		}
+	}
+
+	return dispute, nil
+}
+
+// settle moves the funds a dispute resolved with status calls for, and returns the dispute with the ID of
+// the transaction that moved them, if any had to. That ID is stored along with the transaction, so when an
+// earlier attempt failed after creating it, the same transaction is completed instead of moving the funds
+// again.
+func (s *Service) settle(ctx context.Context, dispute models.Dispute, status string) (models.Dispute, error) {
+	if dispute.SettlementID != nil {
+		return dispute, s.resume(ctx, dispute, status)
+	}
+
+	switch {
+	case status == string(types.DisputeStatusWon) && dispute.ProvisionalCreditID == nil:
+		return s.pay(ctx, dispute, types.TransactionTypeCredit, dispute.Amount,
+			"dispute:"+dispute.ID+":refund", "refund for dispute "+dispute.ID)
+	case status == string(types.DisputeStatusLost) && dispute.ProvisionalCreditID != nil:
+		return s.reverse(ctx, dispute)
+	default:
+		return dispute, nil
+	}
+}
+
+// resume completes the settlement an earlier attempt to resolve the dispute with status left behind. It
+// refuses to resolve the dispute otherwise, as the funds were already moved for the other outcome.
+func (s *Service) resume(ctx context.Context, dispute models.Dispute, status string) error {
+	settlement, err := s.transactionService.GetTransactionByID(ctx, *dispute.SettlementID)
+	if err != nil {
+		return err
+	}
+
+	settles := string(types.DisputeStatusWon)
+	if settlement.Type == string(types.TransactionTypeDebit) {
+		settles = string(types.DisputeStatusLost)
+	}
+
+	if settles != status {
+		return fmt.Errorf("%w from %s to %s: settlement %s is for a %s dispute", services.ErrInvalidTransition,
+			dispute.Status, status, settlement.ID, settles)
+	}
+
+	return s.complete(ctx, settlement)
+}
+
+// reverse takes back the provisional credit of a lost dispute. A credit still pending is failed instead,
+// as it never reached the balance.
+func (s *Service) reverse(ctx context.Context, dispute models.Dispute) (models.Dispute, error) {
+	credit, err := s.transactionService.GetTransactionByID(ctx, *dispute.ProvisionalCreditID)
+	if err != nil {
+		return models.Dispute{}, err
+	}
+
+	switch credit.Status {
+	case string(types.TransactionStatusCompleted):
+		return s.pay(ctx, dispute, types.TransactionTypeDebit, credit.Amount,
+			"dispute:"+dispute.ID+":reversal", "reversal of provisional credit for dispute "+dispute.ID)
+	case string(types.TransactionStatusFailed), string(types.TransactionStatusRejected):
+		return dispute, nil
+	default:
+		// Credits awaiting approval are refused here, as only approvers may reject them.
+		if _, err := s.transactionService.UpdateTransactionStatus(ctx, credit.ID,
+			string(types.TransactionStatusFailed), nil); err != nil {
+			return models.Dispute{}, err
+		}
+
+		return dispute, nil
+	}
+}
+
+// pay settles the dispute with a transaction on its wallet, which is stored as the settlement of the
+// dispute in the same database transaction.
+func (s *Service) pay(ctx context.Context, dispute models.Dispute, transactionType types.TransactionType,
+	amount int, idempotencyKey, note string) (models.Dispute, error) {
+	settled := dispute
+
+	_, err := s.move(ctx, dispute.WalletID, transactionType, amount, idempotencyKey, note,
+		func(ctx context.Context, transaction models.Transaction) error {
+			dispute.SettlementID = &transaction.ID
+
+			updated, err := s.db.Update(ctx, dispute)
+			if err != nil {
+				return services.FromRepository(err, services.ErrDisputeNotFound)
+			}
+
+			settled = updated
+
+			return nil
+		})
+	if err != nil {
+		return models.Dispute{}, err
+	}
+
+	return settled, nil
+}
+
+// move creates a transaction for a dispute and completes it, unless it awaits approval. It is a system
+// posting, which is charged no fees and may take the balance below zero. record, if set, is called with the
+// transaction in the database transaction that stores it.
+func (s *Service) move(ctx context.Context, walletID string, transactionType types.TransactionType, amount int,
+	idempotencyKey, note string, record func(context.Context, models.Transaction) error) (
+	models.Transaction, error) {
+	transaction, err := s.transactionService.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID:       walletID,
+		Amount:         amount,
+		Note:           &note,
+		Type:           string(transactionType),
+		IdempotencyKey: idempotencyKey,
+		System:         true,
+		Record:         record,
+	})
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	return transaction, s.complete(ctx, transaction)
+}
+
+// complete completes a transaction moving the funds of a dispute if it is still pending. Transactions
+// awaiting approval are left to the approvers.
+func (s *Service) complete(ctx context.Context, transaction models.Transaction) error {
+	if transaction.Status != string(types.TransactionStatusPending) {
+		return nil
+	}
+
+	_, err := s.transactionService.UpdateTransactionStatus(ctx, transaction.ID,
+		string(types.TransactionStatusCompleted), nil)
+
+	return err
+}
+
+// AddDisputeNote attaches evidence to a dispute that is not resolved yet.
+func (s *Service) AddDisputeNote(ctx context.Context, id, note string) (models.DisputeNote, error) {
+	dispute, unlock, err := s.getLocked(ctx, id)
+	if err != nil {
+		return models.DisputeNote{}, err
+	}
+	defer unlock(ctx)
+
+	if dispute.Resolved() {
+		return models.DisputeNote{}, services.ErrDisputeClosed
+	}
+
+	return s.addNote(ctx, dispute.ID, note)
+}
+
+func (s *Service) addNote(ctx context.Context, disputeID, note string) (models.DisputeNote, error) {
+	created, err := s.db.CreateNote(ctx, models.DisputeNote{
+		ID:        ulid.GenerateID(s.now()),
+		DisputeID: disputeID,
+		ActorID:   actor.ID(ctx),
+		Note:      note,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		return models.DisputeNote{}, services.FromRepository(err, nil)
+	}
+
+	return created, nil
+}
+
+// getLocked looks up a dispute and locks it. The dispute is read again once locked, as it may have
+// changed while waiting for the lock.
+func (s *Service) getLocked(ctx context.Context, id string) (models.Dispute, func(context.Context) error, error) {
+	dispute, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return models.Dispute{}, nil, services.FromRepository(err, services.ErrDisputeNotFound)
+	}
+
+	unlock, err := s.lock(ctx, dispute.TransactionID)
+	if err != nil {
+		return models.Dispute{}, nil, err
+	}
+
+	dispute, err = s.db.GetByID(ctx, id)
+	if err != nil {
+		_ = unlock(ctx)
+
+		return models.Dispute{}, nil, services.FromRepository(err, services.ErrDisputeNotFound)
+	}
+
+	return dispute, unlock, nil
+}
+
+// lock serializes the changes to the dispute of a transaction, including opening it.
+func (s *Service) lock(ctx context.Context, transactionID string) (func(context.Context) error, error) {
+	unlock, err := s.locker.Lock(ctx, "dispute:"+transactionID)
+	if err != nil {
+		return nil, services.Unavailable(fmt.Errorf("failed to lock dispute: %w", err))
+	}
+
+	return unlock, nil
+}
@@ -0,0 +1,329 @@
+package disputes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/disputes"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newService returns a service over the store of newStore, and the transaction service moving its funds,
+// configured with the options of opts.
+func newService(t *testing.T, opts ...storeOption) (*disputes.Service, *transactions.Service) {
+	t.Helper()
+
+	store := newStore(t)
+
+	options := make([]transactions.Option, 0, len(opts))
+	for _, opt := range opts {
+		options = append(options, opt(store))
+	}
+
+	transactionService := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(),
+		store.Locker(), time.Now, options...)
+
+	return disputes.NewService(transactionService, store.Disputes(), store.Locker(), time.Now, 0), transactionService
+}
+
+// newStore returns a store with a wallet funded with 1000 that later spent 300 with "debit-1". A "fees-usd"
+// wallet can collect fees.
+func newStore(t *testing.T) *memory.Store {
+	t.Helper()
+
+	ctx := context.Background()
+	store := memory.New(time.Now)
+
+	for id, owner := range map[string]string{"wallet-1": "owner-1", "fees-usd": "bank"} {
+		_, err := store.Wallets().Create(ctx, models.Wallet{
+			ID: id, OwnerID: owner, Currency: "USD", Status: string(types.WalletStatusActive), Version: 1,
+		})
+		require.NoError(t, err)
+	}
+
+	for _, transaction := range []models.Transaction{
+		{ID: "credit-1", Amount: 1000, Type: string(types.TransactionTypeCredit)},
+		{ID: "debit-1", Amount: 300, Type: string(types.TransactionTypeDebit)},
+	} {
+		transaction.WalletID = "wallet-1"
+		transaction.Bucket = string(types.BalanceBucketCash)
+		transaction.Status = string(types.TransactionStatusCompleted)
+		transaction.Version = 1
+
+		_, err := store.Transactions().Create(ctx, transaction)
+		require.NoError(t, err)
+	}
+
+	return store
+}
+
+// storeOption configures the transaction service of newService over its store.
+type storeOption func(store *memory.Store) transactions.Option
+
+// withFees charges rules, collected by "fees-usd".
+func withFees(rules ...fees.Rule) storeOption {
+	return func(store *memory.Store) transactions.Option {
+		return transactions.WithFees(fees.NewSchedule(rules, map[string]string{"USD": "fees-usd"}), store.Transactions())
+	}
+}
+
+func balance(t *testing.T, transactionService *transactions.Service) int {
+	t.Helper()
+
+	balance, err := transactionService.RunningBalance(context.Background(), "wallet-1")
+	require.NoError(t, err)
+
+	return balance
+}
+
+func TestOpenDispute(t *testing.T) {
+	service, transactionService := newService(t)
+	ctx := context.Background()
+
+	_, err := service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "credit-1"})
+	require.ErrorIs(t, err, services.ErrNotDisputable)
+
+	_, err = service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "missing"})
+	require.ErrorIs(t, err, services.ErrTransactionNotFound)
+
+	dispute, err := service.OpenDispute(ctx, models.OpenDisputeRequest{
+		TransactionID: "debit-1", Reason: "goods not received", ProvisionalCredit: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(types.DisputeStatusOpen), dispute.Status)
+	assert.Equal(t, 300, dispute.Amount)
+	assert.WithinDuration(t, time.Now().Add(disputes.DefaultResponseWindow), dispute.DueAt, time.Minute)
+	require.NotNil(t, dispute.ProvisionalCreditID)
+	assert.Equal(t, 1000, balance(t, transactionService), "the provisional credit gives the amount back")
+
+	_, err = service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "debit-1"})
+	require.ErrorIs(t, err, services.ErrDuplicateDispute)
+}
+
+func TestUpdateDisputeStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		provisionalCredit bool
+		status            types.DisputeStatus
+		balance           int
+		settled           bool
+	}{
+		{name: "won without provisional credit refunds", status: types.DisputeStatusWon, balance: 1000, settled: true},
+		{name: "won keeps the provisional credit", provisionalCredit: true, status: types.DisputeStatusWon, balance: 1000},
+		{name: "lost without provisional credit", status: types.DisputeStatusLost, balance: 700},
+		{
+			name: "lost reverses the provisional credit", provisionalCredit: true, status: types.DisputeStatusLost,
+			balance: 700, settled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, transactionService := newService(t)
+			ctx := context.Background()
+
+			opened, err := service.OpenDispute(ctx, models.OpenDisputeRequest{
+				TransactionID: "debit-1", ProvisionalCredit: tt.provisionalCredit,
+			})
+			require.NoError(t, err)
+
+			reviewed, err := service.UpdateDisputeStatus(ctx, opened.ID, string(types.DisputeStatusUnderReview),
+				nil, nil)
+			require.NoError(t, err)
+
+			_, err = service.UpdateDisputeStatus(ctx, opened.ID, string(tt.status), nil, &opened.Version)
+			require.ErrorIs(t, err, repositories.ErrVersionConflict)
+
+			note := "evidence reviewed"
+			dispute, err := service.UpdateDisputeStatus(ctx, opened.ID, string(tt.status), &note, &reviewed.Version)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.status), dispute.Status)
+			assert.Equal(t, &note, dispute.ResolutionNote)
+			assert.Equal(t, tt.balance, balance(t, transactionService))
+
+			if !tt.settled {
+				assert.Nil(t, dispute.SettlementID)
+
+				return
+			}
+
+			require.NotNil(t, dispute.SettlementID)
+
+			settlement, err := transactionService.GetTransactionByID(ctx, *dispute.SettlementID)
+			require.NoError(t, err)
+			assert.Equal(t, 300, settlement.Amount)
+			assert.Equal(t, string(types.TransactionStatusCompleted), settlement.Status)
+
+			_, err = service.UpdateDisputeStatus(ctx, opened.ID, string(types.DisputeStatusUnderReview), nil, nil)
+			require.ErrorIs(t, err, services.ErrInvalidTransition, "resolved disputes no longer change")
+		})
+	}
+}
+
+func TestUpdateDisputeStatus_ResolvesOnlyReviewed(t *testing.T) {
+	service, transactionService := newService(t)
+	ctx := context.Background()
+
+	dispute, err := service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "debit-1"})
+	require.NoError(t, err)
+
+	for _, status := range []types.DisputeStatus{types.DisputeStatusWon, types.DisputeStatusLost} {
+		_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(status), nil, nil)
+		require.ErrorIs(t, err, services.ErrInvalidTransition)
+	}
+
+	dispute, err = service.GetDispute(ctx, dispute.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(types.DisputeStatusOpen), dispute.Status)
+	assert.Nil(t, dispute.SettlementID)
+	assert.Equal(t, 700, balance(t, transactionService), "nothing is refunded")
+}
+
+func TestUpdateDisputeStatus_ReversalMayOverdraw(t *testing.T) {
+	service, transactionService := newService(t)
+	ctx := context.Background()
+
+	dispute, err := service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "debit-1", ProvisionalCredit: true})
+	require.NoError(t, err)
+
+	_, err = transactionService.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 800, Type: string(types.TransactionTypeDebit), IdempotencyKey: "spend-1",
+	})
+	require.NoError(t, err)
+
+	_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusUnderReview), nil, nil)
+	require.NoError(t, err)
+
+	dispute, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusLost), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, string(types.DisputeStatusLost), dispute.Status)
+	require.NotNil(t, dispute.SettlementID)
+	assert.Equal(t, -100, balance(t, transactionService), "the reversal takes the balance below zero")
+
+	_, err = transactionService.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 1, Type: string(types.TransactionTypeDebit), IdempotencyKey: "spend-2",
+	})
+	require.ErrorIs(t, err, services.ErrInsufficientFunds, "other debits still need funds")
+}
+
+// flakyDisputes fails the given number of updates that resolve a dispute.
+type flakyDisputes struct {
+	*memory.DisputeRepository
+	failures int
+}
+
+func (r *flakyDisputes) Update(ctx context.Context, dispute models.Dispute) (models.Dispute, error) {
+	if dispute.Resolved() && r.failures > 0 {
+		r.failures--
+
+		return models.Dispute{}, errors.New("connection reset")
+	}
+
+	return r.DisputeRepository.Update(ctx, dispute)
+}
+
+func TestUpdateDisputeStatus_RetryReusesSettlement(t *testing.T) {
+	store := newStore(t)
+	transactionService := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(),
+		store.Locker(), time.Now)
+	service := disputes.NewService(transactionService, &flakyDisputes{DisputeRepository: store.Disputes(), failures: 1},
+		store.Locker(), time.Now, 0)
+	ctx := context.Background()
+
+	dispute, err := service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "debit-1"})
+	require.NoError(t, err)
+
+	_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusUnderReview), nil, nil)
+	require.NoError(t, err)
+
+	_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusWon), nil, nil)
+	require.Error(t, err)
+
+	dispute, err = service.GetDispute(ctx, dispute.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(types.DisputeStatusUnderReview), dispute.Status)
+	require.NotNil(t, dispute.SettlementID, "the refund is recorded as it is created")
+
+	refund := *dispute.SettlementID
+
+	// Dropping the cached idempotency keys shows the retry does not depend on them.
+	store.Restore(store.Snapshot())
+
+	_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusLost), nil, nil)
+	require.ErrorIs(t, err, services.ErrInvalidTransition, "the funds already moved for a won dispute")
+
+	dispute, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusWon), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, string(types.DisputeStatusWon), dispute.Status)
+	assert.Equal(t, &refund, dispute.SettlementID)
+	assert.Equal(t, 1000, balance(t, transactionService), "the amount is refunded once")
+}
+
+func TestUpdateDisputeStatus_PostingsPayNoFees(t *testing.T) {
+	service, transactionService := newService(t, withFees(
+		fees.Rule{Name: "deposit", Type: "credit", Kind: fees.KindFixed, Fixed: 5},
+		fees.Rule{Name: "withdrawal", Type: "debit", Kind: fees.KindFixed, Fixed: 5},
+	))
+	ctx := context.Background()
+
+	dispute, err := service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "debit-1", ProvisionalCredit: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1000, balance(t, transactionService), "the provisional credit pays no fee")
+
+	_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusUnderReview), nil, nil)
+	require.NoError(t, err)
+
+	dispute, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusLost), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 700, balance(t, transactionService), "the reversal adds no fee")
+
+	for _, id := range []string{*dispute.ProvisionalCreditID, *dispute.SettlementID} {
+		posting, err := transactionService.GetTransactionByID(ctx, id)
+		require.NoError(t, err)
+		assert.Empty(t, posting.Fees)
+		assert.True(t, posting.System, "the velocity rule does not count the postings")
+	}
+}
+
+func TestAddDisputeNote(t *testing.T) {
+	service, _ := newService(t)
+	ctx := actor.WithID(context.Background(), "agent-1")
+
+	_, err := service.AddDisputeNote(ctx, "missing", "receipt")
+	require.ErrorIs(t, err, services.ErrDisputeNotFound)
+
+	dispute, err := service.OpenDispute(ctx, models.OpenDisputeRequest{TransactionID: "debit-1"})
+	require.NoError(t, err)
+
+	note, err := service.AddDisputeNote(ctx, dispute.ID, "receipt")
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1", note.ActorID)
+
+	review := "courier confirmed non-delivery"
+	_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusUnderReview), &review, nil)
+	require.NoError(t, err)
+
+	dispute, err = service.GetDispute(ctx, dispute.ID)
+	require.NoError(t, err)
+	require.Len(t, dispute.Notes, 2)
+	assert.Equal(t, "receipt", dispute.Notes[0].Note)
+	assert.Equal(t, review, dispute.Notes[1].Note)
+	assert.Nil(t, dispute.ResolutionNote)
+
+	_, err = service.UpdateDisputeStatus(ctx, dispute.ID, string(types.DisputeStatusWon), nil, nil)
+	require.NoError(t, err)
+
+	_, err = service.AddDisputeNote(ctx, dispute.ID, "late evidence")
+	require.ErrorIs(t, err, services.ErrDisputeClosed)
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"gorm.io/gorm"
+)
+
+// Domain errors returned by the services. Callers should match them with errors.Is, as they are
+// usually wrapped with more context.
+var (
+	ErrWalletNotFound      = errors.New("wallet not found")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrInsufficientFunds   = errors.New("insufficient funds")
+	ErrWalletNotActive     = errors.New("cannot create transaction for non active wallets")
+	ErrInvalidTransition   = errors.New("invalid status transition")
+	ErrDuplicateWallet     = errors.New("wallet already exists for this owner, currency and product")
+	ErrApprovalRequired    = errors.New("transaction awaits approval")
+	ErrNotApprover         = errors.New("caller is not allowed to approve transactions")
+	ErrSelfApproval        = errors.New("transactions must be approved by someone other than their maker")
+	ErrUnknownMaker        = errors.New("transactions awaiting approval need an identified maker")
+	ErrLinkedTransaction   = errors.New("linked transactions follow the status of the transaction they belong to")
+	ErrPeriodNotOver       = errors.New("interest is only accrued and paid out for periods that are over")
+	ErrDisputeNotFound     = errors.New("dispute not found")
+	ErrDuplicateDispute    = errors.New("transaction is already disputed")
+	ErrNotDisputable       = errors.New("only completed debits can be disputed")
+	ErrDisputeClosed       = errors.New("dispute is already resolved")
+	ErrTransactionDenied   = errors.New("transaction denied by risk rules")
+	ErrFeeWalletUnusable   = errors.New("fee income wallet cannot be credited")
+	// ErrUnavailable marks failures of the database, cache or locks. The request may succeed if retried.
+	ErrUnavailable = errors.New("service temporarily unavailable")
+)
+
+// Unavailable wraps an infrastructure failure so it is reported as ErrUnavailable while keeping the cause.
+func Unavailable(err error) error {
+	return fmt.Errorf("%w: %w", ErrUnavailable, err)
+}
+
+// FromRepository translates a repository error into a domain error. Missing rows become notFound,
+// errors that already carry a meaning are passed through and anything else is treated as unavailable.
+func FromRepository(err error, notFound error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound) && notFound != nil:
+		return notFound
+	case errors.Is(err, repositories.ErrVersionConflict),
+		errors.Is(err, repositories.ErrStaleFence),
+		errors.Is(err, repositories.ErrInvalidSort),
+		errors.Is(err, pagination.ErrInvalidCursor):
+		return err
+	default:
+		return Unavailable(err)
+	}
+}
@@ -0,0 +1,156 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/looplab/fsm"
+)
+
+type approvalRepo interface {
+	Create(ctx context.Context, approval models.TransactionApproval) (models.TransactionApproval, error)
+	ListByTransaction(ctx context.Context, transactionID string) (models.TransactionApprovals, error)
+}
+
+// ApprovalPolicy decides which transactions are held until a second person approves them.
+type ApprovalPolicy struct {
+	// Threshold is the amount above which transactions await approval. Zero only holds adjustments.
+	Threshold int
+	// Approvers are the actor IDs allowed to approve and reject transactions.
+	Approvers []string
+}
+
+// reason explains why a transaction awaits approval, or returns an empty string if it does not.
+func (p ApprovalPolicy) reason(req models.CreateTransactionRequest) string {
+	switch {
+	case req.Adjustment:
+		return "manual adjustment"
+	case p.Threshold > 0 && req.Amount > p.Threshold:
+		return fmt.Sprintf("amount above the approval threshold of %d", p.Threshold)
+	default:
+		return ""
+	}
+}
+
+func (p ApprovalPolicy) canApprove(actorID string) bool {
+	return actorID != "" && slices.Contains(p.Approvers, actorID)
+}
+
+// WithApprovals holds adjustments and transactions above the policy threshold until approved, and keeps
+// their approval trail in approvals. The repository must share the transactions repository's database.
+func WithApprovals(policy ApprovalPolicy, approvals approvalRepo) Option {
+	return func(s *Service) {
+		s.approvalPolicy = policy
+		s.approvals = approvals
+	}
+}
+
+// ApproveTransaction releases a transaction awaiting approval, which becomes pending.
+func (s *Service) ApproveTransaction(ctx context.Context, id, reason string) (models.Transaction, error) {
+	return s.review(ctx, id, types.ApprovalActionApproved, types.TransactionStatusPending, reason)
+}
+
+// RejectTransaction rejects a transaction awaiting approval. A rejected debit no longer holds funds.
+func (s *Service) RejectTransaction(ctx context.Context, id, reason string) (models.Transaction, error) {
+	return s.review(ctx, id, types.ApprovalActionRejected, types.TransactionStatusRejected, reason)
+}
+
+// ListTransactionApprovals returns the approval trail of a transaction, oldest entry first.
+func (s *Service) ListTransactionApprovals(ctx context.Context, id string) (models.TransactionApprovals, error) {
+	if _, err := s.GetTransactionByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if s.approvals == nil {
+		return models.TransactionApprovals{}, nil
+	}
+
+	trail, err := s.approvals.ListByTransaction(ctx, id)
+	if err != nil {
+		return nil, services.FromRepository(err, nil)
+	}
+
+	return trail, nil
+}
+
+func (s *Service) review(
+	ctx context.Context,
+	id string,
+	action types.ApprovalAction,
+	status types.TransactionStatus,
+	reason string,
+) (_ models.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.ReviewTransaction", tracing.TransactionID(id))
+	defer func() { tracing.End(span, err) }()
+
+	checker := actor.ID(ctx)
+	if s.approvals == nil || !s.approvalPolicy.canApprove(checker) {
+		return models.Transaction{}, services.ErrNotApprover
+	}
+
+	transaction, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return models.Transaction{}, services.FromRepository(err, services.ErrTransactionNotFound)
+	}
+
+	span.SetAttributes(tracing.WalletID(transaction.WalletID))
+
+	if transaction.ParentID != nil {
+		return models.Transaction{}, services.ErrLinkedTransaction
+	}
+
+	if fsm.NewFSM(transaction.Status, models.TransactionStates, nil).Cannot(string(status)) ||
+		transaction.Status != string(types.TransactionStatusAwaitingApproval) {
+		return models.Transaction{}, fmt.Errorf("%w from %s to %s",
+			services.ErrInvalidTransition, transaction.Status, status)
+	}
+
+	trail, err := s.approvals.ListByTransaction(ctx, id)
+	if err != nil {
+		return models.Transaction{}, services.FromRepository(err, nil)
+	}
+
+	// Without a known maker nobody can tell the checker is someone else, so the transaction can only be
+	// rejected.
+	switch maker := trail.Maker(); {
+	case maker == checker:
+		return models.Transaction{}, services.ErrSelfApproval
+	case maker == "" && action == types.ApprovalActionApproved:
+		return models.Transaction{}, services.ErrUnknownMaker
+	}
+
+	lock, err := s.lockWallet(ctx, transaction.WalletID)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+	defer lock.unlock(ctx)
+
+	return s.updateStatus(ctx, lock, transaction, string(status), func(ctx context.Context) error {
+		return s.audit(ctx, transaction.ID, action, checker, reason)
+	})
+}
+
+// audit adds an entry to the approval trail of a transaction.
+func (s *Service) audit(
+	ctx context.Context,
+	transactionID string,
+	action types.ApprovalAction,
+	actorID, reason string,
+) error {
+	_, err := s.approvals.Create(ctx, models.TransactionApproval{
+		ID:            ulid.GenerateID(s.now()),
+		TransactionID: transactionID,
+		Action:        string(action),
+		ActorID:       actorID,
+		Reason:        reason,
+	})
+
+	return services.FromRepository(err, nil)
+}
@@ -0,0 +1,188 @@
+package transactions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newApprovalService(t *testing.T) (*transactions.Service, *memory.Store) {
+	t.Helper()
+
+	store := memory.New(time.Now)
+
+	_, err := store.Wallets().Create(context.Background(), models.Wallet{
+		ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: string(types.WalletStatusActive), Version: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Transactions().Create(context.Background(), models.Transaction{
+		ID: "credit-1", WalletID: "wallet-1", Amount: 1000,
+		Type: string(types.TransactionTypeCredit), Status: string(types.TransactionStatusCompleted), Version: 1,
+	})
+	require.NoError(t, err)
+
+	service := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(), store.Locker(), time.Now,
+		transactions.WithApprovals(transactions.ApprovalPolicy{
+			Threshold: 500,
+			Approvers: []string{"maker", "checker"},
+		}, store.Approvals()))
+
+	return service, store
+}
+
+func TestCreateTransaction_AwaitsApproval(t *testing.T) {
+	service, _ := newApprovalService(t)
+	ctx := actor.WithID(context.Background(), "maker")
+
+	tests := []struct {
+		name   string
+		req    models.CreateTransactionRequest
+		status types.TransactionStatus
+	}{
+		{
+			name:   "at the threshold",
+			req:    models.CreateTransactionRequest{Amount: 500, Type: "credit"},
+			status: types.TransactionStatusPending,
+		},
+		{
+			name:   "above the threshold",
+			req:    models.CreateTransactionRequest{Amount: 501, Type: "debit"},
+			status: types.TransactionStatusAwaitingApproval,
+		},
+		{
+			name:   "adjustment",
+			req:    models.CreateTransactionRequest{Amount: 1, Type: "credit", Adjustment: true},
+			status: types.TransactionStatusAwaitingApproval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.WalletID = "wallet-1"
+			tt.req.IdempotencyKey = tt.name
+
+			transaction, err := service.CreateTransaction(ctx, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.status), transaction.Status)
+
+			trail, err := service.ListTransactionApprovals(ctx, transaction.ID)
+			require.NoError(t, err)
+
+			if tt.status == types.TransactionStatusPending {
+				assert.Empty(t, trail)
+
+				return
+			}
+
+			require.Len(t, trail, 1)
+			assert.Equal(t, string(types.ApprovalActionRequested), trail[0].Action)
+			assert.Equal(t, "maker", trail.Maker())
+		})
+	}
+}
+
+func TestApproveTransaction(t *testing.T) {
+	service, _ := newApprovalService(t)
+	maker := actor.WithID(context.Background(), "maker")
+	checker := actor.WithID(context.Background(), "checker")
+
+	transaction, err := service.CreateTransaction(maker, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 800, Type: "debit", IdempotencyKey: "debit-1",
+	})
+	require.NoError(t, err)
+
+	_, err = service.UpdateTransactionStatus(checker, transaction.ID, string(types.TransactionStatusPending), nil)
+	require.ErrorIs(t, err, services.ErrApprovalRequired)
+
+	_, err = service.ApproveTransaction(maker, transaction.ID, "looks right")
+	require.ErrorIs(t, err, services.ErrSelfApproval)
+
+	_, err = service.ApproveTransaction(actor.WithID(context.Background(), "intruder"), transaction.ID, "ok")
+	require.ErrorIs(t, err, services.ErrNotApprover)
+
+	approved, err := service.ApproveTransaction(checker, transaction.ID, "confirmed with the customer")
+	require.NoError(t, err)
+	assert.Equal(t, string(types.TransactionStatusPending), approved.Status)
+
+	_, err = service.RejectTransaction(checker, transaction.ID, "too late")
+	require.ErrorIs(t, err, services.ErrInvalidTransition)
+
+	trail, err := service.ListTransactionApprovals(checker, transaction.ID)
+	require.NoError(t, err)
+	require.Len(t, trail, 2)
+	assert.Equal(t, string(types.ApprovalActionApproved), trail[1].Action)
+	assert.Equal(t, "checker", trail[1].ActorID)
+	assert.Equal(t, "confirmed with the customer", trail[1].Reason)
+}
+
+func TestApproveTransaction_UnknownMaker(t *testing.T) {
+	service, store := newApprovalService(t)
+	ctx := context.Background()
+	checker := actor.WithID(ctx, "checker")
+
+	_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 1, Type: "credit", Adjustment: true, IdempotencyKey: "adjustment-1",
+	})
+	require.ErrorIs(t, err, services.ErrUnknownMaker, "transactions awaiting approval need a maker")
+
+	// Transactions held before makers were required may have an anonymous request in their trail.
+	for _, id := range []string{"held-1", "held-2"} {
+		_, err = store.Transactions().Create(ctx, models.Transaction{
+			ID: id, WalletID: "wallet-1", Amount: 1, Type: string(types.TransactionTypeCredit),
+			Status: string(types.TransactionStatusAwaitingApproval), Version: 1,
+		})
+		require.NoError(t, err)
+
+		_, err = store.Approvals().Create(ctx, models.TransactionApproval{
+			ID: "request-" + id, TransactionID: id, Action: string(types.ApprovalActionRequested), Reason: "adjustment",
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = service.ApproveTransaction(checker, "held-1", "looks right")
+	require.ErrorIs(t, err, services.ErrUnknownMaker)
+
+	rejected, err := service.RejectTransaction(checker, "held-2", "maker unknown")
+	require.NoError(t, err)
+	assert.Equal(t, string(types.TransactionStatusRejected), rejected.Status)
+}
+
+func TestRejectTransaction_ReleasesReservedFunds(t *testing.T) {
+	service, store := newApprovalService(t)
+	ctx := context.Background()
+
+	transaction, err := service.CreateTransaction(actor.WithID(ctx, "maker"), models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 800, Type: "debit", IdempotencyKey: "debit-1",
+	})
+	require.NoError(t, err)
+
+	// The debit awaiting approval holds its funds, so a second one cannot spend them.
+	_, err = service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 300, Type: "debit", IdempotencyKey: "debit-2",
+	})
+	require.ErrorIs(t, err, services.ErrInsufficientFunds)
+
+	rejected, err := service.RejectTransaction(actor.WithID(ctx, "checker"), transaction.ID, "not requested by owner")
+	require.NoError(t, err)
+	assert.Equal(t, string(types.TransactionStatusRejected), rejected.Status)
+
+	balance, err := store.Cache().GetBalance(ctx, "wallet-1")
+	require.NoError(t, err)
+	require.NotNil(t, balance)
+	assert.Equal(t, 1000, *balance)
+
+	_, err = service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 300, Type: "debit", IdempotencyKey: "debit-2",
+	})
+	require.NoError(t, err)
+}
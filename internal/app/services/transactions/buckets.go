@@ -0,0 +1,279 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"go.uber.org/zap"
+)
+
+// expiryBatchSize caps the expired credits written off by one ExpireCredits call.
+const expiryBatchSize = 100
+
+type allocationRepo interface {
+	CreateAllocation(ctx context.Context, allocation models.TransactionAllocation) (
+		models.TransactionAllocation, error)
+	ListAllocations(ctx context.Context, walletID string) (models.TransactionAllocations, error)
+	ListExpiredCredits(ctx context.Context, at time.Time, limit int) (models.Transactions, error)
+}
+
+// DefaultBucketPriority is the order debits take from the buckets in when the policy sets none, spending
+// promotional credit before real money.
+var DefaultBucketPriority = []string{string(types.BalanceBucketPromo), string(types.BalanceBucketCash)}
+
+// BucketPolicy decides how debits spend the balance buckets and how long promotional credit lasts.
+type BucketPolicy struct {
+	// Priority is the order debits take from the buckets in. Buckets left out come last. Empty is
+	// DefaultBucketPriority.
+	Priority []string
+	// PromoTTL is how long promotional credits created without an expiry last. Zero keeps them forever.
+	PromoTTL time.Duration
+}
+
+// WithBuckets splits wallet balances into buckets, which debits take from in the policy order. The
+// allocations of debits are kept in allocations, which must share the transactions repository's database.
+func WithBuckets(policy BucketPolicy, allocations allocationRepo) Option {
+	if len(policy.Priority) == 0 {
+		policy.Priority = DefaultBucketPriority
+	}
+
+	return func(s *Service) {
+		s.bucketPolicy = policy
+		s.allocations = allocations
+	}
+}
+
+// BalanceBuckets splits the balance of a wallet by bucket. It returns nil when buckets are not kept.
+func (s *Service) BalanceBuckets(ctx context.Context, walletID string) (_ models.BalanceBuckets, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.BalanceBuckets", tracing.WalletID(walletID))
+	defer func() { tracing.End(span, err) }()
+
+	if s.allocations == nil {
+		return nil, nil
+	}
+
+	// The ledger is read first: allocations of debits created in between are then left out along with
+	// their debits, instead of the debits being taken from cash.
+	ledger, err := s.db.ListAllTransactions(ctx, walletID)
+	if err != nil {
+		return nil, services.FromRepository(err, nil)
+	}
+
+	allocations, err := s.allocations.ListAllocations(ctx, walletID)
+	if err != nil {
+		return nil, services.FromRepository(err, nil)
+	}
+
+	return ledger.Buckets(allocations, s.now()), nil
+}
+
+// spendable returns the part of the balance of a locked wallet that debits can take, along with its
+// buckets when they are kept.
+func (s *Service) spendable(ctx context.Context, walletID string, ledger models.Transactions) (
+	int, models.BalanceBuckets, error) {
+	if s.allocations == nil {
+		return ledger.Balance(), nil, nil
+	}
+
+	allocations, err := s.allocations.ListAllocations(ctx, walletID)
+	if err != nil {
+		return 0, nil, services.FromRepository(err, nil)
+	}
+
+	buckets := ledger.Buckets(allocations, s.now())
+
+	return buckets.Available(), buckets, nil
+}
+
+// expiry returns when a new credit expires. Promotional credits created without an expiry get the
+// policy lifetime.
+func (s *Service) expiry(transaction models.Transaction) *time.Time {
+	expiresAt := transaction.ExpiresAt
+	if expiresAt == nil && transaction.Bucket == string(types.BalanceBucketPromo) && s.bucketPolicy.PromoTTL > 0 {
+		expiry := s.now().Add(s.bucketPolicy.PromoTTL)
+		expiresAt = &expiry
+	}
+
+	// SQLite compares timestamps as text, which only orders them in a single time zone.
+	if expiresAt != nil {
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	return expiresAt
+}
+
+// allocate takes the debits of walletID among transactions from its buckets, one after the other. When
+// overdraw is set, what the buckets do not cover is left unallocated, which takes it from cash.
+func (s *Service) allocate(buckets models.BalanceBuckets, walletID string, transactions models.Transactions,
+	overdraw bool) (models.TransactionAllocations, error) {
+	if buckets == nil {
+		return nil, nil
+	}
+
+	var allocations models.TransactionAllocations
+
+	for _, transaction := range transactions {
+		if transaction.WalletID != walletID || transaction.Type != string(types.TransactionTypeDebit) {
+			continue
+		}
+
+		taken, covered := buckets.Allocate(transaction, s.bucketPolicy.Priority, s.now())
+		if !covered && !overdraw {
+			return nil, services.ErrInsufficientFunds
+		}
+
+		for _, allocation := range taken {
+			allocation.ID = ulid.GenerateID(s.now())
+			allocations = append(allocations, allocation)
+		}
+	}
+
+	return allocations, nil
+}
+
+// ExpireCredits writes off what is left of the credits that expired, with a completed debit taking it
+// from their bucket. It handles up to expiryBatchSize credits per call, so it is meant to be run
+// periodically, and returns how many it wrote off.
+func (s *Service) ExpireCredits(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.ExpireCredits")
+	defer func() { tracing.End(span, err) }()
+
+	if s.allocations == nil {
+		return 0, nil
+	}
+
+	credits, err := s.allocations.ListExpiredCredits(ctx, s.now(), expiryBatchSize)
+	if err != nil {
+		return 0, services.FromRepository(err, nil)
+	}
+
+	var (
+		walletIDs []string
+		seen      = map[string]bool{}
+		errs      []error
+		expired   int
+	)
+
+	for _, credit := range credits {
+		if !seen[credit.WalletID] {
+			seen[credit.WalletID] = true
+			walletIDs = append(walletIDs, credit.WalletID)
+		}
+	}
+
+	// A wallet failing to expire does not hold back the others.
+	for _, walletID := range walletIDs {
+		count, err := s.expireWalletCredits(ctx, walletID)
+		if err != nil {
+			s.log(ctx).Error("error expiring credits", zap.Error(err), zap.String("walletID", walletID))
+			errs = append(errs, err)
+		}
+
+		expired += count
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// expireWalletCredits writes off the expired credits of a wallet. What is left of them is computed under
+// the wallet lock, so that running it concurrently writes nothing off twice.
+func (s *Service) expireWalletCredits(ctx context.Context, walletID string) (int, error) {
+	lock, err := s.lockWallet(ctx, walletID)
+	if err != nil {
+		return 0, err
+	}
+	defer lock.unlock(ctx)
+
+	ledger, err := s.db.ListAllTransactions(ctx, walletID)
+	if err != nil {
+		return 0, services.FromRepository(err, nil)
+	}
+
+	_, buckets, err := s.spendable(ctx, walletID, ledger)
+	if err != nil {
+		return 0, err
+	}
+
+	lots := buckets.Expired(s.now())
+	if len(lots) == 0 {
+		return 0, nil
+	}
+
+	writeOffs := make(models.Transactions, 0, len(lots))
+	allocations := make(models.TransactionAllocations, 0, len(lots))
+
+	for _, lot := range lots {
+		note := "expiry of credit " + lot.CreditID
+		writeOff := models.Transaction{
+			ID:       ulid.GenerateID(s.now()),
+			WalletID: walletID,
+			Amount:   lot.Amount,
+			Note:     &note,
+			Type:     string(types.TransactionTypeDebit),
+			Status:   string(types.TransactionStatusCompleted),
+			Version:  1,
+			System:   true,
+		}
+
+		writeOffs = append(writeOffs, writeOff)
+		allocations = append(allocations, models.TransactionAllocation{
+			ID:            ulid.GenerateID(s.now()),
+			TransactionID: writeOff.ID,
+			WalletID:      walletID,
+			Bucket:        lot.Bucket,
+			CreditID:      &lot.CreditID,
+			Amount:        lot.Amount,
+		})
+	}
+
+	if err := s.fenced(ctx, lock, func(ctx context.Context) error {
+		return s.db.Tx(ctx, func(ctx context.Context) error {
+			for i, writeOff := range writeOffs {
+				created, err := s.db.Create(ctx, writeOff)
+				if err != nil {
+					return services.FromRepository(err, nil)
+				}
+
+				writeOffs[i] = created
+			}
+
+			return s.createAllocations(ctx, allocations)
+		})
+	}); err != nil {
+		return 0, err
+	}
+
+	balance := ledger.Balance()
+
+	for _, writeOff := range writeOffs {
+		balance -= writeOff.Amount
+		after := balance
+
+		transactionsTotal.WithLabelValues(writeOff.Type, writeOff.Status).Inc()
+		s.publish(ctx, events.TransactionCreated(writeOff, &after, s.now()))
+	}
+
+	if err := s.cache.SetBalance(ctx, walletID, balance); err != nil {
+		s.log(ctx).Warn("error setting balance in cache", zap.Error(err), zap.String("walletID", walletID))
+	}
+
+	return len(writeOffs), nil
+}
+
+func (s *Service) createAllocations(ctx context.Context, allocations models.TransactionAllocations) error {
+	for _, allocation := range allocations {
+		if _, err := s.allocations.CreateAllocation(ctx, allocation); err != nil {
+			return services.FromRepository(err, nil)
+		}
+	}
+
+	return nil
+}
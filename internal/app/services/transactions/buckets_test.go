@@ -0,0 +1,175 @@
+package transactions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is a time source tests move forward by hand.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+// newBucketService returns a service over a wallet holding 1000 in cash, and the clock it runs on.
+func newBucketService(t *testing.T, priority ...string) (*transactions.Service, *memory.Store, *clock) {
+	t.Helper()
+
+	ctx := context.Background()
+	clock := &clock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	store := memory.New(clock.Now)
+
+	_, err := store.Wallets().Create(ctx, models.Wallet{
+		ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: string(types.WalletStatusActive), Version: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Transactions().Create(ctx, models.Transaction{
+		ID: "cash-1", WalletID: "wallet-1", Amount: 1000, Bucket: string(types.BalanceBucketCash),
+		Type: string(types.TransactionTypeCredit), Status: string(types.TransactionStatusCompleted), Version: 1,
+	})
+	require.NoError(t, err)
+
+	service := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(), store.Locker(),
+		clock.Now, transactions.WithBuckets(transactions.BucketPolicy{
+			Priority: priority,
+			PromoTTL: 30 * 24 * time.Hour,
+		}, store.Transactions()))
+
+	return service, store, clock
+}
+
+// grantPromo credits the wallet with completed promotional credit, once per test.
+func grantPromo(t *testing.T, service *transactions.Service, amount int, expiresAt *time.Time) models.Transaction {
+	t.Helper()
+
+	ctx := context.Background()
+
+	credit, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: amount, Type: "credit", IdempotencyKey: "promo-1",
+		Bucket: string(types.BalanceBucketPromo), ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+
+	credit, err = service.UpdateTransactionStatus(ctx, credit.ID, string(types.TransactionStatusCompleted), nil)
+	require.NoError(t, err)
+
+	return credit
+}
+
+func bucketBalances(t *testing.T, service *transactions.Service) map[string][2]int {
+	t.Helper()
+
+	buckets, err := service.BalanceBuckets(context.Background(), "wallet-1")
+	require.NoError(t, err)
+
+	balances := map[string][2]int{}
+	for _, bucket := range buckets {
+		balances[bucket.Bucket] = [2]int{bucket.Balance, bucket.Available}
+	}
+
+	return balances
+}
+
+func TestCreateTransaction_SpendsBucketsInPriorityOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority []string
+		after    map[string][2]int
+	}{
+		{
+			name:     "promo first",
+			priority: []string{"promo", "cash"},
+			after:    map[string][2]int{"cash": {900, 900}, "promo": {0, 0}},
+		},
+		{
+			name:  "promo first by default",
+			after: map[string][2]int{"cash": {900, 900}, "promo": {0, 0}},
+		},
+		{
+			name:     "cash first",
+			priority: []string{"cash"},
+			after:    map[string][2]int{"cash": {600, 600}, "promo": {300, 300}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, clock := newBucketService(t, tt.priority...)
+			ctx := context.Background()
+
+			promo := grantPromo(t, service, 300, nil)
+			require.NotNil(t, promo.ExpiresAt)
+			assert.Equal(t, clock.now.Add(30*24*time.Hour), *promo.ExpiresAt, "promo credit gets the default lifetime")
+
+			debit, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+				WalletID: "wallet-1", Amount: 400, Type: "debit", IdempotencyKey: "debit-1",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.after, bucketBalances(t, service))
+
+			_, err = service.UpdateTransactionStatus(ctx, debit.ID, string(types.TransactionStatusFailed), nil)
+			require.NoError(t, err)
+			assert.Equal(t, map[string][2]int{"cash": {1000, 1000}, "promo": {300, 300}}, bucketBalances(t, service),
+				"a failed debit gives back what it took")
+		})
+	}
+}
+
+func TestExpireCredits(t *testing.T) {
+	service, store, clock := newBucketService(t, "promo", "cash")
+	ctx := context.Background()
+
+	expiresAt := clock.now.Add(time.Hour)
+	promo := grantPromo(t, service, 300, &expiresAt)
+
+	_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 100, Type: "debit", IdempotencyKey: "debit-1",
+	})
+	require.NoError(t, err)
+
+	expired, err := service.ExpireCredits(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, expired, "nothing expired yet")
+
+	clock.now = clock.now.Add(2 * time.Hour)
+	assert.Equal(t, map[string][2]int{"cash": {1000, 1000}, "promo": {200, 0}}, bucketBalances(t, service))
+
+	_, err = service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 1100, Type: "debit", IdempotencyKey: "debit-2",
+	})
+	require.ErrorIs(t, err, services.ErrInsufficientFunds, "expired credit cannot be spent")
+
+	expired, err = service.ExpireCredits(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, map[string][2]int{"cash": {1000, 1000}, "promo": {0, 0}}, bucketBalances(t, service))
+
+	ledger, err := store.Transactions().ListAllTransactions(ctx, "wallet-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1000, ledger.Balance())
+	assert.Equal(t, "expiry of credit "+promo.ID, *ledger[0].Note)
+	assert.Equal(t, 200, ledger[0].Amount)
+	assert.Equal(t, string(types.TransactionStatusCompleted), ledger[0].Status)
+
+	balance, err := store.Cache().GetBalance(ctx, "wallet-1")
+	require.NoError(t, err)
+	require.NotNil(t, balance)
+	assert.Equal(t, 1000, *balance)
+
+	expired, err = service.ExpireCredits(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, expired, "credits are only written off once")
+}
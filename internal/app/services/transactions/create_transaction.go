@@ -2,24 +2,32 @@ package transactions
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
 
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 func (s *Service) CreateTransaction(ctx context.Context, req models.CreateTransactionRequest) (
-	models.Transaction, error) {
+	_ models.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.CreateTransaction",
+		tracing.WalletID(req.WalletID), tracing.TransactionType(req.Type))
+	defer func() { tracing.End(span, err) }()
+
 	// Lock on the idempotency key to prevent race conditions.
-	idempotencyUnlock, err := s.cache.Mutex(ctx, fmt.Sprintf("idempotency:%s", req.IdempotencyKey))
+	idempotencyUnlock, err := s.locker.Lock(ctx, fmt.Sprintf("idempotency:%s", req.IdempotencyKey))
 	if err != nil {
-		log.Println("error locking idempotency key:", zap.Error(err), zap.String("idempotencyKey", req.IdempotencyKey))
+		s.log(ctx).Error("error locking idempotency key", zap.Error(err), zap.String("idempotencyKey", req.IdempotencyKey))
 
-		return models.Transaction{}, err
+		return models.Transaction{}, services.Unavailable(err)
 	}
 
 	defer func() {
@@ -28,13 +36,13 @@ func (s *Service) CreateTransaction(ctx context.Context, req models.CreateTransa
 
 	existingTransaction, err := s.cache.GetIdempotentTransaction(ctx, req.IdempotencyKey)
 	if err != nil {
-		log.Println("error checking idempotency key:", zap.Error(err), zap.String("idempotencyKey", req.IdempotencyKey))
+		s.log(ctx).Error("error checking idempotency key", zap.Error(err), zap.String("idempotencyKey", req.IdempotencyKey))
 
-		return models.Transaction{}, err
+		return models.Transaction{}, services.Unavailable(err)
 	}
 
 	if existingTransaction != nil {
-		log.Println("returning cached transaction for idempotency key:",
+		s.log(ctx).Debug("returning cached transaction for idempotency key",
 			zap.String("idempotencyKey", req.IdempotencyKey),
 			zap.String("transactionID", existingTransaction.ID))
 
@@ -47,74 +55,266 @@ func (s *Service) CreateTransaction(ctx context.Context, req models.CreateTransa
 func (s *Service) create(ctx context.Context, req models.CreateTransactionRequest) (models.Transaction, error) {
 	wallet, err := s.walletRepo.GetByID(ctx, req.WalletID)
 	if err != nil {
-		log.Println("error getting wallet by ID:", zap.Error(err), zap.String("walletID", req.WalletID))
+		s.log(ctx).Error("error getting wallet by ID", zap.Error(err), zap.String("walletID", req.WalletID))
 
-		return models.Transaction{}, err
+		return models.Transaction{}, services.FromRepository(err, services.ErrWalletNotFound)
 	}
 
 	if wallet.Status != types.WalletStatusActive.String() {
-		return models.Transaction{}, errors.New("cannot create transaction for non active wallets")
+		rejectionsTotal.WithLabelValues(string(types.ErrorCodeWalletNotActive)).Inc()
+
+		return models.Transaction{}, services.ErrWalletNotActive
 	}
 
+	unlockOwner, err := s.lockOwner(ctx, wallet, req)
+	if err != nil {
+		s.log(ctx).Error("error locking owner", zap.Error(err))
+
+		return models.Transaction{}, err
+	}
+
+	defer func() {
+		_ = unlockOwner(ctx)
+	}()
+
 	// lock the wallet to prevent race conditions.
-	unlock, err := s.cache.Mutex(ctx, wallet.ID)
+	lock, err := s.lockWallet(ctx, wallet.ID)
 	if err != nil {
-		log.Println("error locking wallet:", zap.Error(err))
+		s.log(ctx).Error("error locking wallet", zap.Error(err))
 
 		return models.Transaction{}, err
 	}
 
 	defer func() {
-		unlock(ctx)
+		lock.unlock(ctx)
 	}()
 
 	ledger, err := s.db.ListAllTransactions(ctx, wallet.ID)
 	if err != nil {
-		log.Println("error listing all transactions:", zap.Error(err), zap.String("walletID", wallet.ID))
+		s.log(ctx).Error("error listing all transactions", zap.Error(err), zap.String("walletID", wallet.ID))
+
+		return models.Transaction{}, services.FromRepository(err, nil)
+	}
+
+	spendable, buckets, err := s.spendable(ctx, wallet.ID, ledger)
+	if err != nil {
+		s.log(ctx).Error("error listing allocations", zap.Error(err), zap.String("walletID", wallet.ID))
 
 		return models.Transaction{}, err
 	}
 
-	if req.Type == string(types.TransactionTypeDebit) && ledger.Balance() < req.Amount {
-		log.Println("insufficient funds for transaction:",
+	var charged fees.Fees
+	if !req.System {
+		charged = s.feesOf(wallet, req.Type, req.Amount)
+	}
+
+	if required := debited(req, charged); !req.System && required > 0 && spendable < required {
+		s.log(ctx).Info("insufficient funds for transaction",
 			zap.String("walletID", wallet.ID),
 			zap.Int("transactionAmount", req.Amount),
-			zap.Int("balance",
-				ledger.Balance()))
+			zap.Int("fees", charged.Total()),
+			zap.Int("balance", spendable))
+
+		rejectionsTotal.WithLabelValues(string(types.ErrorCodeInsufficientFunds)).Inc()
 
-		return models.Transaction{}, errors.New("insufficient funds")
+		return models.Transaction{}, services.ErrInsufficientFunds
+	}
+
+	decision, err := s.screen(ctx, wallet, req)
+	if err != nil {
+		return models.Transaction{}, err
 	}
 
 	transaction := req.ToTransaction()
 	transaction.ID = ulid.GenerateID(s.now())
+	transaction.ExpiresAt = s.expiry(transaction)
+
+	approvalReason := s.approvalReason(req, decision)
+	if approvalReason != "" {
+		// The maker is recorded so that someone else approves the transaction.
+		if actor.ID(ctx) == "" {
+			rejectionsTotal.WithLabelValues(string(types.ErrorCodeUnknownMaker)).Inc()
+
+			return models.Transaction{}, fmt.Errorf("%w: %s", services.ErrUnknownMaker, approvalReason)
+		}
+
+		transaction.Status = string(types.TransactionStatusAwaitingApproval)
+	}
 
-	transaction, err = s.db.Create(ctx, transaction)
+	linked, err := s.feeTransactions(ctx, wallet, charged, transaction.Status)
 	if err != nil {
-		log.Println("error creating transaction:", zap.Error(err))
+		return models.Transaction{}, err
+	}
+
+	allocations, err := s.allocate(buckets, wallet.ID, append(models.Transactions{transaction}, linked...),
+		req.System)
+	if err != nil {
+		rejectionsTotal.WithLabelValues(string(types.ErrorCodeInsufficientFunds)).Inc()
 
 		return models.Transaction{}, err
 	}
 
+	// The balance check above only holds if no other writer took the lock since, which the fence ensures.
+	if err := s.fenced(ctx, lock, func(ctx context.Context) error {
+		transaction, linked, err = s.insert(ctx, transaction, linked, allocations, approvalReason, decision,
+			req.Record)
+
+		return err
+	}); err != nil {
+		s.log(ctx).Error("error creating transaction", zap.Error(err))
+
+		return models.Transaction{}, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(tracing.TransactionID(transaction.ID))
+	transactionsTotal.WithLabelValues(transaction.Type, transaction.Status).Inc()
+
+	balance := balanceAfterCreate(ledger.Balance(), transaction)
+
+	s.publish(ctx, events.TransactionCreated(transaction, &balance, s.now()))
+
+	for _, linkedTransaction := range linked {
+		transactionsTotal.WithLabelValues(linkedTransaction.Type, linkedTransaction.Status).Inc()
+		s.publish(ctx, events.TransactionCreated(linkedTransaction, nil, s.now()))
+	}
+
 	if err := s.cache.SetIdempotentTransaction(ctx, req.IdempotencyKey, transaction); err != nil {
-		log.Println("error caching transaction for idempotency:",
+		s.log(ctx).Warn("error caching transaction for idempotency",
 			zap.Error(err),
 			zap.String("idempotencyKey", req.IdempotencyKey))
 	}
 
-	return s.updateBalanceInCache(ctx, ledger.Balance(), transaction)
+	return s.updateBalanceInCache(ctx, balance, transaction)
 }
 
-func (s *Service) updateBalanceInCache(
+// insert stores the transaction along with the transactions linked to it, the allocations of its debits,
+// the decision of the risk rules, if any, and, when it awaits approval, the request that opens its approval
+// trail. record, if set, is called last in the same database transaction. The fees of the returned
+// transaction are its linked debits of the same wallet.
+func (s *Service) insert(
 	ctx context.Context,
-	currentBalance int,
 	transaction models.Transaction,
-) (models.Transaction, error) {
+	linked models.Transactions,
+	allocations models.TransactionAllocations,
+	approvalReason string,
+	decision models.RiskDecision,
+	record func(ctx context.Context, transaction models.Transaction) error,
+) (models.Transaction, models.Transactions, error) {
+	if len(linked) == 0 && len(allocations) == 0 && approvalReason == "" && decision.ID == "" && record == nil {
+		transaction, err := s.db.Create(ctx, transaction)
+
+		return transaction, nil, services.FromRepository(err, nil)
+	}
+
+	stored := make(models.Transactions, 0, len(linked))
+
+	err := s.db.Tx(ctx, func(ctx context.Context) error {
+		var err error
+
+		transaction, err = s.db.Create(ctx, transaction)
+		if err != nil {
+			return services.FromRepository(err, nil)
+		}
+
+		for _, linkedTransaction := range linked {
+			linkedTransaction.ParentID = &transaction.ID
+
+			linkedTransaction, err = s.db.Create(ctx, linkedTransaction)
+			if err != nil {
+				return services.FromRepository(err, nil)
+			}
+
+			stored = append(stored, linkedTransaction)
+		}
+
+		if err := s.createAllocations(ctx, allocations); err != nil {
+			return err
+		}
+
+		if decision.ID != "" {
+			decision.TransactionID = &transaction.ID
+
+			if _, err := s.risk.Create(ctx, decision); err != nil {
+				return services.FromRepository(err, nil)
+			}
+		}
+
+		if approvalReason != "" {
+			err := s.audit(ctx, transaction.ID, types.ApprovalActionRequested, actor.ID(ctx), approvalReason)
+			if err != nil {
+				return err
+			}
+		}
+
+		if record == nil {
+			return nil
+		}
+
+		return record(ctx, transaction)
+	})
+	if err != nil {
+		return models.Transaction{}, nil, err
+	}
+
+	for _, linkedTransaction := range stored {
+		if linkedTransaction.WalletID == transaction.WalletID {
+			transaction.Fees = append(transaction.Fees, linkedTransaction)
+		}
+	}
+
+	return transaction, stored, nil
+}
+
+// approvalReason explains why the transaction must await approval, or returns an empty string if it
+// need not. Transactions the risk rules put up for review await approval too.
+func (s *Service) approvalReason(req models.CreateTransactionRequest, decision models.RiskDecision) string {
+	if s.approvals == nil {
+		return ""
+	}
+
+	reason := s.approvalPolicy.reason(req)
+	if decision.Decision != string(types.RiskDecisionReview) {
+		return reason
+	}
+
+	if reason == "" {
+		return heldReason(decision)
+	}
+
+	return reason + "; " + heldReason(decision)
+}
+
+// debited returns the amount a transaction and its fees reserve from the wallet balance.
+func debited(req models.CreateTransactionRequest, charged fees.Fees) int {
+	if req.Type == string(types.TransactionTypeDebit) {
+		return req.Amount + charged.Total()
+	}
+
+	return charged.Total()
+}
+
+// balanceAfterCreate is the wallet balance once the new transaction and its fees are recorded. Debits
+// are reserved right away, even while awaiting approval, and credits only count once completed.
+func balanceAfterCreate(currentBalance int, transaction models.Transaction) int {
+	balance := currentBalance
+	for _, fee := range transaction.Fees {
+		balance -= fee.Amount
+	}
+
 	if transaction.Type == string(types.TransactionTypeDebit) {
-		currentBalance -= transaction.Amount
+		return balance - transaction.Amount
 	}
 
-	if err := s.cache.SetBalance(ctx, transaction.WalletID, currentBalance); err != nil {
-		log.Println("error setting balance in cache:", zap.Error(err), zap.String("walletID", transaction.WalletID))
+	return balance
+}
+
+func (s *Service) updateBalanceInCache(
+	ctx context.Context,
+	balance int,
+	transaction models.Transaction,
+) (models.Transaction, error) {
+	if err := s.cache.SetBalance(ctx, transaction.WalletID, balance); err != nil {
+		s.log(ctx).Warn("error setting balance in cache", zap.Error(err), zap.String("walletID", transaction.WalletID))
 	}
 
 	return transaction, nil
@@ -0,0 +1,147 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type linkedRepo interface {
+	ListLinked(ctx context.Context, parentID string) (models.Transactions, error)
+}
+
+// WithFees charges the fees of schedule on every transaction created, as linked transactions moving the
+// fee from the wallet to the income wallet of its currency. Linked transactions follow the status of
+// their parent, which linked looks them up in. It must share the transactions repository's database.
+func WithFees(schedule fees.Schedule, linked linkedRepo) Option {
+	return func(s *Service) {
+		s.feeSchedule = schedule
+		s.linked = linked
+	}
+}
+
+// QuoteTransaction previews the fees a transaction would be charged, without creating it.
+func (s *Service) QuoteTransaction(ctx context.Context, req models.QuoteTransactionRequest) (
+	models.TransactionQuote, error) {
+	wallet, err := s.walletRepo.GetByID(ctx, req.WalletID)
+	if err != nil {
+		return models.TransactionQuote{}, services.FromRepository(err, services.ErrWalletNotFound)
+	}
+
+	return models.TransactionQuote{
+		WalletID: wallet.ID,
+		Currency: wallet.Currency,
+		Type:     req.Type,
+		Amount:   req.Amount,
+		Fees:     s.feesOf(wallet, req.Type, req.Amount),
+	}, nil
+}
+
+// feesOf returns the fees charged on a transaction of the wallet. Income wallets are charged none, as
+// they would pay the fees to themselves.
+func (s *Service) feesOf(wallet models.Wallet, transactionType string, amount int) fees.Fees {
+	incomeWallet, found := s.feeSchedule.IncomeWallet(wallet.Currency)
+	if !found || incomeWallet == wallet.ID {
+		return nil
+	}
+
+	return s.feeSchedule.Quote(wallet.Currency, transactionType, amount)
+}
+
+// feeTransactions returns, for every fee, the debit of the wallet and the credit of the income wallet.
+// They are linked to their parent once it is stored. The income wallet must be an active wallet of the
+// currency, or the fees could not be credited and the transaction is refused.
+func (s *Service) feeTransactions(ctx context.Context, wallet models.Wallet, charged fees.Fees, status string) (
+	models.Transactions, error) {
+	transactions := make(models.Transactions, 0, 2*len(charged))
+	if len(charged) == 0 {
+		return transactions, nil
+	}
+
+	incomeWallet, err := s.incomeWallet(ctx, wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fee := range charged {
+		note := "fee: " + fee.Rule
+
+		for _, leg := range []struct {
+			walletID        string
+			transactionType types.TransactionType
+		}{
+			{wallet.ID, types.TransactionTypeDebit},
+			{incomeWallet.ID, types.TransactionTypeCredit},
+		} {
+			transaction := models.Transaction{
+				ID:       ulid.GenerateID(s.now()),
+				WalletID: leg.walletID,
+				Amount:   fee.Amount,
+				Note:     &note,
+				Type:     string(leg.transactionType),
+				Status:   status,
+				Version:  1,
+			}
+
+			if leg.transactionType == types.TransactionTypeCredit {
+				transaction.Bucket = string(types.BalanceBucketCash)
+			}
+
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	return transactions, nil
+}
+
+// incomeWallet looks up the wallet collecting the fees of currency. fees.income_wallets is only checked
+// against the wallets here, as they may be created, frozen or closed while the service runs.
+func (s *Service) incomeWallet(ctx context.Context, currency string) (models.Wallet, error) {
+	id, _ := s.feeSchedule.IncomeWallet(currency)
+
+	wallet, err := s.walletRepo.GetByID(ctx, id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Wallet{}, services.FromRepository(err, nil)
+	}
+
+	var problem string
+
+	switch {
+	case err != nil:
+		problem = "does not exist"
+	case wallet.Currency != currency:
+		problem = "holds " + wallet.Currency
+	case wallet.Status != string(types.WalletStatusActive):
+		problem = "is " + wallet.Status
+	default:
+		return wallet, nil
+	}
+
+	s.log(ctx).Error("fee income wallet is unusable, check fees.income_wallets",
+		zap.String("currency", currency), zap.String("walletID", id), zap.String("problem", problem))
+	rejectionsTotal.WithLabelValues(string(types.ErrorCodeFeeWalletUnusable)).Inc()
+
+	return models.Wallet{}, fmt.Errorf("%w: %s wallet %s %s", services.ErrFeeWalletUnusable, currency, id, problem)
+}
+
+// listLinked returns the transactions following the status of a transaction.
+func (s *Service) listLinked(ctx context.Context, transactionID string) (models.Transactions, error) {
+	if s.linked == nil {
+		return nil, nil
+	}
+
+	linked, err := s.linked.ListLinked(ctx, transactionID)
+	if err != nil {
+		return nil, services.FromRepository(err, nil)
+	}
+
+	return linked, nil
+}
@@ -0,0 +1,209 @@
+package transactions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFeeService(t *testing.T) (*transactions.Service, *memory.Store) {
+	t.Helper()
+
+	ctx := context.Background()
+	store := memory.New(time.Now)
+
+	for _, id := range []string{"wallet-1", "fees-usd"} {
+		_, err := store.Wallets().Create(ctx, models.Wallet{
+			ID: id, OwnerID: id, Currency: "USD", Status: string(types.WalletStatusActive), Version: 1,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := store.Transactions().Create(ctx, models.Transaction{
+		ID: "credit-1", WalletID: "wallet-1", Amount: 1000,
+		Type: string(types.TransactionTypeCredit), Status: string(types.TransactionStatusCompleted), Version: 1,
+	})
+	require.NoError(t, err)
+
+	schedule := fees.NewSchedule([]fees.Rule{
+		{Name: "withdrawal", Type: "debit", Kind: fees.KindPercentage, BasisPoints: 100, Min: 20},
+		{Name: "processing", Type: "debit", Kind: fees.KindFixed, Fixed: 5},
+	}, map[string]string{"USD": "fees-usd"})
+
+	service := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(), store.Locker(), time.Now,
+		transactions.WithFees(schedule, store.Transactions()))
+
+	return service, store
+}
+
+func balanceOf(t *testing.T, store *memory.Store, walletID string) int {
+	t.Helper()
+
+	ledger, err := store.Transactions().ListAllTransactions(context.Background(), walletID)
+	require.NoError(t, err)
+
+	return ledger.Balance()
+}
+
+func TestQuoteTransaction(t *testing.T) {
+	service, _ := newFeeService(t)
+
+	quote, err := service.QuoteTransaction(context.Background(), models.QuoteTransactionRequest{
+		WalletID: "wallet-1", Amount: 3000, Type: "debit",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fees.Fees{{Rule: "withdrawal", Amount: 30}, {Rule: "processing", Amount: 5}}, quote.Fees)
+	assert.Equal(t, -3035, quote.ToResponse().BalanceChange)
+
+	_, err = service.QuoteTransaction(context.Background(), models.QuoteTransactionRequest{
+		WalletID: "missing", Amount: 3000, Type: "debit",
+	})
+	require.ErrorIs(t, err, services.ErrWalletNotFound)
+}
+
+func TestCreateTransaction_ChargesFees(t *testing.T) {
+	service, store := newFeeService(t)
+	ctx := context.Background()
+
+	_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 990, Type: "debit", IdempotencyKey: "too-much",
+	})
+	require.ErrorIs(t, err, services.ErrInsufficientFunds, "the fees must be covered too")
+
+	debit, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 500, Type: "debit", IdempotencyKey: "debit-1",
+	})
+	require.NoError(t, err)
+	require.Len(t, debit.Fees, 2)
+	assert.Equal(t, debit.ID, *debit.Fees[0].ParentID)
+	assert.Equal(t, 20, debit.Fees[0].Amount)
+	assert.Equal(t, 475, balanceOf(t, store, "wallet-1"))
+	assert.Equal(t, 0, balanceOf(t, store, "fees-usd"), "fee income counts once the transaction completes")
+
+	_, err = service.UpdateTransactionStatus(ctx, debit.Fees[0].ID, string(types.TransactionStatusCompleted), nil)
+	require.ErrorIs(t, err, services.ErrLinkedTransaction)
+
+	_, err = service.UpdateTransactionStatus(ctx, debit.ID, string(types.TransactionStatusCompleted), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 475, balanceOf(t, store, "wallet-1"))
+	assert.Equal(t, 25, balanceOf(t, store, "fees-usd"))
+
+	income, err := store.Transactions().ListAllTransactions(ctx, "fees-usd")
+	require.NoError(t, err)
+
+	for _, transaction := range income {
+		assert.Equal(t, string(types.TransactionStatusCompleted), transaction.Status)
+	}
+}
+
+func TestUpdateTransactionStatus_RefundsFeesOfFailedTransaction(t *testing.T) {
+	service, store := newFeeService(t)
+	ctx := context.Background()
+
+	debit, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 500, Type: "debit", IdempotencyKey: "debit-1",
+	})
+	require.NoError(t, err)
+
+	_, err = service.UpdateTransactionStatus(ctx, debit.ID, string(types.TransactionStatusFailed), nil)
+	require.NoError(t, err)
+
+	balance, err := store.Cache().GetBalance(ctx, "wallet-1")
+	require.NoError(t, err)
+	require.NotNil(t, balance)
+	assert.Equal(t, 1000, *balance)
+	assert.Equal(t, 1000, balanceOf(t, store, "wallet-1"))
+	assert.Equal(t, 0, balanceOf(t, store, "fees-usd"))
+}
+
+func TestCreateTransaction_SystemPostingsPayNoFees(t *testing.T) {
+	service, store := newFeeService(t)
+	ctx := context.Background()
+
+	debit, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 500, Type: "debit", IdempotencyKey: "reversal-1", System: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, debit.Fees)
+
+	linked, err := store.Transactions().ListLinked(ctx, debit.ID)
+	require.NoError(t, err)
+	assert.Empty(t, linked)
+	assert.Equal(t, 500, balanceOf(t, store, "wallet-1"))
+}
+
+func TestCreateTransaction_UnusableIncomeWallet(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, store *memory.Store) string
+		problem string
+	}{
+		{
+			name:    "missing",
+			prepare: func(*testing.T, *memory.Store) string { return "fees-missing" },
+			problem: "USD wallet fees-missing does not exist",
+		},
+		{
+			name: "of another currency",
+			prepare: func(t *testing.T, store *memory.Store) string {
+				_, err := store.Wallets().Create(context.Background(), models.Wallet{
+					ID: "fees-eur", OwnerID: "fees-eur", Currency: "EUR", Status: string(types.WalletStatusActive),
+					Version: 1,
+				})
+				require.NoError(t, err)
+
+				return "fees-eur"
+			},
+			problem: "USD wallet fees-eur holds EUR",
+		},
+		{
+			name: "frozen",
+			prepare: func(t *testing.T, store *memory.Store) string {
+				wallet, err := store.Wallets().GetByID(context.Background(), "fees-usd")
+				require.NoError(t, err)
+
+				wallet.Status = string(types.WalletStatusFrozen)
+				_, err = store.Wallets().Update(context.Background(), wallet)
+				require.NoError(t, err)
+
+				return "fees-usd"
+			},
+			problem: "USD wallet fees-usd is frozen",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, store := newFeeService(t)
+			ctx := context.Background()
+
+			schedule := fees.NewSchedule([]fees.Rule{
+				{Name: "processing", Type: "debit", Kind: fees.KindFixed, Fixed: 5},
+			}, map[string]string{"USD": tt.prepare(t, store)})
+
+			service := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(), store.Locker(),
+				time.Now, transactions.WithFees(schedule, store.Transactions()))
+
+			_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+				WalletID: "wallet-1", Amount: 500, Type: "debit", IdempotencyKey: "debit-1",
+			})
+			require.ErrorIs(t, err, services.ErrFeeWalletUnusable)
+			assert.Contains(t, err.Error(), tt.problem)
+			assert.Equal(t, 1000, balanceOf(t, store, "wallet-1"), "nothing is stored")
+
+			_, err = service.CreateTransaction(ctx, models.CreateTransactionRequest{
+				WalletID: "wallet-1", Amount: 500, Type: "credit", IdempotencyKey: "credit-2",
+			})
+			require.NoError(t, err, "transactions charged no fees go through")
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package transactions_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiredLocker grants every lock right away, as if the previous holder's lock had already expired.
+type expiredLocker struct{}
+
+func (expiredLocker) Lock(context.Context, string) (func(context.Context) error, error) {
+	return func(context.Context) error { return nil }, nil
+}
+
+// stallingRepo stalls after the first ledger read until resumed, like a slow query or a GC pause would.
+type stallingRepo struct {
+	*memory.TransactionRepository
+
+	stalls  atomic.Bool
+	stalled chan struct{}
+	resume  chan struct{}
+}
+
+func (r *stallingRepo) ListAllTransactions(ctx context.Context, walletID string) (models.Transactions, error) {
+	ledger, err := r.TransactionRepository.ListAllTransactions(ctx, walletID)
+
+	if r.stalls.CompareAndSwap(false, true) {
+		close(r.stalled)
+		<-r.resume
+	}
+
+	return ledger, err
+}
+
+func TestCreateTransaction_RejectsWriterWhoseLockExpired(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New(time.Now)
+
+	_, err := store.Wallets().Create(ctx, models.Wallet{
+		ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: string(types.WalletStatusActive), Version: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Transactions().Create(ctx, models.Transaction{
+		ID: "credit-1", WalletID: "wallet-1", Amount: 100,
+		Type: string(types.TransactionTypeCredit), Status: string(types.TransactionStatusCompleted), Version: 1,
+	})
+	require.NoError(t, err)
+
+	repo := &stallingRepo{
+		TransactionRepository: store.Transactions(),
+		stalled:               make(chan struct{}),
+		resume:                make(chan struct{}),
+	}
+	service := transactions.NewService(store.Wallets(), repo, store.Cache(), expiredLocker{}, time.Now,
+		transactions.WithFencing(store.Wallets()))
+
+	debit := func(key string) models.CreateTransactionRequest {
+		return models.CreateTransactionRequest{
+			WalletID: "wallet-1", Amount: 100, Type: string(types.TransactionTypeDebit), IdempotencyKey: key,
+		}
+	}
+
+	stale := make(chan error, 1)
+
+	go func() {
+		_, err := service.CreateTransaction(ctx, debit("first"))
+		stale <- err
+	}()
+
+	// The first writer has read a balance of 100 when its lock expires and a second writer takes over.
+	<-repo.stalled
+
+	_, err = service.CreateTransaction(ctx, debit("second"))
+	require.NoError(t, err)
+
+	close(repo.resume)
+
+	require.ErrorIs(t, <-stale, repositories.ErrStaleFence)
+
+	ledger, err := store.Transactions().ListAllTransactions(ctx, "wallet-1")
+	require.NoError(t, err)
+	assert.Len(t, ledger, 2)
+	assert.Equal(t, 0, ledger.Balance())
+}
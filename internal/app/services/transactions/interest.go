@@ -0,0 +1,267 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/interest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// interestPageSize is the number of wallets AccrueInterest reads at once.
+const interestPageSize = 100
+
+// monthLayout is the layout of the months interest is paid out for.
+const monthLayout = "2006-01"
+
+type accrualRepo interface {
+	CreateAccrual(ctx context.Context, accrual models.InterestAccrual) (models.InterestAccrual, error)
+	LastAccrual(ctx context.Context, walletID, before string) (models.InterestAccrual, error)
+	ListUnpostedWallets(ctx context.Context, from, to string) ([]string, error)
+	ListUnpostedAccruals(ctx context.Context, walletID, from, to string) (models.InterestAccruals, error)
+	MarkAccrualsPosted(ctx context.Context, walletID string, days []string, transactionID string) error
+}
+
+type walletLister interface {
+	List(ctx context.Context, query models.QueryWallets) ([]models.Wallet, *pagination.Pagination, error)
+}
+
+// WithInterest accrues interest daily on the wallets earning a rate of schedule, which wallets lists, and
+// pays it out monthly. Accruals are kept in accruals, which must share the transactions repository's
+// database.
+func WithInterest(schedule interest.Schedule, wallets walletLister, accruals accrualRepo) Option {
+	return func(s *Service) {
+		s.interestSchedule = schedule
+		s.interestWallets = wallets
+		s.accruals = accruals
+	}
+}
+
+// AccrueInterest accrues the interest the wallets earning a rate earned on the UTC day of date, from their
+// balance at the end of it, and returns how many accrued. Running it again for the same day accrues
+// nothing twice. The fraction of a minor unit left over is carried from the latest day accrued before, so
+// days are meant to be accrued in order.
+func (s *Service) AccrueInterest(ctx context.Context, date time.Time) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.AccrueInterest")
+	defer func() { tracing.End(span, err) }()
+
+	start := startOfDay(date)
+	end := start.AddDate(0, 0, 1)
+
+	if end.After(s.now()) {
+		return 0, services.ErrPeriodNotOver
+	}
+
+	if s.accruals == nil {
+		return 0, nil
+	}
+
+	var (
+		accrued int
+		errs    []error
+	)
+
+	for _, rate := range s.interestSchedule.Rates {
+		limit := interestPageSize
+		query := models.QueryWallets{
+			Currencies: []string{rate.Currency},
+			Products:   []string{rate.Product},
+			Paginator:  pagination.Paginator{Limit: &limit},
+		}
+
+		for {
+			wallets, page, err := s.interestWallets.List(ctx, query)
+			if err != nil {
+				return accrued, services.FromRepository(err, nil)
+			}
+
+			// A wallet failing to accrue does not hold back the others.
+			for _, wallet := range wallets {
+				if !wallet.CreatedAt.Before(end) {
+					continue
+				}
+
+				ok, err := s.accrueWalletInterest(ctx, wallet.ID, rate.AnnualBasisPoints, start)
+				if err != nil {
+					s.log(ctx).Error("error accruing interest", zap.Error(err), zap.String("walletID", wallet.ID))
+					errs = append(errs, err)
+				}
+
+				if ok {
+					accrued++
+				}
+			}
+
+			if page == nil || page.NextCursor == nil {
+				break
+			}
+
+			query.After = page.NextCursor
+		}
+	}
+
+	return accrued, errors.Join(errs...)
+}
+
+// accrueWalletInterest accrues the interest of a wallet for the day starting at start. It reports false
+// when the day already accrued.
+func (s *Service) accrueWalletInterest(ctx context.Context, walletID string, annualBasisPoints int,
+	start time.Time) (bool, error) {
+	day := start.Format(models.DateLayout)
+
+	ledger, err := s.db.ListAllTransactions(ctx, walletID)
+	if err != nil {
+		return false, services.FromRepository(err, nil)
+	}
+
+	last, err := s.accruals.LastAccrual(ctx, walletID, day)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, services.FromRepository(err, nil)
+	}
+
+	balance := ledger.BalanceAt(start.AddDate(0, 0, 1))
+	amount, carry := interest.Accrue(balance, annualBasisPoints, last.Carry)
+
+	if _, err := s.accruals.CreateAccrual(ctx, models.InterestAccrual{
+		WalletID:          walletID,
+		AccruedOn:         day,
+		Balance:           balance,
+		AnnualBasisPoints: annualBasisPoints,
+		Amount:            amount,
+		Carry:             carry,
+	}); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return false, nil
+		}
+
+		return false, services.FromRepository(err, nil)
+	}
+
+	return true, nil
+}
+
+// PostInterest pays out the interest accrued in the UTC month of date, with a completed cash credit per
+// wallet, and returns how many wallets were paid. Running it again pays out only what accrued since.
+func (s *Service) PostInterest(ctx context.Context, date time.Time) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.PostInterest")
+	defer func() { tracing.End(span, err) }()
+
+	start := startOfDay(date).AddDate(0, 0, 1-date.UTC().Day())
+	end := start.AddDate(0, 1, 0)
+
+	if end.After(s.now()) {
+		return 0, services.ErrPeriodNotOver
+	}
+
+	if s.accruals == nil {
+		return 0, nil
+	}
+
+	from, to := start.Format(models.DateLayout), end.Format(models.DateLayout)
+
+	walletIDs, err := s.accruals.ListUnpostedWallets(ctx, from, to)
+	if err != nil {
+		return 0, services.FromRepository(err, nil)
+	}
+
+	var (
+		posted int
+		errs   []error
+	)
+
+	for _, walletID := range walletIDs {
+		ok, err := s.postWalletInterest(ctx, walletID, from, to, "interest for "+start.Format(monthLayout))
+		if err != nil {
+			s.log(ctx).Error("error posting interest", zap.Error(err), zap.String("walletID", walletID))
+			errs = append(errs, err)
+		}
+
+		if ok {
+			posted++
+		}
+	}
+
+	return posted, errors.Join(errs...)
+}
+
+// postWalletInterest pays out the unposted interest a wallet accrued from from up to to excluded. The
+// accruals are read under the wallet lock, so that running it concurrently pays nothing out twice.
+func (s *Service) postWalletInterest(ctx context.Context, walletID, from, to, note string) (bool, error) {
+	lock, err := s.lockWallet(ctx, walletID)
+	if err != nil {
+		return false, err
+	}
+	defer lock.unlock(ctx)
+
+	accruals, err := s.accruals.ListUnpostedAccruals(ctx, walletID, from, to)
+	if err != nil {
+		return false, services.FromRepository(err, nil)
+	}
+
+	if len(accruals) == 0 {
+		return false, nil
+	}
+
+	ledger, err := s.db.ListAllTransactions(ctx, walletID)
+	if err != nil {
+		return false, services.FromRepository(err, nil)
+	}
+
+	days := make([]string, 0, len(accruals))
+	for _, accrual := range accruals {
+		days = append(days, accrual.AccruedOn)
+	}
+
+	credit := models.Transaction{
+		ID:       ulid.GenerateID(s.now()),
+		WalletID: walletID,
+		Amount:   accruals.Total(),
+		Note:     &note,
+		Type:     string(types.TransactionTypeCredit),
+		Status:   string(types.TransactionStatusCompleted),
+		Bucket:   string(types.BalanceBucketCash),
+		Version:  1,
+		System:   true,
+	}
+
+	if err := s.fenced(ctx, lock, func(ctx context.Context) error {
+		return s.db.Tx(ctx, func(ctx context.Context) error {
+			created, err := s.db.Create(ctx, credit)
+			if err != nil {
+				return services.FromRepository(err, nil)
+			}
+
+			credit = created
+
+			return services.FromRepository(s.accruals.MarkAccrualsPosted(ctx, walletID, days, credit.ID), nil)
+		})
+	}); err != nil {
+		return false, err
+	}
+
+	balance := ledger.Balance() + credit.Amount
+
+	transactionsTotal.WithLabelValues(credit.Type, credit.Status).Inc()
+	s.publish(ctx, events.TransactionCreated(credit, &balance, s.now()))
+
+	if err := s.cache.SetBalance(ctx, walletID, balance); err != nil {
+		s.log(ctx).Warn("error setting balance in cache", zap.Error(err), zap.String("walletID", walletID))
+	}
+
+	return true, nil
+}
+
+func startOfDay(date time.Time) time.Time {
+	year, month, day := date.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,151 @@
+package transactions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/interest"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInterestService returns a service over a savings wallet earning 5% a year on 1000.00 and a plain
+// wallet earning nothing, both funded on 2026-01-01, and the clock it runs on.
+func newInterestService(t *testing.T) (*transactions.Service, *memory.Store, *clock) {
+	t.Helper()
+
+	ctx := context.Background()
+	clock := &clock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	store := memory.New(clock.Now)
+
+	for _, wallet := range []models.Wallet{
+		{ID: "savings-1", OwnerID: "owner-1", Currency: "USD", Product: "savings"},
+		{ID: "plain-1", OwnerID: "owner-1", Currency: "USD"},
+	} {
+		wallet.Status = string(types.WalletStatusActive)
+		wallet.Version = 1
+
+		_, err := store.Wallets().Create(ctx, wallet)
+		require.NoError(t, err)
+
+		_, err = store.Transactions().Create(ctx, models.Transaction{
+			ID: "deposit-" + wallet.ID, WalletID: wallet.ID, Amount: 100000, Bucket: string(types.BalanceBucketCash),
+			Type: string(types.TransactionTypeCredit), Status: string(types.TransactionStatusCompleted), Version: 1,
+		})
+		require.NoError(t, err)
+	}
+
+	service := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(), store.Locker(),
+		clock.Now, transactions.WithInterest(interest.NewSchedule([]interest.Rate{
+			{Currency: "USD", Product: "savings", AnnualBasisPoints: 500},
+		}), store.Wallets(), store.Interest()))
+
+	return service, store, clock
+}
+
+func TestAccrueInterest(t *testing.T) {
+	service, store, clock := newInterestService(t)
+	ctx := context.Background()
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.AccrueInterest(ctx, january)
+	require.ErrorIs(t, err, services.ErrPeriodNotOver)
+
+	clock.now = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for day := range 31 {
+		accrued, err := service.AccrueInterest(ctx, january.AddDate(0, 0, day))
+		require.NoError(t, err)
+		assert.Equal(t, 1, accrued, "only the savings wallet earns a rate")
+	}
+
+	accrued, err := service.AccrueInterest(ctx, january.Add(6*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, accrued, "a day accrues once")
+
+	// 1000.00 at 5% earns 0.136986... a day: 0.13 with the fractions carried, plus a cent on the days
+	// they add up to one.
+	accruals, err := store.Interest().ListUnpostedAccruals(ctx, "savings-1", "2026-01-01", "2026-02-01")
+	require.NoError(t, err)
+	require.Len(t, accruals, 31)
+	assert.Equal(t, 424, accruals.Total())
+	assert.Equal(t, 13, accruals[0].Amount)
+	assert.Equal(t, 14, accruals[1].Amount)
+	assert.Equal(t, 100000, accruals[30].Balance)
+}
+
+func TestPostInterest(t *testing.T) {
+	service, store, clock := newInterestService(t)
+	ctx := context.Background()
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clock.now = time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.PostInterest(ctx, january)
+	require.ErrorIs(t, err, services.ErrPeriodNotOver)
+
+	for day := range 19 {
+		_, err := service.AccrueInterest(ctx, january.AddDate(0, 0, day))
+		require.NoError(t, err)
+	}
+
+	clock.now = time.Date(2026, 2, 1, 1, 0, 0, 0, time.UTC)
+
+	posted, err := service.PostInterest(ctx, january.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	assert.Equal(t, 1, posted)
+
+	posted, err = service.PostInterest(ctx, january)
+	require.NoError(t, err)
+	assert.Zero(t, posted, "interest is paid out once")
+
+	// Days accrued late are paid out by the next run.
+	for day := 19; day < 31; day++ {
+		_, err := service.AccrueInterest(ctx, january.AddDate(0, 0, day))
+		require.NoError(t, err)
+	}
+
+	posted, err = service.PostInterest(ctx, january)
+	require.NoError(t, err)
+	assert.Equal(t, 1, posted)
+
+	credits, _, err := service.ListTransactions(ctx, models.QueryTransactions{WalletIDs: []string{"savings-1"}})
+	require.NoError(t, err)
+
+	total := 0
+
+	for _, credit := range credits {
+		if credit.ID == "deposit-savings-1" {
+			continue
+		}
+
+		require.NotNil(t, credit.Note)
+		assert.Equal(t, "interest for 2026-01", *credit.Note)
+		assert.Equal(t, string(types.TransactionTypeCredit), credit.Type)
+		assert.Equal(t, string(types.TransactionStatusCompleted), credit.Status)
+		total += credit.Amount
+	}
+
+	assert.Equal(t, 424, total)
+
+	ledger, err := store.Transactions().ListAllTransactions(ctx, "savings-1")
+	require.NoError(t, err)
+	assert.Equal(t, 100424, ledger.Balance())
+
+	// Interest paid out earns interest from the day after.
+	clock.now = time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	_, err = service.AccrueInterest(ctx, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	accruals, err := store.Interest().ListUnpostedAccruals(ctx, "savings-1", "2026-02-01", "2026-03-01")
+	require.NoError(t, err)
+	require.Len(t, accruals, 1)
+	assert.Equal(t, 100424, accruals[0].Balance)
+}
@@ -0,0 +1,33 @@
+package transactions
+
+import (
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	transactionsTotal = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "transactions_total",
+		Help: "Transactions created and moved to a new status, by type and the status reached.",
+	}, "type", "status")
+
+	rejectionsTotal = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "transaction_rejections_total",
+		Help: "Transactions refused by business rules, by reason.",
+	}, "reason")
+
+	riskDecisionsTotal = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "risk_decisions_total",
+		Help: "Decisions of the risk rules run before transactions are created, by decision.",
+	}, "decision")
+
+	riskRuleHitsTotal = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "risk_rule_hits_total",
+		Help: "Transactions a risk rule did not allow, by rule.",
+	}, "rule")
+
+	balanceCacheTotal = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "balance_cache_requests_total",
+		Help: "Running balance lookups by cache result, hit or miss.",
+	}, "result")
+)
@@ -4,8 +4,8 @@ package mocks
 
 import (
 	context "context"
-	models "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
 
+	models "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -74,36 +74,6 @@ func (_m *MockCacheClient) GetIdempotentTransaction(ctx context.Context, idempot
 	return r0, r1
 }
 
-// Mutex provides a mock function with given fields: ctx, key
-func (_m *MockCacheClient) Mutex(ctx context.Context, key string) (func(context.Context) (bool, error), error) {
-	ret := _m.Called(ctx, key)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Mutex")
-	}
-
-	var r0 func(context.Context) (bool, error)
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (func(context.Context) (bool, error), error)); ok {
-		return rf(ctx, key)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) func(context.Context) (bool, error)); ok {
-		r0 = rf(ctx, key)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(func(context.Context) (bool, error))
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, key)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // SetBalance provides a mock function with given fields: ctx, walletID, balance
 func (_m *MockCacheClient) SetBalance(ctx context.Context, walletID string, balance int) error {
 	ret := _m.Called(ctx, walletID, balance)
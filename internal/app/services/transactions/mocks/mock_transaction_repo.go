@@ -5,11 +5,8 @@ package mocks
 import (
 	context "context"
 
-	gorm "gorm.io/gorm"
-
-	mock "github.com/stretchr/testify/mock"
-
 	models "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
 
 	pagination "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
 )
@@ -47,26 +44,6 @@ func (_m *MockTransactionRepo) Create(ctx context.Context, transaction models.Tr
 	return r0, r1
 }
 
-// DB provides a mock function with given fields: ctx
-func (_m *MockTransactionRepo) DB(ctx context.Context) *gorm.DB {
-	ret := _m.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DB")
-	}
-
-	var r0 *gorm.DB
-	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
-	}
-
-	return r0
-}
-
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *MockTransactionRepo) GetByID(ctx context.Context, id string) (models.Transaction, error) {
 	ret := _m.Called(ctx, id)
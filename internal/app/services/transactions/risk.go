@@ -0,0 +1,147 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/risk"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"go.uber.org/zap"
+)
+
+type riskRepo interface {
+	Create(ctx context.Context, decision models.RiskDecision) (models.RiskDecision, error)
+	List(ctx context.Context, query models.QueryRiskDecisions) (models.RiskDecisions, *pagination.Pagination, error)
+}
+
+// WithRisk runs the rules of engine before every transaction is created, and keeps their decisions in
+// decisions. Denied transactions are refused with services.ErrTransactionDenied and those up for review
+// await approval, so WithApprovals must be set for them to be let through; without it they are denied.
+// The repository must share the transactions repository's database.
+func WithRisk(engine *risk.Engine, decisions riskRepo) Option {
+	return func(s *Service) {
+		s.riskEngine = engine
+		s.risk = decisions
+	}
+}
+
+// ListRiskDecisions returns the decisions of the risk rules along with the rules hit.
+func (s *Service) ListRiskDecisions(ctx context.Context, query models.QueryRiskDecisions) (
+	models.RiskDecisions, *pagination.Pagination, error) {
+	if s.risk == nil {
+		paginator := repositories.GetPaginator(query.Paginator)
+
+		return models.RiskDecisions{}, pagination.NewPagination(*paginator.Page, 0, 0, *paginator.PerPage), nil
+	}
+
+	decisions, pagination, err := s.risk.List(ctx, query)
+	if err != nil {
+		return nil, nil, services.FromRepository(err, nil)
+	}
+
+	return decisions, pagination, nil
+}
+
+// lockOwner serializes the transactions of the wallets of an owner while a rule counts them, as transactions
+// created in parallel on the owner's other wallets would otherwise each pass the count. It is taken before
+// the wallet lock, and is a no-op for system postings, which are not screened.
+func (s *Service) lockOwner(ctx context.Context, wallet models.Wallet, req models.CreateTransactionRequest) (
+	func(context.Context) error, error) {
+	if req.System || s.riskEngine == nil || !s.riskEngine.OwnerScoped() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	unlock, err := s.locker.Lock(ctx, "owner:"+wallet.OwnerID)
+	if err != nil {
+		return nil, services.Unavailable(fmt.Errorf("failed to lock owner: %w", err))
+	}
+
+	return unlock, nil
+}
+
+// screen runs the risk rules on a transaction about to be created. The returned decision is stored along
+// with the transaction; it is the zero RiskDecision when no rules are set or for system postings. A denied
+// transaction is recorded right away and refused with services.ErrTransactionDenied.
+func (s *Service) screen(ctx context.Context, wallet models.Wallet, req models.CreateTransactionRequest) (
+	models.RiskDecision, error) {
+	if req.System || s.riskEngine == nil || s.riskEngine.Len() == 0 {
+		return models.RiskDecision{}, nil
+	}
+
+	assessment, err := s.riskEngine.Assess(ctx, risk.Input{Wallet: wallet, Request: req, Now: s.now()})
+	if err != nil {
+		s.log(ctx).Error("error assessing transaction risk", zap.Error(err), zap.String("walletID", wallet.ID))
+
+		return models.RiskDecision{}, services.Unavailable(err)
+	}
+
+	// Without approvals nobody could release a held transaction.
+	if assessment.Decision == types.RiskDecisionReview && s.approvals == nil {
+		assessment.Decision = types.RiskDecisionDeny
+	}
+
+	decision := s.riskDecision(wallet, req, assessment)
+
+	riskDecisionsTotal.WithLabelValues(decision.Decision).Inc()
+
+	for _, hit := range assessment.Hits {
+		riskRuleHitsTotal.WithLabelValues(hit.Rule).Inc()
+	}
+
+	if assessment.Decision != types.RiskDecisionDeny {
+		return decision, nil
+	}
+
+	s.log(ctx).Info("transaction denied by risk rules",
+		zap.String("walletID", wallet.ID),
+		zap.String("idempotencyKey", req.IdempotencyKey),
+		zap.String("reason", assessment.Reason()))
+
+	rejectionsTotal.WithLabelValues(string(types.ErrorCodeTransactionDenied)).Inc()
+
+	if _, err := s.risk.Create(ctx, decision); err != nil {
+		s.log(ctx).Error("error recording risk decision", zap.Error(err), zap.String("walletID", wallet.ID))
+	}
+
+	return models.RiskDecision{}, services.ErrTransactionDenied
+}
+
+func (s *Service) riskDecision(wallet models.Wallet, req models.CreateTransactionRequest,
+	assessment risk.Assessment) models.RiskDecision {
+	decision := models.RiskDecision{
+		ID:             ulid.GenerateID(s.now()),
+		WalletID:       wallet.ID,
+		IdempotencyKey: req.IdempotencyKey,
+		Type:           req.Type,
+		Amount:         req.Amount,
+		Decision:       string(assessment.Decision),
+		Hits:           make(models.RiskRuleHits, 0, len(assessment.Hits)),
+	}
+
+	for _, hit := range assessment.Hits {
+		decision.Hits = append(decision.Hits, models.RiskRuleHit{
+			ID:       ulid.GenerateID(s.now()),
+			Rule:     hit.Rule,
+			Decision: string(hit.Decision),
+			Reason:   hit.Reason,
+		})
+	}
+
+	return decision
+}
+
+// heldReason explains why the risk rules held a transaction for review.
+func heldReason(decision models.RiskDecision) string {
+	reasons := make([]string, 0, len(decision.Hits))
+	for _, hit := range decision.Hits {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", hit.Rule, hit.Reason))
+	}
+
+	return "held by risk rules: " + strings.Join(reasons, "; ")
+}
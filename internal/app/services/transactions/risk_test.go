@@ -0,0 +1,305 @@
+package transactions_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/risk"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRule stands in for a custom rule whose backing service is down.
+type failingRule struct{}
+
+func (failingRule) Name() string {
+	return "failing"
+}
+
+func (failingRule) Evaluate(context.Context, risk.Input) (risk.Verdict, error) {
+	return risk.Verdict{}, errors.New("scoring service down")
+}
+
+// newRiskStore returns a store holding a wallet of owner-1 funded with 1000.
+func newRiskStore(t *testing.T) *memory.Store {
+	t.Helper()
+
+	store := memory.New(time.Now)
+
+	_, err := store.Wallets().Create(context.Background(), models.Wallet{
+		ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: string(types.WalletStatusActive), Version: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Transactions().Create(context.Background(), models.Transaction{
+		ID: "credit-1", WalletID: "wallet-1", Amount: 1000,
+		Type: string(types.TransactionTypeCredit), Status: string(types.TransactionStatusCompleted), Version: 1,
+	})
+	require.NoError(t, err)
+
+	return store
+}
+
+// newRiskService returns a service over store that runs rules, along with approvals unless
+// withoutApprovals is set.
+func newRiskService(t *testing.T, store *memory.Store, withoutApprovals bool,
+	rules ...risk.Rule) *transactions.Service {
+	t.Helper()
+
+	opts := []transactions.Option{transactions.WithRisk(risk.NewEngine(rules...), store.Risk())}
+	if !withoutApprovals {
+		opts = append(opts, transactions.WithApprovals(transactions.ApprovalPolicy{
+			Approvers: []string{"checker"},
+		}, store.Approvals()))
+	}
+
+	return transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(), store.Locker(), time.Now,
+		opts...)
+}
+
+func TestCreateTransaction_RiskRules(t *testing.T) {
+	threshold := risk.AmountThreshold{Amount: 500, Decision: types.RiskDecisionReview}
+
+	tests := []struct {
+		name             string
+		rules            []risk.Rule
+		withoutApprovals bool
+		amount           int
+		decision         types.RiskDecision
+		status           types.TransactionStatus
+	}{
+		{
+			name: "allowed", rules: []risk.Rule{threshold}, amount: 100,
+			decision: types.RiskDecisionAllow, status: types.TransactionStatusPending,
+		},
+		{
+			name: "held for review", rules: []risk.Rule{threshold}, amount: 600,
+			decision: types.RiskDecisionReview, status: types.TransactionStatusAwaitingApproval,
+		},
+		{
+			name: "review without approvals is denied", rules: []risk.Rule{threshold}, withoutApprovals: true,
+			amount: 600, decision: types.RiskDecisionDeny,
+		},
+		{
+			name: "denied", amount: 100, decision: types.RiskDecisionDeny,
+			rules: []risk.Rule{threshold, risk.Blocklist{
+				Decision: types.RiskDecisionDeny, IDs: map[string]bool{"owner-1": true},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRiskStore(t)
+			service := newRiskService(t, store, tt.withoutApprovals, tt.rules...)
+			ctx := actor.WithID(context.Background(), "maker")
+
+			transaction, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+				WalletID: "wallet-1", Amount: tt.amount, Type: "debit", IdempotencyKey: "debit-1",
+			})
+
+			decisions, _, listErr := service.ListRiskDecisions(ctx, models.QueryRiskDecisions{})
+			require.NoError(t, listErr)
+			require.Len(t, decisions, 1, "every decision is recorded")
+			assert.Equal(t, string(tt.decision), decisions[0].Decision)
+			assert.Equal(t, "debit-1", decisions[0].IdempotencyKey)
+
+			if tt.decision == types.RiskDecisionDeny {
+				require.ErrorIs(t, err, services.ErrTransactionDenied)
+				assert.Nil(t, decisions[0].TransactionID)
+				assert.NotEmpty(t, decisions[0].Hits)
+				assert.Len(t, store.Snapshot().Transactions, 1, "denied transactions are never created")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.status), transaction.Status)
+			require.NotNil(t, decisions[0].TransactionID)
+			assert.Equal(t, transaction.ID, *decisions[0].TransactionID)
+
+			if tt.decision != types.RiskDecisionReview {
+				assert.Empty(t, decisions[0].Hits)
+
+				return
+			}
+
+			require.Len(t, decisions[0].Hits, 1)
+			assert.Equal(t, "amount_threshold", decisions[0].Hits[0].Rule)
+
+			trail, err := service.ListTransactionApprovals(ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, trail, 1)
+			assert.Equal(t, "held by risk rules: amount_threshold: amount above 500", trail[0].Reason)
+
+			approved, err := service.ApproveTransaction(actor.WithID(ctx, "checker"), transaction.ID, "verified")
+			require.NoError(t, err)
+			assert.Equal(t, string(types.TransactionStatusPending), approved.Status)
+		})
+	}
+}
+
+func TestCreateTransaction_Velocity(t *testing.T) {
+	store := newRiskStore(t)
+	service := newRiskService(t, store, false, risk.Velocity{
+		Limit: 3, Window: time.Minute, Decision: types.RiskDecisionDeny, Counter: store.Transactions(),
+	})
+	ctx := context.Background()
+
+	for _, key := range []string{"debit-1", "debit-2"} {
+		_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+			WalletID: "wallet-1", Amount: 10, Type: "debit", IdempotencyKey: key,
+		})
+		require.NoError(t, err)
+	}
+
+	// Along with the funding credit, the owner made three transactions within the window.
+	_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 10, Type: "debit", IdempotencyKey: "debit-3",
+	})
+	require.ErrorIs(t, err, services.ErrTransactionDenied)
+}
+
+// slowCounter widens the window between counting the transactions of an owner and creating the next one.
+type slowCounter struct {
+	risk.TransactionCounter
+}
+
+func (c slowCounter) CountByOwnerSince(ctx context.Context, ownerID string, since time.Time) (int, error) {
+	defer time.Sleep(10 * time.Millisecond)
+
+	return c.TransactionCounter.CountByOwnerSince(ctx, ownerID, since)
+}
+
+func TestCreateTransaction_VelocityAcrossWallets(t *testing.T) {
+	store := newRiskStore(t)
+	service := newRiskService(t, store, false, risk.Velocity{
+		Limit: 3, Window: time.Minute, Decision: types.RiskDecisionDeny,
+		Counter: slowCounter{TransactionCounter: store.Transactions()},
+	})
+	ctx := context.Background()
+
+	_, err := store.Wallets().Create(ctx, models.Wallet{
+		ID: "wallet-2", OwnerID: "owner-1", Currency: "EUR", Status: string(types.WalletStatusActive), Version: 1,
+	})
+	require.NoError(t, err)
+
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int32
+	)
+
+	// Along with the funding credit, the owner may make two more transactions, on whichever wallet.
+	for i := range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+				WalletID: []string{"wallet-1", "wallet-2"}[i%2], Amount: 10, Type: "credit",
+				IdempotencyKey: fmt.Sprintf("credit-%d", i),
+			})
+			if err == nil {
+				created.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(2), created.Load())
+}
+
+func TestCreateTransaction_VelocityIgnoresSystemPostings(t *testing.T) {
+	clock := &clock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	store := memory.New(clock.Now)
+	ctx := context.Background()
+
+	_, err := store.Wallets().Create(ctx, models.Wallet{
+		ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Status: string(types.WalletStatusActive), Version: 1,
+	})
+	require.NoError(t, err)
+
+	expiresAt := clock.now.Add(time.Hour)
+
+	for _, credit := range []models.Transaction{
+		{ID: "cash-1", Amount: 1000, Bucket: string(types.BalanceBucketCash)},
+		{ID: "promo-1", Amount: 10, Bucket: string(types.BalanceBucketPromo), ExpiresAt: &expiresAt},
+		{ID: "promo-2", Amount: 10, Bucket: string(types.BalanceBucketPromo), ExpiresAt: &expiresAt},
+		{ID: "promo-3", Amount: 10, Bucket: string(types.BalanceBucketPromo), ExpiresAt: &expiresAt},
+	} {
+		credit.WalletID = "wallet-1"
+		credit.Type = string(types.TransactionTypeCredit)
+		credit.Status = string(types.TransactionStatusCompleted)
+		credit.Version = 1
+
+		_, err := store.Transactions().Create(ctx, credit)
+		require.NoError(t, err)
+	}
+
+	service := transactions.NewService(store.Wallets(), store.Transactions(), store.Cache(), store.Locker(),
+		clock.Now,
+		transactions.WithBuckets(transactions.BucketPolicy{}, store.Transactions()),
+		transactions.WithRisk(risk.NewEngine(risk.Velocity{
+			Limit: 2, Window: time.Hour, Decision: types.RiskDecisionDeny, Counter: store.Transactions(),
+		}), store.Risk()))
+
+	// The credits are out of the window by the time they expire.
+	clock.now = clock.now.Add(2 * time.Hour)
+
+	expired, err := service.ExpireCredits(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, expired)
+
+	_, err = service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 10, Type: "debit", IdempotencyKey: "debit-1",
+	})
+	require.NoError(t, err, "the write-offs do not count towards the velocity limit")
+}
+
+func TestCreateTransaction_SystemPostingsSkipRisk(t *testing.T) {
+	store := newRiskStore(t)
+	service := newRiskService(t, store, false, risk.Blocklist{
+		Decision: types.RiskDecisionDeny, IDs: map[string]bool{"owner-1": true},
+	})
+	ctx := context.Background()
+
+	_, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 10, Type: "credit", IdempotencyKey: "refund-1",
+	})
+	require.ErrorIs(t, err, services.ErrTransactionDenied)
+
+	transaction, err := service.CreateTransaction(ctx, models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 10, Type: "credit", IdempotencyKey: "refund-2", System: true,
+	})
+	require.NoError(t, err, "system postings are not screened")
+	assert.Equal(t, string(types.TransactionStatusPending), transaction.Status)
+
+	decisions, _, err := service.ListRiskDecisions(ctx, models.QueryRiskDecisions{})
+	require.NoError(t, err)
+	require.Len(t, decisions, 1, "only the screened transaction has a decision")
+	assert.Equal(t, "refund-1", decisions[0].IdempotencyKey)
+}
+
+func TestCreateTransaction_RiskRuleFails(t *testing.T) {
+	store := newRiskStore(t)
+	service := newRiskService(t, store, false, failingRule{})
+
+	_, err := service.CreateTransaction(context.Background(), models.CreateTransactionRequest{
+		WalletID: "wallet-1", Amount: 10, Type: "debit", IdempotencyKey: "debit-1",
+	})
+	require.ErrorIs(t, err, services.ErrUnavailable, "a failing rule does not let the transaction through")
+	assert.Len(t, store.Snapshot().Transactions, 1)
+	assert.Empty(t, store.Snapshot().RiskDecisions)
+}
@@ -2,26 +2,34 @@ package transactions
 
 import (
 	"context"
-	"log"
 
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
 	"go.uber.org/zap"
 )
 
-func (s *Service) RunningBalance(ctx context.Context, walletID string) (int, error) {
+func (s *Service) RunningBalance(ctx context.Context, walletID string) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.RunningBalance", tracing.WalletID(walletID))
+	defer func() { tracing.End(span, err) }()
+
 	balance, err := s.cache.GetBalance(ctx, walletID)
 	if err != nil {
-		return 0, err
+		return 0, services.Unavailable(err)
 	}
 
 	if balance != nil {
+		balanceCacheTotal.WithLabelValues("hit").Inc()
+
 		return *balance, nil
 	}
 
-	unlock, err := s.cache.Mutex(ctx, walletID)
+	balanceCacheTotal.WithLabelValues("miss").Inc()
+
+	unlock, err := s.locker.Lock(ctx, walletID)
 	if err != nil {
-		log.Println("error locking wallet:", zap.Error(err), zap.String("walletID", walletID))
+		s.log(ctx).Error("error locking wallet", zap.Error(err), zap.String("walletID", walletID))
 
-		return 0, err
+		return 0, services.Unavailable(err)
 	}
 
 	defer func() {
@@ -30,14 +38,14 @@ func (s *Service) RunningBalance(ctx context.Context, walletID string) (int, err
 
 	transactions, err := s.db.ListAllTransactions(ctx, walletID)
 	if err != nil {
-		log.Println("error listing all transactions:", zap.Error(err), zap.String("walletID", walletID))
+		s.log(ctx).Error("error listing all transactions", zap.Error(err), zap.String("walletID", walletID))
 
-		return 0, err
+		return 0, services.FromRepository(err, nil)
 	}
 
 	err = s.cache.SetBalance(ctx, walletID, transactions.Balance())
 	if err != nil {
-		log.Println("error setting balance in cache:", zap.Error(err), zap.String("walletID", walletID))
+		s.log(ctx).Warn("error setting balance in cache", zap.Error(err), zap.String("walletID", walletID))
 	}
 
 	return transactions.Balance(), nil
@@ -2,16 +2,25 @@ package transactions
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/fees"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/interest"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
-	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/dblib"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/risk"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/logging"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"go.uber.org/zap"
 )
 
 type transactionRepo interface {
-	dblib.TxManager
-
+	Tx(ctx context.Context, do func(ctx context.Context) error) error
 	Create(ctx context.Context, transaction models.Transaction) (models.Transaction, error)
 	GetByID(ctx context.Context, id string) (models.Transaction, error)
 	Update(ctx context.Context, transaction models.Transaction) (models.Transaction, error)
@@ -26,34 +35,180 @@ type walletRepo interface {
 type cacheClient interface {
 	GetBalance(ctx context.Context, walletID string) (*int, error)
 	SetBalance(ctx context.Context, walletID string, balance int) error
-	Mutex(ctx context.Context, key string) (func(context.Context) (bool, error), error)
 	GetIdempotentTransaction(ctx context.Context, idempotencyKey string) (*models.Transaction, error)
 	SetIdempotentTransaction(ctx context.Context, idempotencyKey string, transaction models.Transaction) error
 }
 
+type locker interface {
+	Lock(ctx context.Context, key string) (func(context.Context) error, error)
+}
+
+// fencer hands out fencing tokens of wallets and checks them when writing, so a holder whose wallet lock
+// expired mid-request cannot write once another holder took the lock.
+type fencer interface {
+	AdvanceFence(ctx context.Context, walletID string) (int64, error)
+	CheckFence(ctx context.Context, walletID string, token int64) error
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event events.Event) error
+}
+
 type Service struct {
 	walletRepo walletRepo
 	db         transactionRepo
 	cache      cacheClient
+	locker     locker
+	publisher  eventPublisher
+	fencer     fencer
+	logger     *zap.Logger
 	now        func() time.Time
+
+	approvalPolicy ApprovalPolicy
+	approvals      approvalRepo
+
+	feeSchedule fees.Schedule
+	linked      linkedRepo
+
+	bucketPolicy BucketPolicy
+	allocations  allocationRepo
+
+	interestSchedule interest.Schedule
+	interestWallets  walletLister
+	accruals         accrualRepo
+
+	riskEngine *risk.Engine
+	risk       riskRepo
 }
 
-func NewService(walletRepo walletRepo, db transactionRepo, cache cacheClient, now func() time.Time) *Service {
-	return &Service{
+// Option configures optional collaborators of the Service.
+type Option func(*Service)
+
+// WithLogger sets the logger. Nothing is logged by default.
+func WithLogger(logger *zap.Logger) Option {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithPublisher publishes an event after every transaction created and every status change.
+func WithPublisher(publisher eventPublisher) Option {
+	return func(s *Service) {
+		s.publisher = publisher
+	}
+}
+
+// WithFencing guards the writes made under a wallet lock with the wallet fencing token. The fencer must
+// share the transactions repository's database, as the token is checked in the same transaction.
+func WithFencing(fencer fencer) Option {
+	return func(s *Service) {
+		s.fencer = fencer
+	}
+}
+
+func NewService(walletRepo walletRepo, db transactionRepo, cache cacheClient, locker locker,
+	now func() time.Time, opts ...Option) *Service {
+	s := &Service{
 		walletRepo: walletRepo,
 		db:         db,
 		cache:      cache,
+		locker:     locker,
+		logger:     zap.NewNop(),
 		now:        now,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *Service) GetTransactionByID(ctx context.Context, id string) (models.Transaction, error) {
-	return s.db.GetByID(ctx, id)
+	transaction, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return models.Transaction{}, services.FromRepository(err, services.ErrTransactionNotFound)
+	}
+
+	return transaction, nil
 }
 
 func (s *Service) ListTransactions(
 	ctx context.Context,
 	query models.QueryTransactions,
 ) (models.Transactions, *pagination.Pagination, error) {
-	return s.db.List(ctx, query)
+	transactions, pagination, err := s.db.List(ctx, query)
+	if err != nil {
+		return nil, nil, services.FromRepository(err, nil)
+	}
+
+	return transactions, pagination, nil
+}
+
+// walletLock is a held lock on a wallet along with the fencing token taken when acquiring it.
+type walletLock struct {
+	walletID string
+	fence    int64
+	unlock   func(context.Context) error
+}
+
+// lockWallet locks the wallet and, when fencing is enabled, takes its next fencing token.
+func (s *Service) lockWallet(ctx context.Context, walletID string) (walletLock, error) {
+	unlock, err := s.locker.Lock(ctx, walletID)
+	if err != nil {
+		return walletLock{}, services.Unavailable(fmt.Errorf("failed to lock wallet: %w", err))
+	}
+
+	lock := walletLock{walletID: walletID, unlock: unlock}
+
+	if s.fencer == nil {
+		return lock, nil
+	}
+
+	lock.fence, err = s.fencer.AdvanceFence(ctx, walletID)
+	if err != nil {
+		_ = unlock(ctx)
+
+		return walletLock{}, services.FromRepository(err, services.ErrWalletNotFound)
+	}
+
+	return lock, nil
+}
+
+// fenced runs write in a database transaction that commits only if lock still carries the current
+// fencing token. Without fencing write runs as is.
+func (s *Service) fenced(ctx context.Context, lock walletLock, write func(ctx context.Context) error) error {
+	if s.fencer == nil {
+		return write(ctx)
+	}
+
+	return s.db.Tx(ctx, func(ctx context.Context) error {
+		if err := s.fencer.CheckFence(ctx, lock.walletID, lock.fence); err != nil {
+			if errors.Is(err, repositories.ErrStaleFence) {
+				rejectionsTotal.WithLabelValues(string(types.ErrorCodeLockLost)).Inc()
+			}
+
+			return services.FromRepository(err, services.ErrWalletNotFound)
+		}
+
+		return write(ctx)
+	})
+}
+
+// publish is best effort: the change is already committed, so a lost event must not fail the request.
+func (s *Service) publish(ctx context.Context, event events.Event) {
+	if s.publisher == nil {
+		return
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.log(ctx).Warn("error publishing transaction event",
+			zap.Error(err),
+			zap.String("type", event.Type.String()),
+			zap.String("transactionID", event.Transaction.ID))
+	}
+}
+
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.WithContext(ctx, s.logger)
 }
@@ -7,24 +7,27 @@ import (
 	"time"
 
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services/transactions/mocks"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestRunningBalance(t *testing.T) {
     tests := []struct {
         name            string
         walletID        string
-        mockSetup       func(*mocks.MockTransactionRepo, *mocks.MockCacheClient)
+        mockSetup       func(*mocks.MockTransactionRepo, *mocks.MockCacheClient, *mocks.MockLocker)
         expectedBalance int
         expectedError   string
     }{
         {
             name:     "balance from cache - cache hit",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 balance := 1200
                 c.On("GetBalance", mock.Anything, "wallet-123").Return(&balance, nil)
             },
@@ -33,13 +36,13 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "credit transactions only counted when completed",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Transactions: only completed credit should count
                 // Expected balance: 1000 (completed credit) + 0 (pending credit not counted) = 1000
@@ -57,13 +60,13 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "debit transactions counted when pending or completed, not failed",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Transactions: debit pending and completed should count, failed should not
                 // Expected balance: 2000 - 300 (completed debit) - 200 (pending debit) + 0 (failed debit not counted) = 1500
@@ -82,13 +85,13 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "mixed transaction types with various statuses",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Credits (only completed): 1000 + 800 = 1800
                 // Debits (pending + completed): 300 + 150 = 450
@@ -118,13 +121,13 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "zero balance with failed transactions",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // All transactions are failed or pending credits - should result in 0 balance
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{
@@ -141,7 +144,7 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "cache error - fallback to database calculation",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache error
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), errors.New("cache connection error"))
             },
@@ -150,13 +153,13 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "database error during transaction list",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Database error
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{}, errors.New("database connection error"))
@@ -166,25 +169,25 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "mutex lock error",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
-                // Mutex lock error
-                c.On("Mutex", mock.Anything, "wallet-123").Return(nil, errors.New("failed to acquire lock"))
+                // Lock error
+                l.On("Lock", mock.Anything, "wallet-123").Return(nil, errors.New("failed to acquire lock"))
             },
             expectedError: "failed to acquire lock",
         },
         {
             name:     "empty transaction list",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // No transactions
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{}, nil)
@@ -197,13 +200,13 @@ func TestRunningBalance(t *testing.T) {
         {
             name:     "cache set error - balance still returned",
             walletID: "wallet-123",
-            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Cache miss
                 c.On("GetBalance", mock.Anything, "wallet-123").Return((*int)(nil), nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Return transactions
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{
@@ -223,11 +226,12 @@ func TestRunningBalance(t *testing.T) {
             mockWalletRepo := mocks.NewMockWalletRepo(t)
             mockTransactionRepo := mocks.NewMockTransactionRepo(t)
             mockCache := mocks.NewMockCacheClient(t)
+            mockLocker := mocks.NewMockLocker(t)
 
-            tt.mockSetup(mockTransactionRepo, mockCache)
+            tt.mockSetup(mockTransactionRepo, mockCache, mockLocker)
 
             // Create service
-            service := NewService(mockWalletRepo, mockTransactionRepo, mockCache, time.Now)
+            service := NewService(mockWalletRepo, mockTransactionRepo, mockCache, mockLocker, time.Now)
 
             // Execute
             result, err := service.RunningBalance(context.Background(), tt.walletID)
@@ -250,8 +254,9 @@ func TestCreateTransaction(t *testing.T) {
     tests := []struct {
         name          string
         request       models.CreateTransactionRequest
-        mockSetup     func(*mocks.MockWalletRepo, *mocks.MockTransactionRepo, *mocks.MockCacheClient)
+        mockSetup     func(*mocks.MockWalletRepo, *mocks.MockTransactionRepo, *mocks.MockCacheClient, *mocks.MockLocker)
         expectedError string
+        expectedErrIs error
         expectSuccess bool
     }{
         {
@@ -262,10 +267,10 @@ func TestCreateTransaction(t *testing.T) {
                 Type:           string(types.TransactionTypeCredit),
                 IdempotencyKey: "idempotency-123",
             },
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock idempotency check
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "idempotency:idempotency-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-123").Return(unlockFunc, nil)
                 c.On("GetIdempotentTransaction", mock.Anything, "idempotency-123").Return((*models.Transaction)(nil), nil)
 
                 // Mock wallet retrieval - active wallet
@@ -276,7 +281,7 @@ func TestCreateTransaction(t *testing.T) {
                 wr.On("GetByID", mock.Anything, "wallet-123").Return(wallet, nil)
 
                 // Mock wallet lock
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock existing transactions (current balance: 500)
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{
@@ -313,10 +318,10 @@ func TestCreateTransaction(t *testing.T) {
                 Type:           string(types.TransactionTypeDebit),
                 IdempotencyKey: "idempotency-456",
             },
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock idempotency check
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "idempotency:idempotency-456").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-456").Return(unlockFunc, nil)
                 c.On("GetIdempotentTransaction", mock.Anything, "idempotency-456").Return((*models.Transaction)(nil), nil)
 
                 // Mock wallet retrieval - active wallet
@@ -327,7 +332,7 @@ func TestCreateTransaction(t *testing.T) {
                 wr.On("GetByID", mock.Anything, "wallet-123").Return(wallet, nil)
 
                 // Mock wallet lock
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock existing transactions (current balance: 1000)
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{
@@ -364,10 +369,10 @@ func TestCreateTransaction(t *testing.T) {
                 Type:           string(types.TransactionTypeDebit),
                 IdempotencyKey: "idempotency-789",
             },
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock idempotency check
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "idempotency:idempotency-789").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-789").Return(unlockFunc, nil)
                 c.On("GetIdempotentTransaction", mock.Anything, "idempotency-789").Return((*models.Transaction)(nil), nil)
 
                 // Mock wallet retrieval - active wallet
@@ -378,12 +383,13 @@ func TestCreateTransaction(t *testing.T) {
                 wr.On("GetByID", mock.Anything, "wallet-123").Return(wallet, nil)
 
                 // Mock wallet lock
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock existing transactions (current balance: 0)
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{}, nil)
             },
             expectedError: "insufficient funds",
+            expectedErrIs: services.ErrInsufficientFunds,
         },
         {
             name: "should not allow debit when balance is insufficient",
@@ -393,10 +399,10 @@ func TestCreateTransaction(t *testing.T) {
                 Type:           string(types.TransactionTypeDebit),
                 IdempotencyKey: "idempotency-999",
             },
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock idempotency check
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "idempotency:idempotency-999").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-999").Return(unlockFunc, nil)
                 c.On("GetIdempotentTransaction", mock.Anything, "idempotency-999").Return((*models.Transaction)(nil), nil)
 
                 // Mock wallet retrieval - active wallet
@@ -407,7 +413,7 @@ func TestCreateTransaction(t *testing.T) {
                 wr.On("GetByID", mock.Anything, "wallet-123").Return(wallet, nil)
 
                 // Mock wallet lock
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock existing transactions (current balance: 1000)
                 tr.On("ListAllTransactions", mock.Anything, "wallet-123").Return(models.Transactions{
@@ -415,6 +421,7 @@ func TestCreateTransaction(t *testing.T) {
                 }, nil)
             },
             expectedError: "insufficient funds",
+            expectedErrIs: services.ErrInsufficientFunds,
         },
         {
             name: "should not allow transactions on inactive wallets",
@@ -424,10 +431,10 @@ func TestCreateTransaction(t *testing.T) {
                 Type:           string(types.TransactionTypeCredit),
                 IdempotencyKey: "idempotency-inactive",
             },
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock idempotency check
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "idempotency:idempotency-inactive").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-inactive").Return(unlockFunc, nil)
                 c.On("GetIdempotentTransaction", mock.Anything, "idempotency-inactive").Return((*models.Transaction)(nil), nil)
 
                 // Mock wallet retrieval - inactive wallet
@@ -438,6 +445,7 @@ func TestCreateTransaction(t *testing.T) {
                 wr.On("GetByID", mock.Anything, "wallet-456").Return(wallet, nil)
             },
             expectedError: "cannot create transaction for non active wallets",
+            expectedErrIs: services.ErrWalletNotActive,
         },
         {
             name: "should not allow transactions on frozen wallets",
@@ -447,10 +455,10 @@ func TestCreateTransaction(t *testing.T) {
                 Type:           string(types.TransactionTypeCredit),
                 IdempotencyKey: "idempotency-frozen",
             },
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock idempotency check
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "idempotency:idempotency-frozen").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-frozen").Return(unlockFunc, nil)
                 c.On("GetIdempotentTransaction", mock.Anything, "idempotency-frozen").Return((*models.Transaction)(nil), nil)
 
                 // Mock wallet retrieval - frozen wallet
@@ -461,6 +469,43 @@ func TestCreateTransaction(t *testing.T) {
                 wr.On("GetByID", mock.Anything, "wallet-789").Return(wallet, nil)
             },
             expectedError: "cannot create transaction for non active wallets",
+            expectedErrIs: services.ErrWalletNotActive,
+        },
+        {
+            name: "missing wallet is reported as not found",
+            request: models.CreateTransactionRequest{
+                WalletID:       "wallet-missing",
+                Amount:         100,
+                Type:           string(types.TransactionTypeCredit),
+                IdempotencyKey: "idempotency-missing",
+            },
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-missing").Return(unlockFunc, nil)
+                c.On("GetIdempotentTransaction", mock.Anything, "idempotency-missing").Return((*models.Transaction)(nil), nil)
+
+                wr.On("GetByID", mock.Anything, "wallet-missing").Return(models.Wallet{}, gorm.ErrRecordNotFound)
+            },
+            expectedError: "wallet not found",
+            expectedErrIs: services.ErrWalletNotFound,
+        },
+        {
+            name: "database outage is reported as unavailable",
+            request: models.CreateTransactionRequest{
+                WalletID:       "wallet-123",
+                Amount:         100,
+                Type:           string(types.TransactionTypeCredit),
+                IdempotencyKey: "idempotency-outage",
+            },
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:idempotency-outage").Return(unlockFunc, nil)
+                c.On("GetIdempotentTransaction", mock.Anything, "idempotency-outage").Return((*models.Transaction)(nil), nil)
+
+                wr.On("GetByID", mock.Anything, "wallet-123").Return(models.Wallet{}, errors.New("dial tcp: connection refused"))
+            },
+            expectedError: "connection refused",
+            expectedErrIs: services.ErrUnavailable,
         },
         {
             name: "idempotency - return existing transaction",
@@ -470,10 +515,10 @@ func TestCreateTransaction(t *testing.T) {
                 Type:           string(types.TransactionTypeCredit),
                 IdempotencyKey: "existing-key",
             },
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock idempotency check - existing transaction found
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "idempotency:existing-key").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "idempotency:existing-key").Return(unlockFunc, nil)
                 
                 existingTransaction := &models.Transaction{
                     ID:       "existing-txn-123",
@@ -494,11 +539,12 @@ func TestCreateTransaction(t *testing.T) {
             mockWalletRepo := mocks.NewMockWalletRepo(t)
             mockTransactionRepo := mocks.NewMockTransactionRepo(t)
             mockCache := mocks.NewMockCacheClient(t)
+            mockLocker := mocks.NewMockLocker(t)
 
-            tt.mockSetup(mockWalletRepo, mockTransactionRepo, mockCache)
+            tt.mockSetup(mockWalletRepo, mockTransactionRepo, mockCache, mockLocker)
 
             // Create service
-            service := NewService(mockWalletRepo, mockTransactionRepo, mockCache, func() time.Time { return fixedTime })
+            service := NewService(mockWalletRepo, mockTransactionRepo, mockCache, mockLocker, func() time.Time { return fixedTime })
 
             // Execute
             result, err := service.CreateTransaction(context.Background(), tt.request)
@@ -507,6 +553,9 @@ func TestCreateTransaction(t *testing.T) {
             if tt.expectedError != "" {
                 assert.Error(t, err)
                 assert.Contains(t, err.Error(), tt.expectedError)
+                if tt.expectedErrIs != nil {
+                    assert.ErrorIs(t, err, tt.expectedErrIs)
+                }
                 assert.Empty(t, result.ID) // Should not return a valid transaction
             } else if tt.expectSuccess {
                 assert.NoError(t, err)
@@ -523,15 +572,17 @@ func TestUpdateTransactionStatus(t *testing.T) {
         name          string
         transactionID string
         newStatus     string
-        mockSetup     func(*mocks.MockWalletRepo, *mocks.MockTransactionRepo, *mocks.MockCacheClient)
+        version       *int
+        mockSetup     func(*mocks.MockWalletRepo, *mocks.MockTransactionRepo, *mocks.MockCacheClient, *mocks.MockLocker)
         expectedError string
+        expectedErrIs error
         expectSuccess bool
     }{
         {
             name:          "credit transaction completed - balance should be updated in cache",
             transactionID: "txn-123",
             newStatus:     string(types.TransactionStatusCompleted),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - pending credit transaction
                 transaction := models.Transaction{
                     ID:       "txn-123",
@@ -543,8 +594,8 @@ func TestUpdateTransactionStatus(t *testing.T) {
                 tr.On("GetByID", mock.Anything, "txn-123").Return(transaction, nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock database transaction
                 tr.On("Tx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -572,7 +623,7 @@ func TestUpdateTransactionStatus(t *testing.T) {
             name:          "debit transaction failed - balance should be updated in cache",
             transactionID: "txn-456",
             newStatus:     string(types.TransactionStatusFailed),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - pending debit transaction
                 transaction := models.Transaction{
                     ID:       "txn-456",
@@ -584,8 +635,8 @@ func TestUpdateTransactionStatus(t *testing.T) {
                 tr.On("GetByID", mock.Anything, "txn-456").Return(transaction, nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock database transaction
                 tr.On("Tx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -613,7 +664,7 @@ func TestUpdateTransactionStatus(t *testing.T) {
             name:          "credit transaction failed - balance should be updated in cache",
             transactionID: "txn-789",
             newStatus:     string(types.TransactionStatusFailed),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - pending credit transaction
                 transaction := models.Transaction{
                     ID:       "txn-789",
@@ -625,8 +676,8 @@ func TestUpdateTransactionStatus(t *testing.T) {
                 tr.On("GetByID", mock.Anything, "txn-789").Return(transaction, nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock database transaction
                 tr.On("Tx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -647,7 +698,7 @@ func TestUpdateTransactionStatus(t *testing.T) {
             name:          "no cache update when balance not in cache",
             transactionID: "txn-999",
             newStatus:     string(types.TransactionStatusCompleted),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - pending credit transaction
                 transaction := models.Transaction{
                     ID:       "txn-999",
@@ -659,8 +710,8 @@ func TestUpdateTransactionStatus(t *testing.T) {
                 tr.On("GetByID", mock.Anything, "txn-999").Return(transaction, nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock database transaction
                 tr.On("Tx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -686,7 +737,7 @@ func TestUpdateTransactionStatus(t *testing.T) {
             name:          "same status - no update needed",
             transactionID: "txn-same",
             newStatus:     string(types.TransactionStatusCompleted),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - already completed transaction
                 transaction := models.Transaction{
                     ID:       "txn-same",
@@ -705,7 +756,7 @@ func TestUpdateTransactionStatus(t *testing.T) {
             name:          "invalid status transition",
             transactionID: "txn-invalid",
             newStatus:     string(types.TransactionStatusPending),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - completed transaction
                 transaction := models.Transaction{
                     ID:       "txn-invalid",
@@ -717,26 +768,86 @@ func TestUpdateTransactionStatus(t *testing.T) {
                 tr.On("GetByID", mock.Anything, "txn-invalid").Return(transaction, nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
             },
             expectedError: "invalid status transition from completed to pending",
+            expectedErrIs: services.ErrInvalidTransition,
+        },
+        {
+            name:          "stale If-Match version is rejected",
+            transactionID: "txn-stale",
+            newStatus:     string(types.TransactionStatusCompleted),
+            version:       intPtr(1),
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
+                // Transaction was already updated once since the client read it
+                transaction := models.Transaction{
+                    ID:       "txn-stale",
+                    WalletID: "wallet-123",
+                    Amount:   1000,
+                    Type:     string(types.TransactionTypeCredit),
+                    Status:   string(types.TransactionStatusPending),
+                    Version:  2,
+                }
+                tr.On("GetByID", mock.Anything, "txn-stale").Return(transaction, nil)
+            },
+            expectedError: repositories.ErrVersionConflict.Error(),
+        },
+        {
+            name:          "concurrent update detected by repository",
+            transactionID: "txn-race",
+            newStatus:     string(types.TransactionStatusFailed),
+            version:       intPtr(1),
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
+                transaction := models.Transaction{
+                    ID:       "txn-race",
+                    WalletID: "wallet-123",
+                    Amount:   300,
+                    Type:     string(types.TransactionTypeDebit),
+                    Status:   string(types.TransactionStatusPending),
+                    Version:  1,
+                }
+                tr.On("GetByID", mock.Anything, "txn-race").Return(transaction, nil)
+
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+
+                var txErr error
+                tr.On("Tx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+                    fn := args.Get(1).(func(context.Context) error)
+                    txErr = fn(context.Background())
+                }).Return(func(context.Context, func(context.Context) error) error { return txErr })
+
+                // Another writer bumped the version between the read and the write
+                tr.On("Update", mock.Anything, mock.Anything).Return(models.Transaction{}, repositories.ErrVersionConflict)
+            },
+            expectedError: repositories.ErrVersionConflict.Error(),
         },
         {
             name:          "transaction not found",
             transactionID: "txn-not-found",
             newStatus:     string(types.TransactionStatusCompleted),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - not found
                 tr.On("GetByID", mock.Anything, "txn-not-found").Return(models.Transaction{}, errors.New("transaction not found"))
             },
             expectedError: "transaction not found",
         },
+        {
+            name:          "missing transaction is reported as not found",
+            transactionID: "txn-missing",
+            newStatus:     string(types.TransactionStatusCompleted),
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
+                tr.On("GetByID", mock.Anything, "txn-missing").Return(models.Transaction{}, gorm.ErrRecordNotFound)
+            },
+            expectedError: "transaction not found",
+            expectedErrIs: services.ErrTransactionNotFound,
+        },
         {
             name:          "debit transaction completed - no cache update needed",
             transactionID: "txn-debit-completed",
             newStatus:     string(types.TransactionStatusCompleted),
-            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient) {
+            mockSetup: func(wr *mocks.MockWalletRepo, tr *mocks.MockTransactionRepo, c *mocks.MockCacheClient, l *mocks.MockLocker) {
                 // Mock transaction retrieval - pending debit transaction
                 transaction := models.Transaction{
                     ID:       "txn-debit-completed",
@@ -748,8 +859,8 @@ func TestUpdateTransactionStatus(t *testing.T) {
                 tr.On("GetByID", mock.Anything, "txn-debit-completed").Return(transaction, nil)
 
                 // Mock wallet lock
-                unlockFunc := func(ctx context.Context) (bool, error) { return true, nil }
-                c.On("Mutex", mock.Anything, "wallet-123").Return(unlockFunc, nil)
+                unlockFunc := func(ctx context.Context) error { return nil }
+                l.On("Lock", mock.Anything, "wallet-123").Return(unlockFunc, nil)
 
                 // Mock database transaction
                 tr.On("Tx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
@@ -774,19 +885,23 @@ func TestUpdateTransactionStatus(t *testing.T) {
             mockWalletRepo := mocks.NewMockWalletRepo(t)
             mockTransactionRepo := mocks.NewMockTransactionRepo(t)
             mockCache := mocks.NewMockCacheClient(t)
+            mockLocker := mocks.NewMockLocker(t)
 
-            tt.mockSetup(mockWalletRepo, mockTransactionRepo, mockCache)
+            tt.mockSetup(mockWalletRepo, mockTransactionRepo, mockCache, mockLocker)
 
             // Create service
-            service := NewService(mockWalletRepo, mockTransactionRepo, mockCache, time.Now)
+            service := NewService(mockWalletRepo, mockTransactionRepo, mockCache, mockLocker, time.Now)
 
             // Execute
-            result, err := service.UpdateTransactionStatus(context.Background(), tt.transactionID, tt.newStatus)
+            result, err := service.UpdateTransactionStatus(context.Background(), tt.transactionID, tt.newStatus, tt.version)
 
             // Assert
             if tt.expectedError != "" {
                 assert.Error(t, err)
                 assert.Contains(t, err.Error(), tt.expectedError)
+                if tt.expectedErrIs != nil {
+                    assert.ErrorIs(t, err, tt.expectedErrIs)
+                }
             } else if tt.expectSuccess {
                 assert.NoError(t, err)
                 assert.Equal(t, tt.transactionID, result.ID)
@@ -797,3 +912,7 @@ func TestUpdateTransactionStatus(t *testing.T) {
         })
     }
 }
+
+func intPtr(i int) *int {
+    return &i
+}
@@ -3,63 +3,199 @@ package transactions
 import (
 	"context"
 	"fmt"
-	"log"
+	"slices"
 
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/events"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 	"github.com/looplab/fsm"
 	"go.uber.org/zap"
 )
 
-func (s *Service) UpdateTransactionStatus(ctx context.Context, id string, status string) (models.Transaction, error) {
+// UpdateTransactionStatus moves the transaction to the given status. When expectedVersion is set the update is
+// rejected with repositories.ErrVersionConflict unless it matches the current transaction version.
+func (s *Service) UpdateTransactionStatus(ctx context.Context, id string, status string, expectedVersion *int) (
+	_ models.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.UpdateTransactionStatus", tracing.TransactionID(id))
+	defer func() { tracing.End(span, err) }()
+
 	transaction, err := s.db.GetByID(ctx, id)
 	if err != nil {
-		return models.Transaction{}, err
+		return models.Transaction{}, services.FromRepository(err, services.ErrTransactionNotFound)
+	}
+
+	span.SetAttributes(tracing.WalletID(transaction.WalletID))
+
+	if expectedVersion != nil && *expectedVersion != transaction.Version {
+		return models.Transaction{}, repositories.ErrVersionConflict
 	}
 
 	if transaction.Status == status {
 		return transaction, nil
 	}
 
-	unlock, err := s.cache.Mutex(ctx, transaction.WalletID)
+	if transaction.ParentID != nil {
+		return models.Transaction{}, services.ErrLinkedTransaction
+	}
+
+	// Transactions awaiting approval only move on through ApproveTransaction and RejectTransaction.
+	if transaction.Status == string(types.TransactionStatusAwaitingApproval) {
+		return models.Transaction{}, services.ErrApprovalRequired
+	}
+
+	lock, err := s.lockWallet(ctx, transaction.WalletID)
 	if err != nil {
-		return models.Transaction{}, fmt.Errorf("failed to lock wallet: %w", err)
+		return models.Transaction{}, err
 	}
-	defer unlock(ctx)
+	defer lock.unlock(ctx)
 
 	newStatus := fsm.NewFSM(transaction.Status, models.TransactionStates, nil)
 	if newStatus.Cannot(status) {
-		return models.Transaction{}, fmt.Errorf("invalid status transition from %s to %s", transaction.Status, status)
+		return models.Transaction{}, fmt.Errorf("%w from %s to %s", services.ErrInvalidTransition, transaction.Status, status)
 	}
 
-	return s.updateStatus(ctx, transaction, status)
+	return s.updateStatus(ctx, lock, transaction, status, nil)
 }
 
+// updateStatus moves the transaction, and the transactions linked to it, to status. also, when set, is
+// written in the same database transaction as the status change.
 func (s *Service) updateStatus(
 	ctx context.Context,
+	lock walletLock,
 	transaction models.Transaction,
 	status string,
+	also func(ctx context.Context) error,
 ) (models.Transaction, error) {
-	var (
-		updatedTransaction models.Transaction
-		err                error
-	)
+	linked, err := s.listLinked(ctx, transaction.ID)
+	if err != nil {
+		return models.Transaction{}, err
+	}
 
-	if err := s.db.Tx(ctx, func(ctx context.Context) error {
-		prevStatus := transaction.Status
-		transaction.Status = status
+	// Linked transactions of other wallets change their balances too, so their locks are held as well.
+	linkedLocks, err := s.lockLinkedWallets(ctx, lock.walletID, linked)
+	if err != nil {
+		return models.Transaction{}, err
+	}
 
-		updatedTransaction, err = s.db.Update(ctx, transaction)
-		if err != nil {
-			return err
+	defer func() {
+		for _, linkedLock := range linkedLocks {
+			linkedLock.unlock(ctx)
 		}
+	}()
+
+	current := append(models.Transactions{transaction}, linked...)
+
+	var updated models.Transactions
+
+	write := func(ctx context.Context) error {
+		return s.db.Tx(ctx, func(ctx context.Context) error {
+			updated = make(models.Transactions, 0, len(current))
+
+			for _, next := range current {
+				if fsm.NewFSM(next.Status, models.TransactionStates, nil).Cannot(status) {
+					return fmt.Errorf("%w of linked transaction %s from %s to %s",
+						services.ErrInvalidTransition, next.ID, next.Status, status)
+				}
+
+				next.Status = status
+
+				updatedTransaction, err := s.db.Update(ctx, next)
+				if err != nil {
+					return services.FromRepository(err, services.ErrTransactionNotFound)
+				}
+
+				updated = append(updated, updatedTransaction)
+			}
+
+			if also != nil {
+				if err := also(ctx); err != nil {
+					return err
+				}
+			}
 
-		return s.refreshCacheAfterTransactionUpdate(ctx, updatedTransaction, prevStatus)
-	}); err != nil {
+			for i, updatedTransaction := range updated {
+				if err := s.refreshCacheAfterTransactionUpdate(ctx, updatedTransaction, current[i].Status); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	for _, linkedLock := range linkedLocks {
+		inner := write
+		write = func(ctx context.Context) error { return s.fenced(ctx, linkedLock, inner) }
+	}
+
+	if err := s.fenced(ctx, lock, write); err != nil {
 		return models.Transaction{}, err
 	}
 
-	return updatedTransaction, nil
+	for _, updatedTransaction := range updated {
+		transactionsTotal.WithLabelValues(updatedTransaction.Type, updatedTransaction.Status).Inc()
+
+		if s.publisher != nil {
+			balance := s.balanceForEvent(ctx, updatedTransaction.WalletID)
+			s.publish(ctx, events.TransactionStatusUpdated(updatedTransaction, balance, s.now()))
+		}
+	}
+
+	return updated[0], nil
+}
+
+// lockLinkedWallets locks the wallets of linked transactions other than the locked one, in ID order.
+func (s *Service) lockLinkedWallets(ctx context.Context, lockedWalletID string, linked models.Transactions) (
+	[]walletLock, error) {
+	var walletIDs []string
+
+	for _, linkedTransaction := range linked {
+		if linkedTransaction.WalletID != lockedWalletID && !slices.Contains(walletIDs, linkedTransaction.WalletID) {
+			walletIDs = append(walletIDs, linkedTransaction.WalletID)
+		}
+	}
+
+	slices.Sort(walletIDs)
+
+	walletLocks := make([]walletLock, 0, len(walletIDs))
+
+	for _, walletID := range walletIDs {
+		lock, err := s.lockWallet(ctx, walletID)
+		if err != nil {
+			for _, held := range walletLocks {
+				held.unlock(ctx)
+			}
+
+			return nil, err
+		}
+
+		walletLocks = append(walletLocks, lock)
+	}
+
+	return walletLocks, nil
+}
+
+// balanceForEvent reads the balance of a wallet whose lock is held, for the event reporting a change.
+// Events are best effort, so nil is returned when the balance cannot be read.
+func (s *Service) balanceForEvent(ctx context.Context, walletID string) *int {
+	balance, err := s.cache.GetBalance(ctx, walletID)
+	if err == nil && balance != nil {
+		return balance
+	}
+
+	ledger, err := s.db.ListAllTransactions(ctx, walletID)
+	if err != nil {
+		s.log(ctx).Warn("error listing all transactions", zap.Error(err), zap.String("walletID", walletID))
+
+		return nil
+	}
+
+	current := ledger.Balance()
+
+	return &current
 }
 
 func (s *Service) refreshCacheAfterTransactionUpdate(
@@ -73,12 +209,12 @@ func (s *Service) refreshCacheAfterTransactionUpdate(
 
 	balance, err := s.cache.GetBalance(ctx, transaction.WalletID)
 	if err != nil {
-		log.Println("error getting balance from cache:",
+		s.log(ctx).Error("error getting balance from cache",
 			zap.Error(err),
 			zap.String("walletID", transaction.WalletID),
 			zap.Any("transaction", transaction))
 
-		return err
+		return services.Unavailable(err)
 	}
 
 	//no need to refresh if balance is not in cache.
@@ -93,34 +229,38 @@ func (s *Service) refreshCacheAfterTransactionUpdate(
 	)
 
 	if err := s.cache.SetBalance(ctx, transaction.WalletID, newBalance); err != nil {
-		log.Println("error setting balance in cache:",
+		s.log(ctx).Error("error setting balance in cache",
 			zap.Error(err),
 			zap.String("walletID", transaction.WalletID),
 			zap.Any("transaction", transaction))
 
-		return err
+		return services.Unavailable(err)
 	}
 
 	return nil
 }
 
 func shouldUpdateBalanceCache(transaction models.Transaction, previousStatus string) bool {
-    if transaction.Status == previousStatus {
-        return false
-    }
-
-    if transaction.Type == string(types.TransactionTypeDebit) && 
-        transaction.Status == string(types.TransactionStatusFailed) {
-        return true
-    }
-
-    if transaction.Type == string(types.TransactionTypeCredit) &&
-        previousStatus == string(types.TransactionStatusPending) &&
-        transaction.Status == string(types.TransactionStatusCompleted) {
-        return true
-    }
-
-    return false
+	if transaction.Status == previousStatus {
+		return false
+	}
+
+	if transaction.Type == string(types.TransactionTypeDebit) && releasesFunds(transaction.Status) {
+		return true
+	}
+
+	if transaction.Type == string(types.TransactionTypeCredit) &&
+		previousStatus == string(types.TransactionStatusPending) &&
+		transaction.Status == string(types.TransactionStatusCompleted) {
+		return true
+	}
+
+	return false
+}
+
+// releasesFunds reports whether a debit moving to status no longer holds the funds it reserved.
+func releasesFunds(status string) bool {
+	return status == string(types.TransactionStatusFailed) || status == string(types.TransactionStatusRejected)
 }
 
 func computeNewBalanceAfterTransactionStatusUpdate(
@@ -128,7 +268,7 @@ func computeNewBalanceAfterTransactionStatusUpdate(
 	transaction models.Transaction,
 	currentBalance int,
 ) int {
-	if status == string(types.TransactionStatusFailed) {
+	if releasesFunds(status) {
 		if transaction.Type == string(types.TransactionTypeDebit) {
 			return currentBalance + transaction.Amount
 		}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	models "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,6 +14,36 @@ type MockTransactionService struct {
 	mock.Mock
 }
 
+// BalanceBuckets provides a mock function with given fields: ctx, walletID
+func (_m *MockTransactionService) BalanceBuckets(ctx context.Context, walletID string) (models.BalanceBuckets, error) {
+	ret := _m.Called(ctx, walletID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BalanceBuckets")
+	}
+
+	var r0 models.BalanceBuckets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.BalanceBuckets, error)); ok {
+		return rf(ctx, walletID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.BalanceBuckets); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.BalanceBuckets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RunningBalance provides a mock function with given fields: ctx, walletID
 func (_m *MockTransactionService) RunningBalance(ctx context.Context, walletID string) (int, error) {
 	ret := _m.Called(ctx, walletID)
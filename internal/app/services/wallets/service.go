@@ -6,8 +6,12 @@ import (
 	"time"
 
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/tracing"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"gorm.io/gorm"
 )
 
 type walletDB interface {
@@ -19,6 +23,7 @@ type walletDB interface {
 
 type transactionService interface {
 	RunningBalance(ctx context.Context, walletID string) (int, error)
+	BalanceBuckets(ctx context.Context, walletID string) (models.BalanceBuckets, error)
 }
 
 type cache interface {
@@ -42,13 +47,28 @@ func NewService(transactionService transactionService, db walletDB, cache cache,
 }
 
 func (s *Service) GetWalletByID(ctx context.Context, id string) (models.Wallet, error) {
-	return s.db.GetByID(ctx, id)
+	wallet, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return models.Wallet{}, services.FromRepository(err, services.ErrWalletNotFound)
+	}
+
+	return wallet, nil
 }
 
-func (s *Service) UpdateWalletStatus(ctx context.Context, id, status string) (models.Wallet, error) {
+// UpdateWalletStatus changes the wallet status. When expectedVersion is set the update is rejected with
+// repositories.ErrVersionConflict unless it matches the current wallet version.
+func (s *Service) UpdateWalletStatus(ctx context.Context, id, status string, expectedVersion *int) (
+	_ models.Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "wallets.UpdateWalletStatus", tracing.WalletID(id))
+	defer func() { tracing.End(span, err) }()
+
 	wallet, err := s.db.GetByID(ctx, id)
 	if err != nil {
-		return models.Wallet{}, err
+		return models.Wallet{}, services.FromRepository(err, services.ErrWalletNotFound)
+	}
+
+	if expectedVersion != nil && *expectedVersion != wallet.Version {
+		return models.Wallet{}, repositories.ErrVersionConflict
 	}
 
 	if wallet.Status == status {
@@ -57,40 +77,69 @@ func (s *Service) UpdateWalletStatus(ctx context.Context, id, status string) (mo
 
 	wallet.Status = status
 
-	return s.db.Update(ctx, wallet)
+	wallet, err = s.db.Update(ctx, wallet)
+	if err != nil {
+		return models.Wallet{}, services.FromRepository(err, services.ErrWalletNotFound)
+	}
+
+	return wallet, nil
 }
 
 func (s *Service) ListWallets(ctx context.Context, query models.QueryWallets) (
 	models.Wallets,
 	*pagination.Pagination, error,
 ) {
-	return s.db.List(ctx, query)
+	wallets, pagination, err := s.db.List(ctx, query)
+	if err != nil {
+		return nil, nil, services.FromRepository(err, nil)
+	}
+
+	return wallets, pagination, nil
 }
 
-func (s *Service) CreateWallet(ctx context.Context, req models.CreateWalletRequest) (models.Wallet, error) {
+func (s *Service) CreateWallet(ctx context.Context, req models.CreateWalletRequest) (_ models.Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "wallets.CreateWallet")
+	defer func() { tracing.End(span, err) }()
+
 	list, _, err := s.db.List(ctx, models.QueryWallets{
 		OwnerIDs:   []string{req.OwnerID},
 		Currencies: []string{req.Currency},
+		Products:   []string{req.Product},
 	})
 	if err != nil {
-		return models.Wallet{}, err
+		return models.Wallet{}, services.FromRepository(err, nil)
 	}
 
 	if len(list) > 0 {
-		return models.Wallet{}, errors.New("wallet already exists for this owner and currency")
+		return models.Wallet{}, services.ErrDuplicateWallet
 	}
 
 	newID := ulid.GenerateID(s.now())
 	wallet := req.ToWallet()
 	wallet.ID = newID
 
-	return s.db.Create(ctx, wallet)
+	span.SetAttributes(tracing.WalletID(newID))
+
+	wallet, err = s.db.Create(ctx, wallet)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// Lost a race with a concurrent create for the same owner, currency and product.
+		return models.Wallet{}, services.ErrDuplicateWallet
+	}
+
+	if err != nil {
+		return models.Wallet{}, services.FromRepository(err, nil)
+	}
+
+	return wallet, nil
 }
 
-func (s *Service) GetWalletWithBalance(ctx context.Context, id string) (models.Wallet, error) {
+func (s *Service) GetWalletWithBalance(ctx context.Context, id string) (_ models.Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "wallets.GetWalletWithBalance", tracing.WalletID(id))
+	defer func() { tracing.End(span, err) }()
+
 	wallet, err := s.db.GetByID(ctx, id)
 	if err != nil {
-		return models.Wallet{}, err
+		return models.Wallet{}, services.FromRepository(err, services.ErrWalletNotFound)
 	}
 
 	balance, err := s.transactionService.RunningBalance(ctx, id)
@@ -98,7 +147,13 @@ func (s *Service) GetWalletWithBalance(ctx context.Context, id string) (models.W
 		return models.Wallet{}, err
 	}
 
+	buckets, err := s.transactionService.BalanceBuckets(ctx, id)
+	if err != nil {
+		return models.Wallet{}, err
+	}
+
 	wallet.Balance = &balance
+	wallet.Buckets = buckets
 
 	return wallet, nil
 }
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"gorm.io/gorm"
+)
+
+func (r *TransactionRepository) CreateAllocation(ctx context.Context, allocation models.TransactionAllocation) (
+	models.TransactionAllocation, error) {
+	if err := r.store.failed(); err != nil {
+		return models.TransactionAllocation{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Mirrors the foreign keys on transaction_id and credit_id.
+	if _, found := r.store.transactions[allocation.TransactionID]; !found {
+		return models.TransactionAllocation{}, gorm.ErrForeignKeyViolated
+	}
+
+	if allocation.CreditID != nil {
+		if _, found := r.store.transactions[*allocation.CreditID]; !found {
+			return models.TransactionAllocation{}, gorm.ErrForeignKeyViolated
+		}
+	}
+
+	if allocation.CreatedAt.IsZero() {
+		allocation.CreatedAt = r.store.now()
+	}
+
+	allocations := r.store.allocations[allocation.WalletID]
+	r.store.allocations[allocation.WalletID] = append(slices.Clip(allocations), allocation)
+	record(ctx, func() { r.store.allocations[allocation.WalletID] = allocations })
+
+	return allocation, nil
+}
+
+func (r *TransactionRepository) ListAllocations(_ context.Context, walletID string) (
+	models.TransactionAllocations, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return slices.Clone(r.store.allocations[walletID]), nil
+}
+
+func (r *TransactionRepository) ListExpiredCredits(_ context.Context, at time.Time, limit int) (
+	models.Transactions, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	taken := map[string]int{}
+
+	for _, allocations := range r.store.allocations {
+		for _, allocation := range allocations {
+			debit := r.store.transactions[allocation.TransactionID]
+			if allocation.CreditID != nil &&
+				debit.Status != string(types.TransactionStatusFailed) &&
+				debit.Status != string(types.TransactionStatusRejected) {
+				taken[*allocation.CreditID] += allocation.Amount
+			}
+		}
+	}
+
+	var expired models.Transactions
+
+	for _, transaction := range r.store.transactions {
+		if transaction.Type == string(types.TransactionTypeCredit) &&
+			transaction.Status == string(types.TransactionStatusCompleted) &&
+			transaction.ExpiresAt != nil && !transaction.ExpiresAt.After(at) &&
+			transaction.Amount > taken[transaction.ID] {
+			expired = append(expired, transaction)
+		}
+	}
+
+	slices.SortFunc(expired, func(a, b models.Transaction) int {
+		return cmp.Or(a.ExpiresAt.Compare(*b.ExpiresAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return expired[:min(limit, len(expired))], nil
+}
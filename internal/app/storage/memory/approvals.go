@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"gorm.io/gorm"
+)
+
+type ApprovalRepository struct {
+	store *Store
+}
+
+func (r *ApprovalRepository) Create(ctx context.Context, approval models.TransactionApproval) (
+	models.TransactionApproval, error) {
+	if err := r.store.failed(); err != nil {
+		return models.TransactionApproval{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Mirrors the foreign key on transaction_id.
+	if _, found := r.store.transactions[approval.TransactionID]; !found {
+		return models.TransactionApproval{}, gorm.ErrForeignKeyViolated
+	}
+
+	if approval.CreatedAt.IsZero() {
+		approval.CreatedAt = r.store.now()
+	}
+
+	trail := r.store.approvals[approval.TransactionID]
+	r.store.approvals[approval.TransactionID] = append(slices.Clip(trail), approval)
+	record(ctx, func() { r.store.approvals[approval.TransactionID] = trail })
+
+	return approval, nil
+}
+
+func (r *ApprovalRepository) ListByTransaction(_ context.Context, transactionID string) (
+	models.TransactionApprovals, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return slices.Clone(r.store.approvals[transactionID]), nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+)
+
+// Cache mirrors the Redis cache: balances and idempotent transactions.
+type Cache struct {
+	store *Store
+}
+
+func (c *Cache) GetBalance(_ context.Context, walletID string) (*int, error) {
+	if err := c.store.failed(); err != nil {
+		return nil, err
+	}
+
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	balance, found := c.store.balances[walletID]
+	if !found {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &balance, nil
+}
+
+func (c *Cache) SetBalance(_ context.Context, walletID string, balance int) error {
+	if err := c.store.failed(); err != nil {
+		return err
+	}
+
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	c.store.balances[walletID] = balance
+
+	return nil
+}
+
+func (c *Cache) GetIdempotentTransaction(_ context.Context, idempotencyKey string) (*models.Transaction, error) {
+	if err := c.store.failed(); err != nil {
+		return nil, err
+	}
+
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	transaction, found := c.store.idempotency[idempotencyKey]
+	if !found {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &transaction, nil
+}
+
+func (c *Cache) SetIdempotentTransaction(
+	_ context.Context,
+	idempotencyKey string,
+	transaction models.Transaction,
+) error {
+	if err := c.store.failed(); err != nil {
+		return err
+	}
+
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	c.store.idempotency[idempotencyKey] = transaction
+
+	return nil
+}
+
+// Locker locks keys within the process, failing like the other stores while a failure is set.
+type Locker struct {
+	store *Store
+}
+
+func (l *Locker) Lock(ctx context.Context, key string) (func(context.Context) error, error) {
+	if err := l.store.failed(); err != nil {
+		return nil, err
+	}
+
+	return l.store.locks.Lock(ctx, key)
+}
@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"gorm.io/gorm"
+)
+
+type DisputeRepository struct {
+	store *Store
+}
+
+func (r *DisputeRepository) Create(ctx context.Context, dispute models.Dispute) (models.Dispute, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Dispute{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, found := r.store.disputes[dispute.ID]; found {
+		return models.Dispute{}, gorm.ErrDuplicatedKey
+	}
+
+	// Mirrors the unique constraint on transaction_id.
+	for _, existing := range r.store.disputes {
+		if existing.TransactionID == dispute.TransactionID {
+			return models.Dispute{}, gorm.ErrDuplicatedKey
+		}
+	}
+
+	if err := r.checkReferences(dispute); err != nil {
+		return models.Dispute{}, err
+	}
+
+	now := r.store.now()
+	dispute.CreatedAt = now
+	dispute.UpdatedAt = now
+	dispute.Notes = nil
+
+	r.store.disputes[dispute.ID] = dispute
+	record(ctx, func() { delete(r.store.disputes, dispute.ID) })
+
+	return dispute, nil
+}
+
+// checkReferences mirrors the foreign keys of the disputes table. The store lock must be held.
+func (r *DisputeRepository) checkReferences(dispute models.Dispute) error {
+	if _, found := r.store.wallets[dispute.WalletID]; !found {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	for _, id := range []*string{&dispute.TransactionID, dispute.ProvisionalCreditID, dispute.SettlementID} {
+		if id == nil {
+			continue
+		}
+
+		if _, found := r.store.transactions[*id]; !found {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+
+	return nil
+}
+
+func (r *DisputeRepository) GetByID(_ context.Context, id string) (models.Dispute, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Dispute{}, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	dispute, found := r.store.disputes[id]
+	if !found {
+		return models.Dispute{}, gorm.ErrRecordNotFound
+	}
+
+	return dispute, nil
+}
+
+func (r *DisputeRepository) GetByTransactionID(_ context.Context, transactionID string) (models.Dispute, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Dispute{}, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, dispute := range r.store.disputes {
+		if dispute.TransactionID == transactionID {
+			return dispute, nil
+		}
+	}
+
+	return models.Dispute{}, gorm.ErrRecordNotFound
+}
+
+func (r *DisputeRepository) Update(ctx context.Context, dispute models.Dispute) (models.Dispute, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Dispute{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, found := r.store.disputes[dispute.ID]
+	if !found || stored.Version != dispute.Version {
+		return models.Dispute{}, repositories.ErrVersionConflict
+	}
+
+	if err := r.checkReferences(dispute); err != nil {
+		return models.Dispute{}, err
+	}
+
+	dispute.Version++
+	dispute.UpdatedAt = r.store.now()
+	dispute.Notes = nil
+
+	r.store.disputes[dispute.ID] = dispute
+	record(ctx, func() { r.store.disputes[stored.ID] = stored })
+
+	return dispute, nil
+}
+
+func (r *DisputeRepository) List(_ context.Context, query models.QueryDisputes) (
+	[]models.Dispute, *pagination.Pagination, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, nil, err
+	}
+
+	r.store.mu.RLock()
+	disputes := slices.Collect(maps.Values(r.store.disputes))
+	r.store.mu.RUnlock()
+
+	disputes = slices.DeleteFunc(disputes, func(dispute models.Dispute) bool {
+		return !matchesDispute(dispute, query)
+	})
+
+	if query.IsCursor() {
+		return findByCursor(disputes, query.Paginator, repositories.IDKeysetOrder,
+			func(a, b models.Dispute) int { return cmp.Compare(a.ID, b.ID) },
+			func(dispute models.Dispute) pagination.Cursor { return pagination.Cursor{ID: dispute.ID} },
+			func(cursor pagination.Cursor) (models.Dispute, error) { return models.Dispute{ID: cursor.ID}, nil })
+	}
+
+	slices.SortFunc(disputes, func(a, b models.Dispute) int { return cmp.Compare(a.ID, b.ID) })
+
+	return paginate(disputes, query.Paginator)
+}
+
+func matchesDispute(dispute models.Dispute, query models.QueryDisputes) bool {
+	if len(query.WalletIDs) > 0 && !slices.Contains(query.WalletIDs, dispute.WalletID) {
+		return false
+	}
+
+	if len(query.TransactionIDs) > 0 && !slices.Contains(query.TransactionIDs, dispute.TransactionID) {
+		return false
+	}
+
+	if len(query.Statuses) > 0 && !slices.Contains(query.Statuses, dispute.Status) {
+		return false
+	}
+
+	if query.DueBefore != nil && !dispute.DueAt.Before(*query.DueBefore) {
+		return false
+	}
+
+	return true
+}
+
+func (r *DisputeRepository) CreateNote(ctx context.Context, note models.DisputeNote) (models.DisputeNote, error) {
+	if err := r.store.failed(); err != nil {
+		return models.DisputeNote{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Mirrors the foreign key on dispute_id.
+	if _, found := r.store.disputes[note.DisputeID]; !found {
+		return models.DisputeNote{}, gorm.ErrForeignKeyViolated
+	}
+
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = r.store.now()
+	}
+
+	notes := r.store.disputeNotes[note.DisputeID]
+	r.store.disputeNotes[note.DisputeID] = append(slices.Clone(notes), note)
+	record(ctx, func() { r.store.disputeNotes[note.DisputeID] = notes })
+
+	return note, nil
+}
+
+// ListNotes returns the notes of a dispute, oldest first.
+func (r *DisputeRepository) ListNotes(_ context.Context, disputeID string) (models.DisputeNotes, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	notes := slices.Clone(r.store.disputeNotes[disputeID])
+	r.store.mu.RUnlock()
+
+	slices.SortStableFunc(notes, compareNotes)
+
+	return notes, nil
+}
+
+func compareNotes(a, b models.DisputeNote) int {
+	return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+}
@@ -0,0 +1,113 @@
+package memory_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func clock() time.Time {
+	return now
+}
+
+// populate fills store with a row of every kind it snapshots.
+func populate(t *testing.T, store *memory.Store) {
+	t.Helper()
+
+	ctx := t.Context()
+	txnID := "txn-1"
+
+	_, err := store.Wallets().Create(ctx, models.Wallet{
+		ID: "wallet-1", OwnerID: "owner-1", Currency: "USD", Product: "savings", Status: "active", Version: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Transactions().Create(ctx, models.Transaction{
+		ID: txnID, WalletID: "wallet-1", Amount: 500, Type: "credit", Status: "completed",
+		Bucket: "cash", Version: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Transactions().CreateAllocation(ctx, models.TransactionAllocation{
+		ID: "alloc-1", TransactionID: txnID, WalletID: "wallet-1", Bucket: "cash", Amount: 500, CreatedAt: now,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Approvals().Create(ctx, models.TransactionApproval{
+		ID: "approval-1", TransactionID: txnID, Action: "submitted", ActorID: "maker", CreatedAt: now,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Interest().CreateAccrual(ctx, models.InterestAccrual{
+		WalletID: "wallet-1", AccruedOn: "2026-01-01", Balance: 500, AnnualBasisPoints: 365, Amount: 1,
+		CreatedAt: now,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Disputes().Create(ctx, models.Dispute{
+		ID: "dispute-1", TransactionID: txnID, WalletID: "wallet-1", Amount: 500, Reason: "fraud",
+		Status: "open", DueAt: now.Add(time.Hour), Version: 1, CreatedAt: now, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Disputes().CreateNote(ctx, models.DisputeNote{
+		ID: "note-1", DisputeID: "dispute-1", ActorID: "agent", Note: "receipt attached", CreatedAt: now,
+	})
+	require.NoError(t, err)
+
+	_, err = store.Risk().Create(ctx, models.RiskDecision{
+		ID: "decision-1", WalletID: "wallet-1", TransactionID: &txnID, IdempotencyKey: "key-1", Type: "credit",
+		Amount: 500, Decision: "review", CreatedAt: now,
+		Hits: models.RiskRuleHits{{ID: "hit-1", Rule: "amount_threshold", Decision: "review", Reason: "large"}},
+	})
+	require.NoError(t, err)
+}
+
+func TestStore_SaveAndLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallet.json")
+
+	saved := memory.New(clock)
+	populate(t, saved)
+	require.NoError(t, saved.SaveFile(path))
+
+	loaded := memory.New(clock)
+	require.NoError(t, loaded.LoadFile(path))
+
+	snapshot := saved.Snapshot()
+	assert.Equal(t, snapshot, loaded.Snapshot())
+	assert.Len(t, snapshot.Wallets, 1)
+	assert.Len(t, snapshot.Transactions, 1)
+	assert.Len(t, snapshot.Approvals, 1)
+	assert.Len(t, snapshot.Allocations, 1)
+	assert.Len(t, snapshot.InterestAccruals, 1)
+	assert.Len(t, snapshot.Disputes, 1)
+	assert.Len(t, snapshot.DisputeNotes, 1)
+	assert.Len(t, snapshot.RiskDecisions, 1)
+	assert.Len(t, snapshot.RiskRuleHits, 1)
+
+	transaction, err := loaded.Transactions().GetByID(t.Context(), "txn-1")
+	require.NoError(t, err)
+	assert.Equal(t, 500, transaction.Amount, "the reloaded store serves its repositories")
+}
+
+func TestStore_LoadFileMissing(t *testing.T) {
+	store := memory.New(clock)
+	require.NoError(t, store.LoadFile(filepath.Join(t.TempDir(), "missing.json")))
+
+	assert.Equal(t, memory.Snapshot{}, store.Snapshot())
+}
+
+func TestStore_LoadFileCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallet.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	require.Error(t, memory.New(clock).LoadFile(path))
+}
@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"gorm.io/gorm"
+)
+
+type InterestRepository struct {
+	store *Store
+}
+
+func (r *InterestRepository) CreateAccrual(ctx context.Context, accrual models.InterestAccrual) (
+	models.InterestAccrual, error) {
+	if err := r.store.failed(); err != nil {
+		return models.InterestAccrual{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Mirrors the foreign keys on wallet_id and transaction_id.
+	if _, found := r.store.wallets[accrual.WalletID]; !found {
+		return models.InterestAccrual{}, gorm.ErrForeignKeyViolated
+	}
+
+	if accrual.TransactionID != nil {
+		if _, found := r.store.transactions[*accrual.TransactionID]; !found {
+			return models.InterestAccrual{}, gorm.ErrForeignKeyViolated
+		}
+	}
+
+	accruals := r.store.accruals[accrual.WalletID]
+
+	// Mirrors the (wallet_id, accrued_on) primary key.
+	i, found := slices.BinarySearchFunc(accruals, accrual.AccruedOn, func(a models.InterestAccrual, day string) int {
+		return cmp.Compare(a.AccruedOn, day)
+	})
+	if found {
+		return models.InterestAccrual{}, gorm.ErrDuplicatedKey
+	}
+
+	if accrual.CreatedAt.IsZero() {
+		accrual.CreatedAt = r.store.now()
+	}
+
+	r.store.accruals[accrual.WalletID] = slices.Insert(slices.Clone(accruals), i, accrual)
+	record(ctx, func() { r.store.accruals[accrual.WalletID] = accruals })
+
+	return accrual, nil
+}
+
+func (r *InterestRepository) LastAccrual(_ context.Context, walletID, before string) (
+	models.InterestAccrual, error) {
+	if err := r.store.failed(); err != nil {
+		return models.InterestAccrual{}, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	accruals := r.store.accruals[walletID]
+
+	for i := len(accruals) - 1; i >= 0; i-- {
+		if accruals[i].AccruedOn < before {
+			return accruals[i], nil
+		}
+	}
+
+	return models.InterestAccrual{}, gorm.ErrRecordNotFound
+}
+
+func (r *InterestRepository) ListUnpostedWallets(_ context.Context, from, to string) ([]string, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var walletIDs []string
+
+	for walletID, accruals := range r.store.accruals {
+		if slices.ContainsFunc(accruals, func(accrual models.InterestAccrual) bool {
+			return unposted(accrual, from, to)
+		}) {
+			walletIDs = append(walletIDs, walletID)
+		}
+	}
+
+	slices.Sort(walletIDs)
+
+	return walletIDs, nil
+}
+
+func (r *InterestRepository) ListUnpostedAccruals(_ context.Context, walletID, from, to string) (
+	models.InterestAccruals, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var accruals models.InterestAccruals
+
+	for _, accrual := range r.store.accruals[walletID] {
+		if unposted(accrual, from, to) {
+			accruals = append(accruals, accrual)
+		}
+	}
+
+	return accruals, nil
+}
+
+func (r *InterestRepository) MarkAccrualsPosted(ctx context.Context, walletID string, days []string,
+	transactionID string) error {
+	if err := r.store.failed(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Mirrors the foreign key on transaction_id.
+	if _, found := r.store.transactions[transactionID]; !found {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	accruals := r.store.accruals[walletID]
+	posted := slices.Clone(accruals)
+
+	for i, accrual := range posted {
+		if accrual.TransactionID == nil && slices.Contains(days, accrual.AccruedOn) {
+			posted[i].TransactionID = &transactionID
+		}
+	}
+
+	r.store.accruals[walletID] = posted
+	record(ctx, func() { r.store.accruals[walletID] = accruals })
+
+	return nil
+}
+
+func unposted(accrual models.InterestAccrual, from, to string) bool {
+	return accrual.AccruedOn >= from && accrual.AccruedOn < to && accrual.Amount > 0 && accrual.TransactionID == nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+)
+
+// paginate returns the requested page of items, which must already be in listing order.
+func paginate[T any](items []T, paginator pagination.Paginator) ([]T, *pagination.Pagination, error) {
+	paginator = repositories.GetPaginator(paginator)
+	perPage := min(*paginator.PerPage, repositories.MaxPerPage)
+
+	start := min((*paginator.Page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	page := items[start:end]
+
+	return page, pagination.NewPagination(*paginator.Page, len(page), len(items), perPage), nil
+}
+
+// findByCursor is the in-memory counterpart of repositories.FindByCursor. compare orders items ascending
+// by the keyset columns, and fromCursor rebuilds an item holding the key values of a cursor.
+func findByCursor[T any](
+	items []T,
+	paginator pagination.Paginator,
+	order repositories.KeysetOrder,
+	compare func(a, b T) int,
+	cursorOf func(T) pagination.Cursor,
+	fromCursor func(pagination.Cursor) (T, error),
+) ([]T, *pagination.Pagination, error) {
+	limit := repositories.CursorLimit(paginator)
+
+	cursor, err := repositories.ParseCursor(paginator, order)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inOrder := compare
+	if order.Descending {
+		inOrder = func(a, b T) int { return compare(b, a) }
+	}
+
+	slices.SortFunc(items, inOrder)
+
+	backwards := paginator.Before != nil
+
+	if cursor != nil {
+		pivot, err := fromCursor(*cursor)
+		if err != nil {
+			return nil, nil, pagination.ErrInvalidCursor
+		}
+
+		items = slices.DeleteFunc(items, func(item T) bool {
+			if backwards {
+				return inOrder(item, pivot) >= 0
+			}
+
+			return inOrder(item, pivot) <= 0
+		})
+	}
+
+	var page []T
+	if backwards {
+		page = items[max(len(items)-limit-1, 0):]
+	} else {
+		page = items[:min(limit+1, len(items))]
+	}
+
+	hasMore := len(page) > limit
+	if hasMore && backwards {
+		page = page[1:]
+	} else if hasMore {
+		page = page[:limit]
+	}
+
+	return page, repositories.CursorPagination(page, cursorOf, paginator, order, limit, hasMore), nil
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"gorm.io/gorm"
+)
+
+type RiskRepository struct {
+	store *Store
+}
+
+// Create stores a decision along with its hits.
+func (r *RiskRepository) Create(ctx context.Context, decision models.RiskDecision) (models.RiskDecision, error) {
+	if err := r.store.failed(); err != nil {
+		return models.RiskDecision{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, found := r.store.riskDecisions[decision.ID]; found {
+		return models.RiskDecision{}, gorm.ErrDuplicatedKey
+	}
+
+	// Mirrors the foreign keys of the risk_decisions table.
+	if _, found := r.store.wallets[decision.WalletID]; !found {
+		return models.RiskDecision{}, gorm.ErrForeignKeyViolated
+	}
+
+	if decision.TransactionID != nil {
+		if _, found := r.store.transactions[*decision.TransactionID]; !found {
+			return models.RiskDecision{}, gorm.ErrForeignKeyViolated
+		}
+	}
+
+	decision.CreatedAt = r.store.now()
+	decision.Hits = slices.Clone(decision.Hits)
+
+	for i := range decision.Hits {
+		decision.Hits[i].DecisionID = decision.ID
+	}
+
+	stored := decision
+	stored.Hits = nil
+
+	r.store.riskDecisions[decision.ID] = stored
+	r.store.riskHits[decision.ID] = decision.Hits
+	record(ctx, func() {
+		delete(r.store.riskDecisions, decision.ID)
+		delete(r.store.riskHits, decision.ID)
+	})
+
+	return decision, nil
+}
+
+// List returns decisions along with their hits.
+func (r *RiskRepository) List(_ context.Context, query models.QueryRiskDecisions) (
+	models.RiskDecisions, *pagination.Pagination, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	decisions := slices.Collect(maps.Values(r.store.riskDecisions))
+	decisions = slices.DeleteFunc(decisions, func(decision models.RiskDecision) bool {
+		return !matchesRiskDecision(decision, query)
+	})
+
+	for i := range decisions {
+		decisions[i].Hits = slices.Clone(r.store.riskHits[decisions[i].ID])
+		slices.SortFunc(decisions[i].Hits, func(a, b models.RiskRuleHit) int { return cmp.Compare(a.Rule, b.Rule) })
+	}
+
+	if query.IsCursor() {
+		return findByCursor(decisions, query.Paginator, repositories.IDKeysetOrder,
+			func(a, b models.RiskDecision) int { return cmp.Compare(a.ID, b.ID) },
+			func(decision models.RiskDecision) pagination.Cursor { return pagination.Cursor{ID: decision.ID} },
+			func(cursor pagination.Cursor) (models.RiskDecision, error) {
+				return models.RiskDecision{ID: cursor.ID}, nil
+			})
+	}
+
+	slices.SortFunc(decisions, func(a, b models.RiskDecision) int { return cmp.Compare(a.ID, b.ID) })
+
+	return paginate(decisions, query.Paginator)
+}
+
+func matchesRiskDecision(decision models.RiskDecision, query models.QueryRiskDecisions) bool {
+	if len(query.WalletIDs) > 0 && !slices.Contains(query.WalletIDs, decision.WalletID) {
+		return false
+	}
+
+	if len(query.TransactionIDs) > 0 &&
+		(decision.TransactionID == nil || !slices.Contains(query.TransactionIDs, *decision.TransactionID)) {
+		return false
+	}
+
+	if len(query.Decisions) > 0 && !slices.Contains(query.Decisions, decision.Decision) {
+		return false
+	}
+
+	return true
+}
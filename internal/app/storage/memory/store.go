@@ -0,0 +1,314 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/locks"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+)
+
+// Store keeps wallets, transactions and cached values in process memory. It backs the same services as
+// Postgres and Redis do, which makes it suitable for tests and local development.
+type Store struct {
+	mu           sync.RWMutex
+	wallets      map[string]models.Wallet
+	transactions map[string]models.Transaction
+	balances     map[string]int
+	idempotency  map[string]models.Transaction
+	approvals    map[string]models.TransactionApprovals
+	allocations  map[string]models.TransactionAllocations
+	accruals     map[string]models.InterestAccruals
+	disputes     map[string]models.Dispute
+	disputeNotes map[string]models.DisputeNotes
+	// riskDecisions are kept without their hits, which riskHits holds by decision ID.
+	riskDecisions map[string]models.RiskDecision
+	riskHits      map[string]models.RiskRuleHits
+	fences        map[string]int64
+	failure       error
+
+	locks      *locks.InProcess
+	fenceLocks *locks.InProcess
+	now        func() time.Time
+}
+
+func New(now func() time.Time) *Store {
+	return &Store{
+		wallets:       map[string]models.Wallet{},
+		transactions:  map[string]models.Transaction{},
+		balances:      map[string]int{},
+		idempotency:   map[string]models.Transaction{},
+		approvals:     map[string]models.TransactionApprovals{},
+		allocations:   map[string]models.TransactionAllocations{},
+		accruals:      map[string]models.InterestAccruals{},
+		disputes:      map[string]models.Dispute{},
+		disputeNotes:  map[string]models.DisputeNotes{},
+		riskDecisions: map[string]models.RiskDecision{},
+		riskHits:      map[string]models.RiskRuleHits{},
+		fences:        map[string]int64{},
+		locks:         locks.NewInProcess(),
+		fenceLocks:    locks.NewInProcess(),
+		now:           now,
+	}
+}
+
+// Wallets returns a wallet repository backed by the store.
+func (s *Store) Wallets() *WalletRepository {
+	return &WalletRepository{store: s}
+}
+
+// Transactions returns a transaction repository backed by the store.
+func (s *Store) Transactions() *TransactionRepository {
+	return &TransactionRepository{store: s}
+}
+
+// Approvals returns a transaction approval repository backed by the store.
+func (s *Store) Approvals() *ApprovalRepository {
+	return &ApprovalRepository{store: s}
+}
+
+// Interest returns an interest accrual repository backed by the store.
+func (s *Store) Interest() *InterestRepository {
+	return &InterestRepository{store: s}
+}
+
+// Disputes returns a dispute repository backed by the store.
+func (s *Store) Disputes() *DisputeRepository {
+	return &DisputeRepository{store: s}
+}
+
+// Risk returns a risk decision repository backed by the store.
+func (s *Store) Risk() *RiskRepository {
+	return &RiskRepository{store: s}
+}
+
+// Cache returns a balance and idempotency cache backed by the store.
+func (s *Store) Cache() *Cache {
+	return &Cache{store: s}
+}
+
+// Locker returns an in-process locker that fails along with the store.
+func (s *Store) Locker() *Locker {
+	return &Locker{store: s}
+}
+
+// SetFailure makes every repository and cache call fail with err, as if the backing database and
+// cache were down. Passing nil restores normal operation.
+func (s *Store) SetFailure(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failure = err
+}
+
+func (s *Store) failed() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.failure
+}
+
+// Tx runs do so that the writes it makes through the store's repositories are undone when it fails.
+// Nested calls join the outer transaction.
+func (s *Store) Tx(ctx context.Context, do func(ctx context.Context) error) error {
+	if _, found := ctx.Value(txKey{}).(*txJournal); found {
+		return do(ctx)
+	}
+
+	journal := &txJournal{}
+	defer journal.end()
+
+	if err := do(context.WithValue(ctx, txKey{}, journal)); err != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		journal.rollback()
+
+		return err
+	}
+
+	return nil
+}
+
+type txKey struct{}
+
+// txJournal records how to undo the writes made inside a transaction and the row locks it holds.
+type txJournal struct {
+	mu      sync.Mutex
+	undo    []func()
+	release []func()
+}
+
+// record registers undo for the write being made. The store lock must be held while calling it.
+func record(ctx context.Context, undo func()) {
+	journal, found := ctx.Value(txKey{}).(*txJournal)
+	if !found {
+		return
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	journal.undo = append(journal.undo, undo)
+}
+
+// holdUntilEnd keeps a lock until the transaction of ctx ends. Outside a transaction it is released
+// right away.
+func holdUntilEnd(ctx context.Context, release func()) {
+	journal, found := ctx.Value(txKey{}).(*txJournal)
+	if !found {
+		release()
+
+		return
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	journal.release = append(journal.release, release)
+}
+
+func (j *txJournal) end() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, release := range j.release {
+		release()
+	}
+}
+
+func (j *txJournal) rollback() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		j.undo[i]()
+	}
+}
+
+// Snapshot is a point-in-time copy of the stored wallets, transactions, approval trails, allocations,
+// interest accruals, disputes and risk decisions, ordered by ID.
+type Snapshot struct {
+	Wallets          []models.Wallet                `json:"wallets"`
+	Transactions     []models.Transaction           `json:"transactions"`
+	Approvals        []models.TransactionApproval   `json:"approvals,omitempty"`
+	Allocations      []models.TransactionAllocation `json:"allocations,omitempty"`
+	InterestAccruals []models.InterestAccrual       `json:"interest_accruals,omitempty"`
+	Disputes         []models.Dispute               `json:"disputes,omitempty"`
+	DisputeNotes     []models.DisputeNote           `json:"dispute_notes,omitempty"`
+	RiskDecisions    []models.RiskDecision          `json:"risk_decisions,omitempty"`
+	RiskRuleHits     []models.RiskRuleHit           `json:"risk_rule_hits,omitempty"`
+}
+
+func (s *Store) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := Snapshot{
+		Wallets:       slices.Collect(maps.Values(s.wallets)),
+		Transactions:  slices.Collect(maps.Values(s.transactions)),
+		Disputes:      slices.Collect(maps.Values(s.disputes)),
+		RiskDecisions: slices.Collect(maps.Values(s.riskDecisions)),
+	}
+
+	for _, trail := range s.approvals {
+		snapshot.Approvals = append(snapshot.Approvals, trail...)
+	}
+
+	for _, allocations := range s.allocations {
+		snapshot.Allocations = append(snapshot.Allocations, allocations...)
+	}
+
+	for _, accruals := range s.accruals {
+		snapshot.InterestAccruals = append(snapshot.InterestAccruals, accruals...)
+	}
+
+	for _, notes := range s.disputeNotes {
+		snapshot.DisputeNotes = append(snapshot.DisputeNotes, notes...)
+	}
+
+	for _, hits := range s.riskHits {
+		snapshot.RiskRuleHits = append(snapshot.RiskRuleHits, hits...)
+	}
+
+	slices.SortFunc(snapshot.Wallets, func(a, b models.Wallet) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.Transactions, func(a, b models.Transaction) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.Approvals, func(a, b models.TransactionApproval) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.Allocations, func(a, b models.TransactionAllocation) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	slices.SortFunc(snapshot.InterestAccruals, func(a, b models.InterestAccrual) int {
+		return cmp.Or(cmp.Compare(a.WalletID, b.WalletID), cmp.Compare(a.AccruedOn, b.AccruedOn))
+	})
+	slices.SortFunc(snapshot.Disputes, func(a, b models.Dispute) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.DisputeNotes, func(a, b models.DisputeNote) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.RiskDecisions, func(a, b models.RiskDecision) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.RiskRuleHits, func(a, b models.RiskRuleHit) int { return cmp.Compare(a.ID, b.ID) })
+
+	return snapshot
+}
+
+// Restore replaces the stored wallets, transactions, approval trails, allocations, interest accruals,
+// disputes and risk decisions with those of snapshot. Cached balances and idempotency keys are dropped,
+// as they are derived from the replaced data.
+func (s *Store) Restore(snapshot Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.wallets = make(map[string]models.Wallet, len(snapshot.Wallets))
+	for _, wallet := range snapshot.Wallets {
+		s.wallets[wallet.ID] = wallet
+	}
+
+	s.transactions = make(map[string]models.Transaction, len(snapshot.Transactions))
+	for _, transaction := range snapshot.Transactions {
+		s.transactions[transaction.ID] = transaction
+	}
+
+	s.approvals = map[string]models.TransactionApprovals{}
+	for _, approval := range snapshot.Approvals {
+		s.approvals[approval.TransactionID] = append(s.approvals[approval.TransactionID], approval)
+	}
+
+	s.allocations = map[string]models.TransactionAllocations{}
+	for _, allocation := range snapshot.Allocations {
+		s.allocations[allocation.WalletID] = append(s.allocations[allocation.WalletID], allocation)
+	}
+
+	s.accruals = map[string]models.InterestAccruals{}
+	for _, accrual := range snapshot.InterestAccruals {
+		s.accruals[accrual.WalletID] = append(s.accruals[accrual.WalletID], accrual)
+	}
+
+	// The accruals of a wallet are kept in day order.
+	for _, accruals := range s.accruals {
+		slices.SortFunc(accruals, func(a, b models.InterestAccrual) int { return cmp.Compare(a.AccruedOn, b.AccruedOn) })
+	}
+
+	s.disputes = make(map[string]models.Dispute, len(snapshot.Disputes))
+	for _, dispute := range snapshot.Disputes {
+		s.disputes[dispute.ID] = dispute
+	}
+
+	s.disputeNotes = map[string]models.DisputeNotes{}
+	for _, note := range snapshot.DisputeNotes {
+		s.disputeNotes[note.DisputeID] = append(s.disputeNotes[note.DisputeID], note)
+	}
+
+	s.riskDecisions = make(map[string]models.RiskDecision, len(snapshot.RiskDecisions))
+	for _, decision := range snapshot.RiskDecisions {
+		s.riskDecisions[decision.ID] = decision
+	}
+
+	s.riskHits = map[string]models.RiskRuleHits{}
+	for _, hit := range snapshot.RiskRuleHits {
+		s.riskHits[hit.DecisionID] = append(s.riskHits[hit.DecisionID], hit)
+	}
+
+	s.balances = map[string]int{}
+	s.idempotency = map[string]models.Transaction{}
+}
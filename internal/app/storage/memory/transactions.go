@@ -0,0 +1,277 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	transactionsRepo "github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories/transactions"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"gorm.io/gorm"
+)
+
+type TransactionRepository struct {
+	store *Store
+}
+
+func (r *TransactionRepository) Tx(ctx context.Context, do func(ctx context.Context) error) error {
+	return r.store.Tx(ctx, do)
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, transaction models.Transaction) (
+	models.Transaction, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Transaction{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, found := r.store.transactions[transaction.ID]; found {
+		return models.Transaction{}, gorm.ErrDuplicatedKey
+	}
+
+	// Mirrors the foreign key on wallet_id.
+	if _, found := r.store.wallets[transaction.WalletID]; !found {
+		return models.Transaction{}, gorm.ErrForeignKeyViolated
+	}
+
+	// And on parent_id.
+	if transaction.ParentID != nil {
+		if _, found := r.store.transactions[*transaction.ParentID]; !found {
+			return models.Transaction{}, gorm.ErrForeignKeyViolated
+		}
+	}
+
+	now := r.store.now()
+	transaction.CreatedAt = now
+	transaction.UpdatedAt = now
+
+	r.store.transactions[transaction.ID] = transaction
+	record(ctx, func() { delete(r.store.transactions, transaction.ID) })
+
+	return transaction, nil
+}
+
+func (r *TransactionRepository) GetByID(_ context.Context, id string) (models.Transaction, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Transaction{}, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	transaction, found := r.store.transactions[id]
+	if !found {
+		return models.Transaction{}, gorm.ErrRecordNotFound
+	}
+
+	return transaction, nil
+}
+
+func (r *TransactionRepository) Update(ctx context.Context, transaction models.Transaction) (
+	models.Transaction, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Transaction{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, found := r.store.transactions[transaction.ID]
+	if !found || stored.Version != transaction.Version {
+		return models.Transaction{}, repositories.ErrVersionConflict
+	}
+
+	transaction.Version++
+	transaction.UpdatedAt = r.store.now()
+
+	r.store.transactions[transaction.ID] = transaction
+	record(ctx, func() { r.store.transactions[stored.ID] = stored })
+
+	return transaction, nil
+}
+
+func (r *TransactionRepository) List(_ context.Context, query models.QueryTransactions) (
+	[]models.Transaction, *pagination.Pagination, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, nil, err
+	}
+
+	query.Sort = cmp.Or(query.Sort, types.TransactionSortCreatedAtDesc)
+	if !slices.Contains(types.GetTransactionSorts(), query.Sort) {
+		return nil, nil, fmt.Errorf("%w: %q", repositories.ErrInvalidSort, query.Sort)
+	}
+
+	transactions := r.all(func(transaction models.Transaction) bool {
+		return matchesTransaction(transaction, query)
+	})
+
+	order := transactionsRepo.KeysetOrder(query.Sort)
+	compare := compareTransactions(query.Sort.Column())
+
+	if query.IsCursor() {
+		return findByCursor(transactions, query.Paginator, order, compare, transactionsRepo.CursorFor(query.Sort),
+			func(cursor pagination.Cursor) (models.Transaction, error) {
+				return transactionFromCursor(cursor, order)
+			})
+	}
+
+	slices.SortFunc(transactions, func(a, b models.Transaction) int {
+		if order.Descending {
+			return compare(b, a)
+		}
+
+		return compare(a, b)
+	})
+
+	return paginate(transactions, query.Paginator)
+}
+
+func (r *TransactionRepository) ListAllTransactions(_ context.Context, walletID string) (
+	models.Transactions, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	transactions := r.all(func(transaction models.Transaction) bool {
+		return transaction.WalletID == walletID
+	})
+
+	slices.SortFunc(transactions, func(a, b models.Transaction) int {
+		return compareTransactions("created_at")(b, a)
+	})
+
+	return transactions, nil
+}
+
+func (r *TransactionRepository) ListLinked(_ context.Context, parentID string) (models.Transactions, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, err
+	}
+
+	transactions := r.all(func(transaction models.Transaction) bool {
+		return transaction.ParentID != nil && *transaction.ParentID == parentID
+	})
+
+	slices.SortFunc(transactions, compareTransactions("created_at"))
+
+	return transactions, nil
+}
+
+// CountByOwnerSince counts the transactions of the wallets of an owner created since a time. Linked
+// transactions, such as fees, are not counted.
+func (r *TransactionRepository) CountByOwnerSince(_ context.Context, ownerID string, since time.Time) (int, error) {
+	if err := r.store.failed(); err != nil {
+		return 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+
+	for _, transaction := range r.store.transactions {
+		if transaction.ParentID == nil && !transaction.System && !transaction.CreatedAt.Before(since) &&
+			r.store.wallets[transaction.WalletID].OwnerID == ownerID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *TransactionRepository) all(keep func(models.Transaction) bool) []models.Transaction {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	transactions := slices.Collect(maps.Values(r.store.transactions))
+
+	return slices.DeleteFunc(transactions, func(transaction models.Transaction) bool {
+		return !keep(transaction)
+	})
+}
+
+func matchesTransaction(transaction models.Transaction, query models.QueryTransactions) bool {
+	if len(query.IDs) > 0 && !slices.Contains(query.IDs, transaction.ID) {
+		return false
+	}
+
+	if len(query.WalletIDs) > 0 && !slices.Contains(query.WalletIDs, transaction.WalletID) {
+		return false
+	}
+
+	if len(query.Statuses) > 0 && !slices.Contains(query.Statuses, transaction.Status) {
+		return false
+	}
+
+	if len(query.Types) > 0 && !slices.Contains(query.Types, transaction.Type) {
+		return false
+	}
+
+	if !inTimeRange(transaction.CreatedAt, query.CreatedAtFrom, query.CreatedAtTo) ||
+		!inTimeRange(transaction.UpdatedAt, query.UpdatedAtFrom, query.UpdatedAtTo) {
+		return false
+	}
+
+	if (query.AmountMin != nil && transaction.Amount < *query.AmountMin) ||
+		(query.AmountMax != nil && transaction.Amount > *query.AmountMax) {
+		return false
+	}
+
+	if query.NoteContains != nil {
+		return transaction.Note != nil &&
+			strings.Contains(strings.ToLower(*transaction.Note), strings.ToLower(*query.NoteContains))
+	}
+
+	return true
+}
+
+func inTimeRange(value time.Time, from, to *time.Time) bool {
+	return (from == nil || !value.Before(*from)) && (to == nil || !value.After(*to))
+}
+
+// compareTransactions orders transactions ascending by column, then by ID, like the SQL order clauses.
+func compareTransactions(column string) func(a, b models.Transaction) int {
+	return func(a, b models.Transaction) int {
+		var byColumn int
+
+		switch column {
+		case "amount":
+			byColumn = cmp.Compare(a.Amount, b.Amount)
+		case "updated_at":
+			byColumn = a.UpdatedAt.Compare(b.UpdatedAt)
+		default:
+			byColumn = a.CreatedAt.Compare(b.CreatedAt)
+		}
+
+		return cmp.Or(byColumn, cmp.Compare(a.ID, b.ID))
+	}
+}
+
+func transactionFromCursor(cursor pagination.Cursor, order repositories.KeysetOrder) (models.Transaction, error) {
+	transaction := models.Transaction{ID: cursor.ID}
+
+	key, err := order.ParseKey(cursor.Key)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	switch order.Column {
+	case "amount":
+		transaction.Amount, _ = key.(int)
+	case "updated_at":
+		transaction.UpdatedAt, _ = key.(time.Time)
+	default:
+		transaction.CreatedAt, _ = key.(time.Time)
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"gorm.io/gorm"
+)
+
+type WalletRepository struct {
+	store *Store
+}
+
+func (r *WalletRepository) Create(ctx context.Context, wallet models.Wallet) (models.Wallet, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Wallet{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, found := r.store.wallets[wallet.ID]; found {
+		return models.Wallet{}, gorm.ErrDuplicatedKey
+	}
+
+	// Mirrors the unique (owner_id, currency, product) constraint.
+	for _, existing := range r.store.wallets {
+		if existing.OwnerID == wallet.OwnerID && existing.Currency == wallet.Currency &&
+			existing.Product == wallet.Product {
+			return models.Wallet{}, gorm.ErrDuplicatedKey
+		}
+	}
+
+	now := r.store.now()
+	wallet.CreatedAt = now
+	wallet.UpdatedAt = now
+	wallet.Balance = nil
+
+	r.store.wallets[wallet.ID] = wallet
+	record(ctx, func() { delete(r.store.wallets, wallet.ID) })
+
+	return wallet, nil
+}
+
+func (r *WalletRepository) GetByID(_ context.Context, id string) (models.Wallet, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Wallet{}, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	wallet, found := r.store.wallets[id]
+	if !found {
+		return models.Wallet{}, gorm.ErrRecordNotFound
+	}
+
+	return wallet, nil
+}
+
+func (r *WalletRepository) Update(ctx context.Context, wallet models.Wallet) (models.Wallet, error) {
+	if err := r.store.failed(); err != nil {
+		return models.Wallet{}, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, found := r.store.wallets[wallet.ID]
+	if !found || stored.Version != wallet.Version {
+		return models.Wallet{}, repositories.ErrVersionConflict
+	}
+
+	wallet.Version++
+	wallet.UpdatedAt = r.store.now()
+	wallet.Balance = nil
+
+	r.store.wallets[wallet.ID] = wallet
+	record(ctx, func() { r.store.wallets[stored.ID] = stored })
+
+	return wallet, nil
+}
+
+// AdvanceFence hands out the next fencing token of the wallet.
+func (r *WalletRepository) AdvanceFence(ctx context.Context, walletID string) (int64, error) {
+	if err := r.store.failed(); err != nil {
+		return 0, err
+	}
+
+	unlock, err := r.store.fenceLocks.Lock(ctx, walletID)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock(ctx)
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, found := r.store.wallets[walletID]; !found {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	r.store.fences[walletID]++
+
+	return r.store.fences[walletID], nil
+}
+
+// CheckFence fails with repositories.ErrStaleFence unless token is the current fencing token of the
+// wallet. Like the row lock taken in Postgres, a check inside a transaction keeps the token from being
+// advanced until the transaction ends.
+func (r *WalletRepository) CheckFence(ctx context.Context, walletID string, token int64) error {
+	if err := r.store.failed(); err != nil {
+		return err
+	}
+
+	unlock, err := r.store.fenceLocks.Lock(ctx, walletID)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.RLock()
+	current := r.store.fences[walletID]
+	r.store.mu.RUnlock()
+
+	if current != token {
+		_ = unlock(ctx)
+
+		return repositories.ErrStaleFence
+	}
+
+	holdUntilEnd(ctx, func() { _ = unlock(context.WithoutCancel(ctx)) })
+
+	return nil
+}
+
+func (r *WalletRepository) List(_ context.Context, query models.QueryWallets) (
+	[]models.Wallet, *pagination.Pagination, error) {
+	if err := r.store.failed(); err != nil {
+		return nil, nil, err
+	}
+
+	r.store.mu.RLock()
+	wallets := slices.Collect(maps.Values(r.store.wallets))
+	r.store.mu.RUnlock()
+
+	wallets = slices.DeleteFunc(wallets, func(wallet models.Wallet) bool {
+		return !matchesWallet(wallet, query)
+	})
+
+	if query.IsCursor() {
+		return findByCursor(wallets, query.Paginator, repositories.IDKeysetOrder,
+			func(a, b models.Wallet) int { return cmp.Compare(a.ID, b.ID) },
+			func(wallet models.Wallet) pagination.Cursor { return pagination.Cursor{ID: wallet.ID} },
+			func(cursor pagination.Cursor) (models.Wallet, error) { return models.Wallet{ID: cursor.ID}, nil })
+	}
+
+	slices.SortFunc(wallets, func(a, b models.Wallet) int { return cmp.Compare(a.ID, b.ID) })
+
+	return paginate(wallets, query.Paginator)
+}
+
+func matchesWallet(wallet models.Wallet, query models.QueryWallets) bool {
+	if len(query.IDs) > 0 && !slices.Contains(query.IDs, wallet.ID) {
+		return false
+	}
+
+	if len(query.OwnerIDs) > 0 && !slices.Contains(query.OwnerIDs, wallet.OwnerID) {
+		return false
+	}
+
+	if len(query.Currencies) > 0 && !slices.Contains(query.Currencies, wallet.Currency) {
+		return false
+	}
+
+	if len(query.Products) > 0 && !slices.Contains(query.Products, wallet.Product) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,29 @@
+// Package storage names the backends the wallets and transactions can be stored in.
+package storage
+
+type Backend string
+
+const (
+	// BackendDatabase stores data in the database of database.driver, Postgres or SQLite. Balances and
+	// idempotency keys are cached, events streamed and wallets locked through Redis when redis.url is set;
+	// a single SQLite instance does all of it in the process instead.
+	BackendDatabase Backend = "database"
+	// BackendPostgres is the former name of BackendDatabase, still accepted for it.
+	BackendPostgres Backend = "postgres"
+	// BackendMemory keeps everything in process memory, optionally persisted to a JSON snapshot file. It
+	// needs no external services and suits demos, local development and integration tests.
+	BackendMemory Backend = "memory"
+)
+
+func GetBackends() []Backend {
+	return []Backend{BackendDatabase, BackendMemory}
+}
+
+// Resolve returns the backend an alias stands for.
+func (b Backend) Resolve() Backend {
+	if b == BackendPostgres {
+		return BackendDatabase
+	}
+
+	return b
+}
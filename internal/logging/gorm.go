@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// slowQueryThreshold is the duration above which statements are logged as slow.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// GormLogger writes the logs of the repositories through zap. Failed and slow statements are always
+// logged; every statement is logged at debug level when the gorm log mode is Info.
+type GormLogger struct {
+	logger *zap.Logger
+	level  gormlogger.LogLevel
+}
+
+func NewGormLogger(logger *zap.Logger) *GormLogger {
+	return &GormLogger{
+		logger: logger.WithOptions(zap.WithCaller(false)),
+		level:  gormlogger.Warn,
+	}
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Info {
+		WithContext(ctx, l.logger).Info(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Warn {
+		WithContext(ctx, l.logger).Warn(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Error {
+		WithContext(ctx, l.logger).Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	source := zap.String("source", utils.FileWithLineNum())
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		sql, rows := fc()
+		WithContext(ctx, l.logger).Error("query failed",
+			zap.Error(err), zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed), source)
+	case elapsed > slowQueryThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		WithContext(ctx, l.logger).Warn("slow query",
+			zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed), source)
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		WithContext(ctx, l.logger).Debug("query",
+			zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed), source)
+	}
+}
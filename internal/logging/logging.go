@@ -0,0 +1,58 @@
+// Package logging builds the service logger and carries the request ID through contexts, so every log
+// line written while serving a request can be correlated with it.
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type requestIDKey struct{}
+
+// New returns a JSON logger writing at the given level: debug, info, warn or error. In debug mode
+// lines are printed in a human-readable format instead.
+func New(level string, debug bool) (*zap.Logger, error) {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+
+	cfg := zap.NewProductionConfig()
+	if debug {
+		cfg = zap.NewDevelopmentConfig()
+	}
+
+	cfg.Level = zap.NewAtomicLevelAt(lvl)
+
+	return cfg.Build()
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the ID of the request being served, or an empty string outside of requests.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+
+	return requestID
+}
+
+// WithContext annotates the logger with the request and trace IDs found in ctx.
+func WithContext(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	var fields []zap.Field
+
+	if requestID := RequestID(ctx); requestID != "" {
+		fields = append(fields, zap.String("requestID", requestID))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields, zap.String("traceID", spanContext.TraceID().String()))
+	}
+
+	return logger.With(fields...)
+}
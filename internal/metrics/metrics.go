@@ -0,0 +1,61 @@
+// Package metrics holds the Prometheus registry of the service. Packages declare their metrics with the
+// constructors below, which register them under the service namespace, and the registry is served on
+// /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "wallet"
+
+var registry = newRegistry()
+
+func newRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return r
+}
+
+// Registerer registers collectors that the constructors below do not cover.
+func Registerer() prometheus.Registerer {
+	return registry
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+func NewCounterVec(opts prometheus.CounterOpts, labels ...string) *prometheus.CounterVec {
+	opts.Namespace = namespace
+
+	return promauto.With(registry).NewCounterVec(opts, labels)
+}
+
+func NewHistogramVec(opts prometheus.HistogramOpts, labels ...string) *prometheus.HistogramVec {
+	opts.Namespace = namespace
+
+	return promauto.With(registry).NewHistogramVec(opts, labels)
+}
+
+func NewGaugeVec(opts prometheus.GaugeOpts, labels ...string) *prometheus.GaugeVec {
+	opts.Namespace = namespace
+
+	return promauto.With(registry).NewGaugeVec(opts, labels)
+}
+
+// RegisterDBStats exports the connection pool statistics of db.
+func RegisterDBStats(db *sql.DB) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, namespace))
+}
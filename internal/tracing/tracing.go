@@ -0,0 +1,114 @@
+// Package tracing sets up OpenTelemetry tracing and holds the helpers the service layer uses to
+// start spans.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/Shaheen-AlQaraghuli/wallet-go"
+
+type Exporter string
+
+const (
+	// ExporterNone records no spans. Trace context is still propagated.
+	ExporterNone Exporter = "none"
+	// ExporterStdout prints spans to stdout, for local debugging.
+	ExporterStdout Exporter = "stdout"
+	// ExporterOTLP sends spans to an OTLP gRPC collector configured with the standard
+	// OTEL_EXPORTER_OTLP_* environment variables.
+	ExporterOTLP Exporter = "otlp"
+)
+
+func GetExporters() []Exporter {
+	return []Exporter{ExporterNone, ExporterStdout, ExporterOTLP}
+}
+
+type Config struct {
+	Exporter    Exporter
+	ServiceName string
+	// SampleRatio is the share of new traces recorded, between 0 and 1. Traces started upstream keep
+	// the sampling decision of their parent.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator. The returned function
+// flushes pending spans and must be called on shutdown.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		exporter, err = otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the service layer.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func WalletID(id string) attribute.KeyValue {
+	return attribute.String("wallet.id", id)
+}
+
+func TransactionID(id string) attribute.KeyValue {
+	return attribute.String("transaction.id", id)
+}
+
+func TransactionType(transactionType string) attribute.KeyValue {
+	return attribute.String("transaction.type", transactionType)
+}
+
+func LockKey(key string) attribute.KeyValue {
+	return attribute.String("lock.key", key)
+}
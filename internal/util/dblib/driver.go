@@ -0,0 +1,87 @@
+package dblib
+
+import (
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type Driver string
+
+const (
+	DriverPostgres Driver = "postgres"
+	// DriverSQLite stores the data in a single file, for edge deployments and CI. It allows a single
+	// writer, so it is used through one connection.
+	DriverSQLite Driver = "sqlite"
+)
+
+func GetDrivers() []Driver {
+	return []Driver{DriverPostgres, DriverSQLite}
+}
+
+// sqlitePragmas are applied to SQLite connections whose DSN does not set them. Foreign keys are off in
+// SQLite unless enabled on each connection.
+var sqlitePragmas = []struct{ name, value string }{
+	{"foreign_keys", "1"},
+	{"busy_timeout", "5000"},
+	{"journal_mode", "WAL"},
+}
+
+// Open connects gorm to the database of driver at dsn.
+func Open(driver Driver, dsn string, config *gorm.Config) (*gorm.DB, error) {
+	if driver != DriverSQLite {
+		return gorm.Open(postgres.Open(dsn), config)
+	}
+
+	// SQLite compares timestamps as text, which only orders correctly when all of them are in UTC.
+	config.NowFunc = func() time.Time { return time.Now().UTC() }
+
+	db, err := gorm.Open(sqlite.Open(sqliteDSN(dsn)), config)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+func sqliteDSN(dsn string) string {
+	for _, pragma := range sqlitePragmas {
+		if strings.Contains(dsn, "_pragma="+pragma.name) {
+			continue
+		}
+
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+
+		dsn += separator + "_pragma=" + pragma.name + "(" + pragma.value + ")"
+	}
+
+	return dsn
+}
+
+// IsSQLite tells whether db is connected to SQLite, for the few statements whose SQL differs.
+func IsSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// TimeArg returns t as compared against timestamp columns. SQLite compares timestamps as text, which only
+// orders them correctly in the UTC they are stored in.
+func TimeArg(db *gorm.DB, t time.Time) time.Time {
+	if IsSQLite(db) {
+		return t.UTC()
+	}
+
+	return t
+}
@@ -0,0 +1,48 @@
+package dblib
+
+import (
+	"errors"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var dbErrors = metrics.NewCounterVec(prometheus.CounterOpts{
+	Name: "db_errors_total",
+	Help: "Failed database statements by operation.",
+}, "operation")
+
+// MetricsPlugin is a gorm plugin counting failed statements. Missing records are not failures.
+type MetricsPlugin struct{}
+
+func (MetricsPlugin) Name() string {
+	return "metrics"
+}
+
+func (MetricsPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	for _, err := range []error{
+		callbacks.Create().After("gorm:create").Register("metrics:create", countErrors("create")),
+		callbacks.Query().After("gorm:query").Register("metrics:query", countErrors("query")),
+		callbacks.Update().After("gorm:update").Register("metrics:update", countErrors("update")),
+		callbacks.Delete().After("gorm:delete").Register("metrics:delete", countErrors("delete")),
+		callbacks.Row().After("gorm:row").Register("metrics:row", countErrors("row")),
+		callbacks.Raw().After("gorm:raw").Register("metrics:raw", countErrors("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func countErrors(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			dbErrors.WithLabelValues(operation).Inc()
+		}
+	}
+}
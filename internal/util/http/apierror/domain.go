@@ -0,0 +1,63 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+type domainError struct {
+	target   error
+	httpCode int
+	code     types.ErrorCode
+}
+
+// domainErrors maps service errors to their HTTP representation. The first match wins.
+var domainErrors = []domainError{
+	{services.ErrWalletNotFound, http.StatusNotFound, types.ErrorCodeWalletNotFound},
+	{services.ErrTransactionNotFound, http.StatusNotFound, types.ErrorCodeTransactionNotFound},
+	{services.ErrInsufficientFunds, http.StatusUnprocessableEntity, types.ErrorCodeInsufficientFunds},
+	{services.ErrWalletNotActive, http.StatusUnprocessableEntity, types.ErrorCodeWalletNotActive},
+	{services.ErrInvalidTransition, http.StatusConflict, types.ErrorCodeInvalidTransition},
+	{services.ErrDuplicateWallet, http.StatusConflict, types.ErrorCodeDuplicateWallet},
+	{services.ErrApprovalRequired, http.StatusConflict, types.ErrorCodeApprovalRequired},
+	{services.ErrNotApprover, http.StatusForbidden, types.ErrorCodeNotApprover},
+	{services.ErrSelfApproval, http.StatusForbidden, types.ErrorCodeSelfApproval},
+	{services.ErrUnknownMaker, http.StatusForbidden, types.ErrorCodeUnknownMaker},
+	{services.ErrLinkedTransaction, http.StatusConflict, types.ErrorCodeLinkedTransaction},
+	{services.ErrPeriodNotOver, http.StatusUnprocessableEntity, types.ErrorCodePeriodNotOver},
+	{services.ErrDisputeNotFound, http.StatusNotFound, types.ErrorCodeDisputeNotFound},
+	{services.ErrDuplicateDispute, http.StatusConflict, types.ErrorCodeDuplicateDispute},
+	{services.ErrNotDisputable, http.StatusUnprocessableEntity, types.ErrorCodeNotDisputable},
+	{services.ErrDisputeClosed, http.StatusConflict, types.ErrorCodeDisputeClosed},
+	{services.ErrTransactionDenied, http.StatusUnprocessableEntity, types.ErrorCodeTransactionDenied},
+	{services.ErrFeeWalletUnusable, http.StatusUnprocessableEntity, types.ErrorCodeFeeWalletUnusable},
+	{repositories.ErrVersionConflict, http.StatusConflict, types.ErrorCodeVersionConflict},
+	{repositories.ErrStaleFence, http.StatusConflict, types.ErrorCodeLockLost},
+	{pagination.ErrInvalidCursor, http.StatusBadRequest, types.ErrorCodeInvalidCursor},
+	{repositories.ErrInvalidSort, http.StatusBadRequest, types.ErrorCodeBadRequest},
+}
+
+// FromError converts an error returned by the service layer into an API error.
+// Infrastructure failures and unknown errors never expose their underlying message.
+func FromError(err error) *Error {
+	for _, domainErr := range domainErrors {
+		if errors.Is(err, domainErr.target) {
+			return &Error{
+				HttpCode: domainErr.httpCode,
+				Code:     domainErr.code,
+				Message:  err.Error(),
+			}
+		}
+	}
+
+	if errors.Is(err, services.ErrUnavailable) {
+		return NewServiceUnavailableError()
+	}
+
+	return NewInternalError(err)
+}
@@ -0,0 +1,125 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/repositories"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/services"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expectedStatus  int
+		expectedCode    types.ErrorCode
+		expectedMessage string
+	}{
+		{
+			name:            "wallet not found",
+			err:             services.ErrWalletNotFound,
+			expectedStatus:  http.StatusNotFound,
+			expectedCode:    types.ErrorCodeWalletNotFound,
+			expectedMessage: "wallet not found",
+		},
+		{
+			name:            "wrapped invalid transition keeps its context",
+			err:             fmt.Errorf("%w from completed to pending", services.ErrInvalidTransition),
+			expectedStatus:  http.StatusConflict,
+			expectedCode:    types.ErrorCodeInvalidTransition,
+			expectedMessage: "invalid status transition from completed to pending",
+		},
+		{
+			name:            "insufficient funds",
+			err:             services.ErrInsufficientFunds,
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedCode:    types.ErrorCodeInsufficientFunds,
+			expectedMessage: "insufficient funds",
+		},
+		{
+			name:            "self approval",
+			err:             services.ErrSelfApproval,
+			expectedStatus:  http.StatusForbidden,
+			expectedCode:    types.ErrorCodeSelfApproval,
+			expectedMessage: services.ErrSelfApproval.Error(),
+		},
+		{
+			name:            "unknown maker",
+			err:             services.ErrUnknownMaker,
+			expectedStatus:  http.StatusForbidden,
+			expectedCode:    types.ErrorCodeUnknownMaker,
+			expectedMessage: services.ErrUnknownMaker.Error(),
+		},
+		{
+			name:            "resolved dispute",
+			err:             services.ErrDisputeClosed,
+			expectedStatus:  http.StatusConflict,
+			expectedCode:    types.ErrorCodeDisputeClosed,
+			expectedMessage: services.ErrDisputeClosed.Error(),
+		},
+		{
+			name:            "invalid sort",
+			err:             fmt.Errorf("%w: %q", repositories.ErrInvalidSort, "note"),
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    types.ErrorCodeBadRequest,
+			expectedMessage: `invalid sort field: "note"`,
+		},
+		{
+			name:            "unusable fee income wallet",
+			err:             fmt.Errorf("%w: USD wallet fees-usd is frozen", services.ErrFeeWalletUnusable),
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedCode:    types.ErrorCodeFeeWalletUnusable,
+			expectedMessage: "fee income wallet cannot be credited: USD wallet fees-usd is frozen",
+		},
+		{
+			name:            "denied by risk rules",
+			err:             services.ErrTransactionDenied,
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedCode:    types.ErrorCodeTransactionDenied,
+			expectedMessage: services.ErrTransactionDenied.Error(),
+		},
+		{
+			name:            "version conflict",
+			err:             repositories.ErrVersionConflict,
+			expectedStatus:  http.StatusConflict,
+			expectedCode:    types.ErrorCodeVersionConflict,
+			expectedMessage: repositories.ErrVersionConflict.Error(),
+		},
+		{
+			name:            "stale fencing token",
+			err:             repositories.ErrStaleFence,
+			expectedStatus:  http.StatusConflict,
+			expectedCode:    types.ErrorCodeLockLost,
+			expectedMessage: repositories.ErrStaleFence.Error(),
+		},
+		{
+			name:            "unavailable does not leak driver text",
+			err:             services.Unavailable(errors.New("pq: password authentication failed")),
+			expectedStatus:  http.StatusServiceUnavailable,
+			expectedCode:    types.ErrorCodeUnavailable,
+			expectedMessage: ErrorMessageUnavailable.String(),
+		},
+		{
+			name:            "unknown errors are internal",
+			err:             errors.New("boom"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedCode:    types.ErrorCodeInternal,
+			expectedMessage: ErrorMessageInternal.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := FromError(tt.err)
+
+			assert.Equal(t, tt.expectedStatus, apiErr.HttpCode)
+			assert.Equal(t, tt.expectedCode, apiErr.Code)
+			assert.Equal(t, tt.expectedMessage, apiErr.Message)
+		})
+	}
+}
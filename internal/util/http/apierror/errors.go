@@ -2,11 +2,14 @@ package apierror
 
 import (
 	"net/http"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 )
 
 func NewInternalError(err error) *Error {
 	return &Error{
 		HttpCode: http.StatusInternalServerError,
+		Code:     types.ErrorCodeInternal,
 		Message:  ErrorMessageInternal.String(),
 	}
 }
@@ -14,6 +17,7 @@ func NewInternalError(err error) *Error {
 func NewNotFoundError(message string) *Error {
 	return &Error{
 		HttpCode: http.StatusNotFound,
+		Code:     types.ErrorCodeNotFound,
 		Message:  message,
 	}
 }
@@ -21,6 +25,7 @@ func NewNotFoundError(message string) *Error {
 func NewBadRequestError(message string) *Error {
 	return &Error{
 		HttpCode: http.StatusBadRequest,
+		Code:     types.ErrorCodeBadRequest,
 		Message:  message,
 	}
 }
@@ -28,6 +33,31 @@ func NewBadRequestError(message string) *Error {
 func NewUnprocessableEntityError(message string) *Error {
 	return &Error{
 		HttpCode: http.StatusUnprocessableEntity,
+		Code:     types.ErrorCodeUnprocessable,
+		Message:  message,
+	}
+}
+
+func NewConflictError(message string) *Error {
+	return &Error{
+		HttpCode: http.StatusConflict,
+		Code:     types.ErrorCodeConflict,
+		Message:  message,
+	}
+}
+
+func NewServiceUnavailableError() *Error {
+	return &Error{
+		HttpCode: http.StatusServiceUnavailable,
+		Code:     types.ErrorCodeUnavailable,
+		Message:  ErrorMessageUnavailable.String(),
+	}
+}
+
+func NewTooManyRequestsError(message string) *Error {
+	return &Error{
+		HttpCode: http.StatusTooManyRequests,
+		Code:     types.ErrorCodeTooManyRequests,
 		Message:  message,
 	}
 }
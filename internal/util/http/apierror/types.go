@@ -4,14 +4,16 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 	"github.com/go-playground/validator/v10"
 )
 
 type ErrorMessage string
 
 const (
-	ErrorMessageValidation ErrorMessage = "validation error"
-	ErrorMessageInternal   ErrorMessage = "something went wrong"
+	ErrorMessageValidation  ErrorMessage = "validation error"
+	ErrorMessageInternal    ErrorMessage = "something went wrong"
+	ErrorMessageUnavailable ErrorMessage = "service temporarily unavailable, please retry later"
 )
 
 func (code ErrorMessage) String() string {
@@ -28,13 +30,16 @@ type ValidationError struct {
 // Error represents a standardized error structure.
 type Error struct {
 	HttpCode int               `json:"-"`
+	Code     types.ErrorCode   `json:"code"`
 	Message  string            `json:"message,omitempty"`
 	Errors   []ValidationError `json:"errors,omitempty"`
+	// RequestID identifies the failed request in the service logs.
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func (e Error) Error() string {
 	sb := strings.Builder{}
-	sb.WriteString(fmt.Sprintf("http_code= %d, message: %s", e.HttpCode, e.Message))
+	sb.WriteString(fmt.Sprintf("http_code= %d, code: %s, message: %s", e.HttpCode, e.Code, e.Message))
 
 	for _, err := range e.Errors {
 		sb.WriteString(fmt.Sprintf(", field: %s, error: %s", err.Source, err.Message))
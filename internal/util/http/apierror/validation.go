@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -15,12 +16,14 @@ func NewValidatorError(c context.Context, err error) *Error {
 	if !ok {
 		return &Error{
 			HttpCode: http.StatusBadRequest,
+			Code:     types.ErrorCodeBadRequest,
 			Message:  err.Error(),
 		}
 	}
 
 	apiErr := &Error{
 		HttpCode: http.StatusUnprocessableEntity,
+		Code:     types.ErrorCodeValidation,
 		Message:  ErrorMessageValidation.String(),
 		Errors:   make([]ValidationError, 0),
 	}
@@ -30,6 +33,7 @@ func NewValidatorError(c context.Context, err error) *Error {
 		if !ok {
 			return &Error{
 				HttpCode: http.StatusUnprocessableEntity,
+				Code:     types.ErrorCodeValidation,
 				Message:  err.Error(),
 			}
 		}
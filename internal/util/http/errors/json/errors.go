@@ -1,21 +1,39 @@
 package json
 
 import (
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/logging"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
 	"github.com/gin-gonic/gin"
 )
 
 func SendApiValidationError(c *gin.Context, err error) {
-	validationError := apierror.NewValidatorError(c, err)
-	c.JSON(validationError.HttpCode, validationError)
+	send(c, apierror.NewValidatorError(c, err))
 }
 
+// SendGenericAPIError responds with the API error matching err. Server errors are also recorded on the
+// context, so the access log keeps the cause the response hides.
 func SendGenericAPIError(c *gin.Context, err error) {
-	genericError := apierror.NewUnprocessableEntityError(err.Error())
-	c.JSON(genericError.HttpCode, genericError)
+	apiError := apierror.FromError(err)
+	if apiError.HttpCode >= 500 {
+		_ = c.Error(err)
+	}
+
+	send(c, apiError)
 }
 
 func SendBadRequestError(c *gin.Context, message string) {
-	badRequestError := apierror.NewBadRequestError(message)
-	c.JSON(badRequestError.HttpCode, badRequestError)
+	send(c, apierror.NewBadRequestError(message))
+}
+
+func SendTooManyRequestsError(c *gin.Context, message string) {
+	send(c, apierror.NewTooManyRequestsError(message))
+}
+
+func SendServiceUnavailableError(c *gin.Context) {
+	send(c, apierror.NewServiceUnavailableError())
+}
+
+func send(c *gin.Context, apiError *apierror.Error) {
+	apiError.RequestID = logging.RequestID(c.Request.Context())
+	c.JSON(apiError.HttpCode, apiError)
 }
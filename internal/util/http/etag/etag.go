@@ -0,0 +1,58 @@
+package etag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+var ErrInvalidIfMatch = errors.New("If-Match header must be a single quoted version, e.g. \"3\"")
+
+// Format renders a resource version as a strong entity tag.
+func Format(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// Set writes the resource version as the ETag response header.
+func Set(c *gin.Context, version int) {
+	c.Header(HeaderETag, Format(version))
+}
+
+// ParseIfMatch returns the version requested through the If-Match header.
+// It returns nil when the header is absent or is the "*" wildcard.
+func ParseIfMatch(c *gin.Context) (*int, error) {
+	value := strings.TrimSpace(c.GetHeader(HeaderIfMatch))
+	if value == "" || value == "*" {
+		return nil, nil //nolint:nilnil
+	}
+
+	version, err := Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// Parse extracts the version from an entity tag produced by Format. Weak tags are accepted as well.
+func Parse(value string) (int, error) {
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(value, "W/"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: got %s", ErrInvalidIfMatch, value)
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: got %s", ErrInvalidIfMatch, value)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/logging"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AccessLog writes a line per request. Server errors are logged at error level, client errors at warn.
+func AccessLog(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+
+		level := zapcore.InfoLevel
+		switch {
+		case status >= 500:
+			level = zapcore.ErrorLevel
+		case status >= 400:
+			level = zapcore.WarnLevel
+		}
+
+		logging.WithContext(c.Request.Context(), logger).Log(level, "request served",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("route", c.FullPath()),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("clientIP", c.ClientIP()),
+			zap.Strings("errors", c.Errors.Errors()),
+		)
+	}
+}
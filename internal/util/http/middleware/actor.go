@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/actor"
+	"github.com/gin-gonic/gin"
+)
+
+const HeaderActorID = "X-Actor-ID"
+
+// Actor stores the X-Actor-ID sent by the gateway in the request context. IDs that are too long are
+// ignored, leaving the request anonymous.
+func Actor(c *gin.Context) {
+	if id := c.GetHeader(HeaderActorID); id != "" && len(id) <= actor.MaxIDLength {
+		c.Request = c.Request.WithContext(actor.WithID(c.Request.Context(), id))
+	}
+
+	c.Next()
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels requests that matched no route, so scanners cannot blow up the label set.
+const unmatchedRoute = "unmatched"
+
+var requestDuration = metrics.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "http_request_duration_seconds",
+	Help:    "Latency of HTTP requests by route and status.",
+	Buckets: prometheus.DefBuckets,
+}, "method", "route", "status")
+
+// Metrics records the latency of every request, labelled with the route pattern rather than the path.
+func Metrics(c *gin.Context) {
+	start := time.Now()
+
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	requestDuration.
+		WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+		Observe(time.Since(start).Seconds())
+}
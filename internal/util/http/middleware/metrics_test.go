@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(Metrics)
+	engine.GET("/wallets/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+
+	for _, path := range []string{"/wallets/1", "/wallets/2", "/wp-login.php"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	res := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, res.Body.String(),
+		`wallet_http_request_duration_seconds_count{method="GET",route="/wallets/:id",status="404"} 2`)
+	assert.Contains(t, res.Body.String(),
+		`wallet_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+}
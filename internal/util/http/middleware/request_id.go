@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/logging"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderRequestID = "X-Request-ID"
+	// maxRequestIDLength bounds the client supplied IDs written to every log line.
+	maxRequestIDLength = 128
+)
+
+// RequestID reuses the X-Request-ID sent by the client, or generates one, stores it in the request
+// context and echoes it in the response.
+func RequestID(c *gin.Context) {
+	requestID := c.GetHeader(HeaderRequestID)
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = ulid.GenerateID(time.Now())
+	}
+
+	c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+	c.Header(HeaderRequestID, requestID)
+
+	c.Next()
+}
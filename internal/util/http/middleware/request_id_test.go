@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	jsonlib "github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/errors/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(RequestID)
+	engine.GET("/wallets/:id", func(c *gin.Context) { jsonlib.SendBadRequestError(c, "bad wallet") })
+
+	tests := []struct {
+		name      string
+		requestID string
+		generated bool
+	}{
+		{name: "client ID is echoed", requestID: "req-123"},
+		{name: "missing ID is generated", generated: true},
+		{name: "oversized ID is replaced", requestID: strings.Repeat("a", 200), generated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/wallets/1", nil)
+			if tt.requestID != "" {
+				req.Header.Set(HeaderRequestID, tt.requestID)
+			}
+
+			res := httptest.NewRecorder()
+			engine.ServeHTTP(res, req)
+
+			requestID := res.Header().Get(HeaderRequestID)
+			require.NotEmpty(t, requestID)
+
+			if tt.generated {
+				assert.NotEqual(t, tt.requestID, requestID)
+			} else {
+				assert.Equal(t, tt.requestID, requestID)
+			}
+
+			var body apierror.Error
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, requestID, body.RequestID)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor marks a position in a keyset-paginated listing. It is handed to clients as an opaque string.
+type Cursor struct {
+	ID string `json:"id"`
+	// Key is the value of the sort column at the cursor position, when sorting by something other than ID.
+	Key string `json:"k,omitempty"`
+	// Sort names the ordering the cursor was issued for.
+	Sort string `json:"s,omitempty"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -15,6 +15,9 @@ type Pagination struct {
 	Page       int `json:"page"`
 	PerPage    int `json:"per_page"`
 	TotalPages int `json:"total_pages"`
+	// NextCursor and PrevCursor are only set in cursor mode, when there are more items in that direction.
+	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
 }
 
 func NewPagination(page int, count int, total int, limit int) *Pagination {
@@ -27,6 +30,26 @@ func NewPagination(page int, count int, total int, limit int) *Pagination {
 	}
 }
 
+// NewCursorPagination describes a keyset page. Totals are not computed in cursor mode.
+func NewCursorPagination(count int, limit int, next *Cursor, prev *Cursor) *Pagination {
+	pagination := &Pagination{
+		Count:   count,
+		PerPage: limit,
+	}
+
+	if next != nil {
+		encoded := next.Encode()
+		pagination.NextCursor = &encoded
+	}
+
+	if prev != nil {
+		encoded := prev.Encode()
+		pagination.PrevCursor = &encoded
+	}
+
+	return pagination
+}
+
 func getTotalPages(total int, limit int) int {
 	totalPages := int(math.Ceil(float64(total) / float64(limit)))
 
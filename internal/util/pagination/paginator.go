@@ -6,6 +6,17 @@ type Paginator struct {
 	Page *int `binding:"omitempty,gte=1" form:"page,omitempty" json:"page,omitempty" url:"page,omitempty"`
 	// PerPage is the number of items per page, with a maximum of 100.
 	PerPage *int `binding:"omitempty,gte=1,lte=100" form:"per_page,omitempty" json:"per_page,omitempty" url:"per_page,omitempty"`
+	// After is an opaque cursor; only items after it are returned. Switches the listing to cursor mode.
+	After *string `binding:"omitempty,excluded_with=Before Page" form:"after,omitempty" json:"after,omitempty" url:"after,omitempty"`
+	// Before is an opaque cursor; only items before it are returned. Switches the listing to cursor mode.
+	Before *string `binding:"omitempty,excluded_with=After Page" form:"before,omitempty" json:"before,omitempty" url:"before,omitempty"`
+	// Limit is the number of items per page in cursor mode, with a maximum of 100.
+	Limit *int `binding:"omitempty,gte=1,lte=100,excluded_with=Page PerPage" form:"limit,omitempty" json:"limit,omitempty" url:"limit,omitempty"`
+}
+
+// IsCursor reports whether keyset pagination was requested instead of page/per_page offsets.
+func (p *Paginator) IsCursor() bool {
+	return p.After != nil || p.Before != nil || p.Limit != nil
 }
 
 func (p *Paginator) GetTotal(count int) (int, bool) {
@@ -0,0 +1,15 @@
+package types
+
+// ApprovalAction is an entry of the approval trail of a transaction.
+type ApprovalAction string
+
+const (
+	// ApprovalActionRequested is recorded when a transaction is created awaiting approval.
+	ApprovalActionRequested ApprovalAction = "requested"
+	ApprovalActionApproved  ApprovalAction = "approved"
+	ApprovalActionRejected  ApprovalAction = "rejected"
+)
+
+func (a ApprovalAction) String() string {
+	return string(a)
+}
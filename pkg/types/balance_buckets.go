@@ -0,0 +1,22 @@
+package types
+
+// BalanceBucket is a part of a wallet balance with its own spending rules. Every credit funds one bucket.
+type BalanceBucket string
+
+const (
+	// BalanceBucketCash holds the money paid into the wallet.
+	BalanceBucketCash BalanceBucket = "cash"
+	// BalanceBucketPromo holds promotional credit, which usually expires.
+	BalanceBucketPromo BalanceBucket = "promo"
+)
+
+func (b BalanceBucket) String() string {
+	return string(b)
+}
+
+func GetBalanceBuckets() []BalanceBucket {
+	return []BalanceBucket{
+		BalanceBucketCash,
+		BalanceBucketPromo,
+	}
+}
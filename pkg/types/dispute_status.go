@@ -0,0 +1,35 @@
+package types
+
+type DisputeStatus string
+type DisputeStatuses []DisputeStatus
+
+const (
+	DisputeStatusOpen        DisputeStatus = "open"
+	DisputeStatusUnderReview DisputeStatus = "under_review"
+	// DisputeStatusWon settles a dispute in favour of the wallet owner, who keeps or gets back the amount.
+	DisputeStatusWon DisputeStatus = "won"
+	// DisputeStatusLost settles a dispute against the wallet owner, reversing any provisional credit.
+	DisputeStatusLost DisputeStatus = "lost"
+)
+
+func (d DisputeStatus) String() string {
+	return string(d)
+}
+
+func (d DisputeStatuses) String() []string {
+	strs := make([]string, 0, len(d))
+	for _, status := range d {
+		strs = append(strs, status.String())
+	}
+
+	return strs
+}
+
+func GetDisputeStatuses() []DisputeStatus {
+	return []DisputeStatus{
+		DisputeStatusOpen,
+		DisputeStatusUnderReview,
+		DisputeStatusWon,
+		DisputeStatusLost,
+	}
+}
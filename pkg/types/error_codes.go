@@ -0,0 +1,36 @@
+package types
+
+// ErrorCode is a stable, machine-readable identifier of an error. Unlike messages, codes never change.
+type ErrorCode string
+
+const (
+	ErrorCodeBadRequest          ErrorCode = "bad_request"
+	ErrorCodeValidation          ErrorCode = "validation_failed"
+	ErrorCodeInternal            ErrorCode = "internal_error"
+	ErrorCodeUnavailable         ErrorCode = "service_unavailable"
+	ErrorCodeNotFound            ErrorCode = "not_found"
+	ErrorCodeConflict            ErrorCode = "conflict"
+	ErrorCodeUnprocessable       ErrorCode = "unprocessable_entity"
+	ErrorCodeTooManyRequests     ErrorCode = "too_many_requests"
+	ErrorCodeWalletNotFound      ErrorCode = "wallet_not_found"
+	ErrorCodeTransactionNotFound ErrorCode = "transaction_not_found"
+	ErrorCodeInsufficientFunds   ErrorCode = "insufficient_funds"
+	ErrorCodeWalletNotActive     ErrorCode = "wallet_not_active"
+	ErrorCodeInvalidTransition   ErrorCode = "invalid_transition"
+	ErrorCodeDuplicateWallet     ErrorCode = "duplicate_wallet"
+	ErrorCodeVersionConflict     ErrorCode = "version_conflict"
+	ErrorCodeInvalidCursor       ErrorCode = "invalid_cursor"
+	ErrorCodeLockLost            ErrorCode = "lock_lost"
+	ErrorCodeApprovalRequired    ErrorCode = "approval_required"
+	ErrorCodeNotApprover         ErrorCode = "not_an_approver"
+	ErrorCodeSelfApproval        ErrorCode = "self_approval"
+	ErrorCodeUnknownMaker        ErrorCode = "unknown_maker"
+	ErrorCodeLinkedTransaction   ErrorCode = "linked_transaction"
+	ErrorCodePeriodNotOver       ErrorCode = "period_not_over"
+	ErrorCodeDisputeNotFound     ErrorCode = "dispute_not_found"
+	ErrorCodeDuplicateDispute    ErrorCode = "duplicate_dispute"
+	ErrorCodeNotDisputable       ErrorCode = "not_disputable"
+	ErrorCodeDisputeClosed       ErrorCode = "dispute_closed"
+	ErrorCodeTransactionDenied   ErrorCode = "transaction_denied"
+	ErrorCodeFeeWalletUnusable   ErrorCode = "fee_wallet_unusable"
+)
@@ -0,0 +1,13 @@
+package types
+
+// EventType identifies what changed in a wallet event.
+type EventType string
+
+const (
+	EventTypeTransactionCreated       EventType = "transaction.created"
+	EventTypeTransactionStatusUpdated EventType = "transaction.status_updated"
+)
+
+func (e EventType) String() string {
+	return string(e)
+}
@@ -0,0 +1,34 @@
+package types
+
+// RiskDecision is the outcome of the risk rules run before a transaction is created.
+type RiskDecision string
+type RiskDecisions []RiskDecision
+
+const (
+	RiskDecisionAllow RiskDecision = "allow"
+	// RiskDecisionReview holds the transaction awaiting approval.
+	RiskDecisionReview RiskDecision = "review"
+	// RiskDecisionDeny refuses the transaction, which is never created.
+	RiskDecisionDeny RiskDecision = "deny"
+)
+
+func (d RiskDecision) String() string {
+	return string(d)
+}
+
+func (d RiskDecisions) String() []string {
+	strs := make([]string, 0, len(d))
+	for _, decision := range d {
+		strs = append(strs, decision.String())
+	}
+
+	return strs
+}
+
+func GetRiskDecisions() []RiskDecision {
+	return []RiskDecision{
+		RiskDecisionAllow,
+		RiskDecisionReview,
+		RiskDecisionDeny,
+	}
+}
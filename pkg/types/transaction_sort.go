@@ -0,0 +1,39 @@
+package types
+
+import "strings"
+
+// TransactionSort is a whitelisted transaction ordering. A leading "-" means descending.
+type TransactionSort string
+
+const (
+	TransactionSortAmountAsc     TransactionSort = "amount"
+	TransactionSortAmountDesc    TransactionSort = "-amount"
+	TransactionSortCreatedAtAsc  TransactionSort = "created_at"
+	TransactionSortCreatedAtDesc TransactionSort = "-created_at"
+	TransactionSortUpdatedAtAsc  TransactionSort = "updated_at"
+	TransactionSortUpdatedAtDesc TransactionSort = "-updated_at"
+)
+
+func (t TransactionSort) String() string {
+	return string(t)
+}
+
+// Column returns the transaction field the sort applies to.
+func (t TransactionSort) Column() string {
+	return strings.TrimPrefix(string(t), "-")
+}
+
+func (t TransactionSort) Descending() bool {
+	return strings.HasPrefix(string(t), "-")
+}
+
+func GetTransactionSorts() []TransactionSort {
+	return []TransactionSort{
+		TransactionSortAmountAsc,
+		TransactionSortAmountDesc,
+		TransactionSortCreatedAtAsc,
+		TransactionSortCreatedAtDesc,
+		TransactionSortUpdatedAtAsc,
+		TransactionSortUpdatedAtDesc,
+	}
+}
@@ -7,6 +7,10 @@ const (
 	TransactionStatusPending   TransactionStatus = "pending"
 	TransactionStatusCompleted TransactionStatus = "completed"
 	TransactionStatusFailed    TransactionStatus = "failed"
+	// TransactionStatusAwaitingApproval holds a transaction until an approver other than its maker
+	// approves it, which makes it pending, or rejects it.
+	TransactionStatusAwaitingApproval TransactionStatus = "awaiting_approval"
+	TransactionStatusRejected         TransactionStatus = "rejected"
 )
 
 func (t TransactionStatus) String() string {
@@ -27,5 +31,7 @@ func GetTransactionStatuses() []TransactionStatus {
 		TransactionStatusPending,
 		TransactionStatusCompleted,
 		TransactionStatusFailed,
+		TransactionStatusAwaitingApproval,
+		TransactionStatusRejected,
 	}
 }
@@ -26,6 +26,18 @@ func RegisterValidations() error {
 		return err
 	}
 
+	if err := registerEnumValidation("transactionSortEnum", GetTransactionSorts()); err != nil {
+		return err
+	}
+
+	if err := registerEnumValidation("balanceBucketEnum", GetBalanceBuckets()); err != nil {
+		return err
+	}
+
+	if err := registerEnumValidation("disputeStatusEnum", GetDisputeStatuses()); err != nil {
+		return err
+	}
+
 	if err := registerEnumSliceValidation("transactionStatusesEnum", GetTransactionStatuses()); err != nil {
 		return err
 	}
@@ -42,6 +54,14 @@ func RegisterValidations() error {
 		return err
 	}
 
+	if err := registerEnumSliceValidation("disputeStatusesEnum", GetDisputeStatuses()); err != nil {
+		return err
+	}
+
+	if err := registerEnumSliceValidation("riskDecisionsEnum", GetRiskDecisions()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1,10 +1,19 @@
 package wallet
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/google/go-querystring/query"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	HeaderAPIKey  = "X-API-Key"
+	HeaderIfMatch = "If-Match"
 )
 
 type NoContentResponse struct{}
@@ -13,21 +22,125 @@ type Client struct {
 	baseURL    string
 	apiVersion string
 	httpClient *resty.Client
+	retry      RetryPolicy
 }
 
-func NewClient(baseURL string) *Client {
+// NewClient creates a wallet service client. Without options it uses a default HTTP client,
+// no timeout, no authentication and no retries.
+func NewClient(baseURL string, opts ...Option) *Client {
+	cfg := clientConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	httpClient := resty.New()
+	if cfg.httpClient != nil {
+		httpClient = resty.NewWithClient(cfg.httpClient)
+	}
+
+	if cfg.timeout > 0 {
+		httpClient.SetTimeout(cfg.timeout)
+	}
+
+	if cfg.apiKey != "" {
+		httpClient.SetHeader(HeaderAPIKey, cfg.apiKey)
+	}
+
+	httpClient.SetHeaders(cfg.headers)
+
 	return &Client{
 		baseURL:    baseURL,
 		apiVersion: "v1",
-		httpClient: resty.New(),
+		httpClient: httpClient,
+		retry:      cfg.retry,
+	}
+}
+
+type request struct {
+	method  string
+	path    string
+	query   any
+	body    any
+	result  any
+	ifMatch *int
+	// idempotent marks requests that can safely be sent more than once and are therefore retried.
+	idempotent bool
+}
+
+// do sends the request, retrying idempotent ones according to the retry policy, and decodes
+// error responses into *APIError.
+func (cl *Client) do(ctx context.Context, req request) error {
+	url, err := cl.buildUrl(req.path, req.query)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := cl.send(ctx, req, url)
+		if err == nil && !resp.IsError() {
+			return nil
+		}
+
+		reqErr := err
+		if reqErr == nil {
+			reqErr = decodeError(resp)
+		}
+
+		if !req.idempotent || attempt >= cl.retry.MaxRetries || !isRetryable(resp, err) {
+			return reqErr
+		}
+
+		if err := sleep(ctx, cl.retry.backoff(attempt, resp)); err != nil {
+			return reqErr
+		}
 	}
 }
 
-func (cl *Client) buildUrl(path string, queryParams any) string {
+func (cl *Client) send(ctx context.Context, req request, url string) (*resty.Response, error) {
+	r := cl.httpClient.R().
+		SetContext(ctx).
+		SetError(&APIError{})
+
+	if req.body != nil {
+		r.SetBody(req.body)
+	}
+
+	if req.result != nil {
+		r.SetResult(req.result)
+	}
+
+	if req.ifMatch != nil {
+		r.SetHeader(HeaderIfMatch, strconv.Quote(strconv.Itoa(*req.ifMatch)))
+	}
+
+	// The caller's trace, if any, continues in the service through the W3C traceparent header.
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	return r.Execute(req.method, url)
+}
+
+func (cl *Client) buildUrl(path string, queryParams any) (string, error) {
 	queries, err := query.Values(queryParams)
 	if err != nil {
-		panic(err)
+		return "", fmt.Errorf("failed to encode query parameters: %w", err)
 	}
 
-	return fmt.Sprintf("%s/%s%s?%s", cl.baseURL, cl.apiVersion, path, queries.Encode())
+	return fmt.Sprintf("%s/%s%s?%s", cl.baseURL, cl.apiVersion, path, queries.Encode()), nil
+}
+
+func isRetryable(resp *resty.Response, err error) bool {
+	if err != nil {
+		// Transport errors are retried unless the caller gave up.
+		return resp == nil || resp.Request == nil || resp.Request.Context().Err() == nil
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
 }
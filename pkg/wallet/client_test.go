@@ -0,0 +1,156 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		body           string
+		expectedCode   types.ErrorCode
+		expectedFields int
+		expectConflict bool
+	}{
+		{
+			name:         "domain error",
+			status:       http.StatusUnprocessableEntity,
+			body:         `{"code":"insufficient_funds","message":"insufficient funds"}`,
+			expectedCode: types.ErrorCodeInsufficientFunds,
+		},
+		{
+			name:           "validation error",
+			status:         http.StatusBadRequest,
+			body:           `{"code":"validation_failed","message":"validation error","errors":[{"source":"currency","message":"required"}]}`,
+			expectedCode:   types.ErrorCodeValidation,
+			expectedFields: 1,
+		},
+		{
+			name:           "version conflict",
+			status:         http.StatusConflict,
+			body:           `{"code":"version_conflict","message":"resource was modified by another request"}`,
+			expectedCode:   types.ErrorCodeVersionConflict,
+			expectConflict: true,
+		},
+		{
+			name:   "non json body",
+			status: http.StatusBadGateway,
+			body:   `bad gateway`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL).GetWalletByID(context.Background(), "wallet-1")
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+			assert.Equal(t, tt.expectedCode, apiErr.Code)
+			assert.NotEmpty(t, apiErr.Message)
+			assert.Len(t, apiErr.Errors, tt.expectedFields)
+
+			var conflictErr *ConflictError
+			assert.Equal(t, tt.expectConflict, errors.As(err, &conflictErr))
+		})
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name             string
+		call             func(cl *Client) error
+		expectedAttempts int32
+	}{
+		{
+			name: "idempotent request is retried",
+			call: func(cl *Client) error {
+				_, err := cl.GetWalletByID(context.Background(), "wallet-1")
+
+				return err
+			},
+			expectedAttempts: 3,
+		},
+		{
+			name: "non idempotent request is not retried",
+			call: func(cl *Client) error {
+				_, err := cl.CreateWallet(context.Background(), CreateWalletRequest{})
+
+				return err
+			},
+			expectedAttempts: 1,
+		},
+		{
+			name: "versioned update is not retried",
+			call: func(cl *Client) error {
+				version := 2
+				_, err := cl.UpdateWalletStatus(context.Background(), "wallet-1",
+					UpdateWalletStatusRequest{Version: &version})
+
+				return err
+			},
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			cl := NewClient(server.URL, WithRetry(2, time.Millisecond, 5*time.Millisecond))
+
+			var apiErr *APIError
+			require.ErrorAs(t, tt.call(cl), &apiErr)
+			assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+			assert.Equal(t, tt.expectedAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestClient_PropagatesTraceContext(t *testing.T) {
+	var traceparent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"wallet":{"id":"wallet-1"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	_, err := NewClient(server.URL).GetWalletByID(ctx, "wallet-1")
+	require.NoError(t, err)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", traceparent)
+}
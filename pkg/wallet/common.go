@@ -7,3 +7,15 @@ import (
 type Metadata struct {
 	Pagination pagination.Pagination `json:"pagination"`
 }
+
+func cursorStart(paginator pagination.Paginator) pagination.Paginator {
+	limit := iteratorPageLimit
+	if paginator.Limit != nil {
+		limit = *paginator.Limit
+	}
+
+	return pagination.Paginator{
+		After: paginator.After,
+		Limit: &limit,
+	}
+}
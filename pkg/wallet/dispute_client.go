@@ -0,0 +1,100 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// OpenDispute opens a dispute of a completed debit. It is not retried, as a retry would be rejected by its
+// own earlier success.
+func (cl *Client) OpenDispute(ctx context.Context, req OpenDisputeRequest) (DisputeResponse, error) {
+	var dispute DisputeResponse
+
+	err := cl.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/disputes",
+		body:   req,
+		result: &dispute,
+	})
+	if err != nil {
+		return DisputeResponse{}, fmt.Errorf("failed to open dispute: %w", err)
+	}
+
+	return dispute, nil
+}
+
+// GetDispute returns a dispute along with its notes.
+func (cl *Client) GetDispute(ctx context.Context, id string) (DisputeResponse, error) {
+	var dispute DisputeResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/disputes/%s", url.PathEscape(id)),
+		result:     &dispute,
+		idempotent: true,
+	})
+	if err != nil {
+		return DisputeResponse{}, fmt.Errorf("failed to get dispute: %w", err)
+	}
+
+	return dispute, nil
+}
+
+func (cl *Client) GetDisputes(ctx context.Context, query ListDisputesRequest) (DisputesResponse, error) {
+	var disputes DisputesResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       "/disputes",
+		query:      query,
+		result:     &disputes,
+		idempotent: true,
+	})
+	if err != nil {
+		return DisputesResponse{}, fmt.Errorf("failed to get disputes: %w", err)
+	}
+
+	return disputes, nil
+}
+
+// UpdateDisputeStatus moves the dispute to a new status. It is retried unless req.Version is set, in which
+// case a retry could be rejected by its own earlier success.
+func (cl *Client) UpdateDisputeStatus(ctx context.Context, id string, req UpdateDisputeStatusRequest) (
+	DisputeResponse, error) {
+	var dispute DisputeResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodPatch,
+		path:       fmt.Sprintf("/disputes/%s/status", url.PathEscape(id)),
+		body:       req,
+		result:     &dispute,
+		ifMatch:    req.Version,
+		idempotent: req.Version == nil,
+	})
+	if err != nil {
+		return DisputeResponse{}, fmt.Errorf("failed to update dispute status: %w", err)
+	}
+
+	return dispute, nil
+}
+
+// AddDisputeNote attaches evidence to a dispute on behalf of the actor set with WithActorID. It is not
+// retried, as a retry would add the note twice.
+func (cl *Client) AddDisputeNote(ctx context.Context, id string, req AddDisputeNoteRequest) (
+	DisputeNoteResponse, error) {
+	var note DisputeNoteResponse
+
+	err := cl.do(ctx, request{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/disputes/%s/notes", url.PathEscape(id)),
+		body:   req,
+		result: &note,
+	})
+	if err != nil {
+		return DisputeNoteResponse{}, fmt.Errorf("failed to add dispute note: %w", err)
+	}
+
+	return note, nil
+}
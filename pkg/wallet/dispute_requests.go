@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+//nolint:lll
+type OpenDisputeRequest struct {
+	// TransactionID is the completed debit disputed.
+	TransactionID string `binding:"required" form:"transaction_id" json:"transaction_id" url:"transaction_id"`
+	// Reason the wallet owner gave for the dispute.
+	Reason string `binding:"required,max=500" form:"reason" json:"reason" url:"reason"`
+	// ProvisionalCredit credits the disputed amount back while the dispute is open. It is reversed if the
+	// dispute is lost.
+	ProvisionalCredit bool `binding:"omitempty" form:"provisional_credit,omitempty" json:"provisional_credit,omitempty" url:"provisional_credit,omitempty"`
+	// DueAt is the deadline to resolve the dispute by. Defaults to the configured response window.
+	DueAt *time.Time `binding:"omitempty" form:"due_at,omitempty" json:"due_at,omitempty" url:"due_at,omitempty"`
+}
+
+//nolint:lll
+type ListDisputesRequest struct {
+	// Wallet IDs to filter.
+	WalletIDs []string `binding:"omitempty" form:"wallet_ids,omitempty" json:"wallet_ids,omitempty" url:"wallet_ids,omitempty"`
+	// Transaction IDs to filter.
+	TransactionIDs []string `binding:"omitempty" form:"transaction_ids,omitempty" json:"transaction_ids,omitempty" url:"transaction_ids,omitempty"`
+	// Statuses of the disputes to filter.
+	Statuses types.DisputeStatuses `binding:"omitempty,disputeStatusesEnum" form:"statuses,omitempty" json:"statuses,omitempty" url:"statuses,omitempty"`
+	// DueBefore only includes disputes due before it, such as those past their deadline.
+	DueBefore *time.Time `binding:"omitempty" form:"due_before,omitempty" json:"due_before,omitempty" url:"due_before,omitempty"`
+
+	pagination.Paginator
+}
+
+type UpdateDisputeStatusRequest struct {
+	Status types.DisputeStatus `binding:"required,disputeStatusEnum" form:"status" json:"status" url:"status"`
+	// Note explains the resolution of a won or lost dispute. Otherwise it is added to the dispute notes.
+	Note *string `binding:"omitempty,max=500" form:"note,omitempty" json:"note,omitempty" url:"note,omitempty"`
+	// Version, when set, is sent as If-Match so the update only applies to that dispute version.
+	Version *int `form:"-" json:"-" url:"-"`
+}
+
+// AddDisputeNoteRequest attaches evidence to a dispute that is not resolved yet.
+type AddDisputeNoteRequest struct {
+	Note string `binding:"required,max=2000" form:"note" json:"note" url:"note"`
+}
@@ -0,0 +1,51 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+type Dispute struct {
+	ID            string              `json:"id"`
+	TransactionID string              `json:"transaction_id"`
+	WalletID      string              `json:"wallet_id"`
+	Amount        int                 `json:"amount"`
+	Reason        string              `json:"reason"`
+	Status        types.DisputeStatus `json:"status"`
+	// DueAt is the deadline to resolve the dispute by.
+	DueAt time.Time `json:"due_at"`
+	// ProvisionalCreditID is the credit given back while the dispute is open.
+	ProvisionalCreditID *string `json:"provisional_credit_id,omitempty"`
+	// SettlementID is the transaction that settled the dispute: the credit of a dispute won without a
+	// provisional credit, or the reversal of the provisional credit of a dispute lost.
+	SettlementID   *string `json:"settlement_id,omitempty"`
+	ResolutionNote *string `json:"resolution_note,omitempty"`
+	// Notes are the evidence attached to the dispute, oldest first, returned when it is looked up.
+	Notes     []DisputeNote `json:"notes,omitempty"`
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// DisputeNote is a piece of evidence attached to a dispute.
+type DisputeNote struct {
+	ID        string    `json:"id"`
+	DisputeID string    `json:"dispute_id"`
+	ActorID   string    `json:"actor_id"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DisputeResponse struct {
+	Dispute `json:"dispute"`
+}
+
+type DisputesResponse struct {
+	Disputes []Dispute `json:"disputes"`
+	Metadata Metadata  `json:"metadata"`
+}
+
+type DisputeNoteResponse struct {
+	DisputeNote `json:"note"`
+}
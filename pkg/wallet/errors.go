@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/go-resty/resty/v2"
+)
+
+// APIError is returned for every non-2xx response of the wallet service.
+// Use errors.As to inspect it and Code to branch on the kind of failure.
+type APIError struct {
+	StatusCode int             `json:"-"`
+	Code       types.ErrorCode `json:"code"`
+	Message    string          `json:"message"`
+	Errors     []FieldError    `json:"errors,omitempty"`
+	// RequestID identifies the failed request in the service logs.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// FieldError describes why a single request field failed validation.
+type FieldError struct {
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("wallet: %d %s: %s", e.StatusCode, e.Code, e.Message))
+
+	for _, fieldErr := range e.Errors {
+		sb.WriteString(fmt.Sprintf(", %s: %s", fieldErr.Source, fieldErr.Message))
+	}
+
+	return sb.String()
+}
+
+// ConflictError is returned when the server rejects a write because the resource
+// was modified since the version the caller sent in If-Match, or is in a conflicting state.
+type ConflictError struct {
+	*APIError
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.APIError
+}
+
+func decodeError(resp *resty.Response) error {
+	apiErr, ok := resp.Error().(*APIError)
+	if !ok || apiErr == nil {
+		apiErr = &APIError{}
+	}
+
+	apiErr.StatusCode = resp.StatusCode()
+
+	if apiErr.Message == "" {
+		apiErr.Message = resp.Status()
+	}
+
+	if apiErr.StatusCode == http.StatusConflict {
+		return &ConflictError{APIError: apiErr}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,19 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+// WalletEvent is the data of an event streamed from GET /v1/wallets/{id}/events. The SSE event name is
+// the event type and the SSE id is the event ID, to be sent back as Last-Event-ID when reconnecting.
+type WalletEvent struct {
+	ID          string          `json:"id"`
+	Type        types.EventType `json:"type"`
+	WalletID    string          `json:"wallet_id"`
+	Transaction Transaction     `json:"transaction"`
+	// Balance is the wallet balance after the change, when known.
+	Balance    *int      `json:"balance,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AccrueInterest is retried safely because a day only ever accrues once.
+func (cl *Client) AccrueInterest(ctx context.Context, req AccrueInterestRequest) (InterestAccrualResponse, error) {
+	var accrual InterestAccrualResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/interest/accruals",
+		body:       req,
+		result:     &accrual,
+		idempotent: true,
+	})
+	if err != nil {
+		return InterestAccrualResponse{}, fmt.Errorf("failed to accrue interest: %w", err)
+	}
+
+	return accrual, nil
+}
+
+// PostInterest is retried safely because accrued interest is only ever paid out once.
+func (cl *Client) PostInterest(ctx context.Context, req PostInterestRequest) (InterestPayoutResponse, error) {
+	var payout InterestPayoutResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/interest/payouts",
+		body:       req,
+		result:     &payout,
+		idempotent: true,
+	})
+	if err != nil {
+		return InterestPayoutResponse{}, fmt.Errorf("failed to post interest: %w", err)
+	}
+
+	return payout, nil
+}
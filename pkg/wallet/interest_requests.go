@@ -0,0 +1,13 @@
+package wallet
+
+// AccrueInterestRequest accrues the interest wallets earned on a day that is over.
+type AccrueInterestRequest struct {
+	// Date is the UTC day to accrue, as YYYY-MM-DD.
+	Date string `binding:"required,datetime=2006-01-02" form:"date" json:"date" url:"date"`
+}
+
+// PostInterestRequest pays out the interest wallets accrued in a month that is over.
+type PostInterestRequest struct {
+	// Month is the UTC month to pay out, as YYYY-MM.
+	Month string `binding:"required,datetime=2006-01" form:"month" json:"month" url:"month"`
+}
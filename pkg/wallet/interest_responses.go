@@ -0,0 +1,14 @@
+package wallet
+
+type InterestAccrualResponse struct {
+	Date string `json:"date"`
+	// Accrued is the number of wallets whose interest accrued by this run. Days already accrued are
+	// not counted again.
+	Accrued int `json:"accrued"`
+}
+
+type InterestPayoutResponse struct {
+	Month string `json:"month"`
+	// Posted is the number of wallets credited with interest by this run.
+	Posted int `json:"posted"`
+}
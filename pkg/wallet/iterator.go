@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"context"
+	"iter"
+)
+
+const iteratorPageLimit = 100
+
+// AllTransactions walks every transaction matching query, following next cursors page by page.
+// Any page/per_page/before set on query is ignored. Iteration stops at the first error, which is yielded.
+func (cl *Client) AllTransactions(ctx context.Context, query ListTransactionsRequest) iter.Seq2[Transaction, error] {
+	return func(yield func(Transaction, error) bool) {
+		query.Paginator = cursorStart(query.Paginator)
+
+		for {
+			page, err := cl.GetTransactions(ctx, query)
+			if err != nil {
+				yield(Transaction{}, err)
+
+				return
+			}
+
+			for _, transaction := range page.Transactions {
+				if !yield(transaction, nil) {
+					return
+				}
+			}
+
+			if page.Metadata.Pagination.NextCursor == nil {
+				return
+			}
+
+			query.After = page.Metadata.Pagination.NextCursor
+		}
+	}
+}
+
+// AllWallets walks every wallet matching query, following next cursors page by page.
+// Any page/per_page/before set on query is ignored. Iteration stops at the first error, which is yielded.
+func (cl *Client) AllWallets(ctx context.Context, query ListWalletsRequest) iter.Seq2[Wallet, error] {
+	return func(yield func(Wallet, error) bool) {
+		query.Paginator = cursorStart(query.Paginator)
+
+		for {
+			page, err := cl.ListWallets(ctx, query)
+			if err != nil {
+				yield(Wallet{}, err)
+
+				return
+			}
+
+			for _, wallet := range page.Wallets {
+				if !yield(wallet, nil) {
+					return
+				}
+			}
+
+			if page.Metadata.Pagination.NextCursor == nil {
+				return
+			}
+
+			query.After = page.Metadata.Pagination.NextCursor
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedServer serves pages of ids, each pointing at the next through its cursor. A page listed in
+// failing answers with an error instead.
+func pagedServer(t *testing.T, key string, pages [][]string, failing int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		index := 0
+		if after := r.URL.Query().Get("after"); after != "" {
+			index = int(after[0] - '0')
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if index == failing {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"code":"invalid_cursor","message":"invalid cursor"}`))
+
+			return
+		}
+
+		items := make([]map[string]string, 0, len(pages[index]))
+		for _, id := range pages[index] {
+			items = append(items, map[string]string{"id": id})
+		}
+
+		page := pagination.Pagination{Count: len(items)}
+		if index+1 < len(pages) {
+			next := string(rune('0' + index + 1))
+			page.NextCursor = &next
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			key:        items,
+			"metadata": Metadata{Pagination: page},
+		}))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestClient_AllTransactions(t *testing.T) {
+	pages := [][]string{{"txn-1", "txn-2"}, {"txn-3", "txn-4"}, {"txn-5"}}
+
+	t.Run("follows next cursors", func(t *testing.T) {
+		server, requests := pagedServer(t, "transactions", pages, -1)
+
+		var walked []string
+
+		for transaction, err := range NewClient(server.URL).AllTransactions(context.Background(), ListTransactionsRequest{}) {
+			require.NoError(t, err)
+
+			walked = append(walked, transaction.ID)
+		}
+
+		assert.Equal(t, []string{"txn-1", "txn-2", "txn-3", "txn-4", "txn-5"}, walked)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("stops fetching when the caller breaks", func(t *testing.T) {
+		server, requests := pagedServer(t, "transactions", pages, -1)
+
+		var walked []string
+
+		for transaction, err := range NewClient(server.URL).AllTransactions(context.Background(), ListTransactionsRequest{}) {
+			require.NoError(t, err)
+
+			walked = append(walked, transaction.ID)
+			if transaction.ID == "txn-3" {
+				break
+			}
+		}
+
+		assert.Equal(t, []string{"txn-1", "txn-2", "txn-3"}, walked)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("yields the error of a failing page", func(t *testing.T) {
+		server, requests := pagedServer(t, "transactions", pages, 1)
+
+		var (
+			walked []string
+			errs   []error
+		)
+
+		for transaction, err := range NewClient(server.URL).AllTransactions(context.Background(), ListTransactionsRequest{}) {
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+
+			walked = append(walked, transaction.ID)
+		}
+
+		assert.Equal(t, []string{"txn-1", "txn-2"}, walked)
+		require.Len(t, errs, 1)
+
+		var apiErr *APIError
+		require.ErrorAs(t, errs[0], &apiErr)
+		assert.Equal(t, types.ErrorCodeInvalidCursor, apiErr.Code)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+}
+
+func TestClient_AllWallets(t *testing.T) {
+	server, requests := pagedServer(t, "wallets", [][]string{{"wallet-1"}, {"wallet-2"}, {"wallet-3"}}, -1)
+
+	var walked []string
+
+	for wallet, err := range NewClient(server.URL).AllWallets(context.Background(), ListWalletsRequest{}) {
+		require.NoError(t, err)
+
+		walked = append(walked, wallet.ID)
+		if len(walked) == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"wallet-1", "wallet-2"}, walked)
+	assert.Equal(t, int32(2), requests.Load())
+}
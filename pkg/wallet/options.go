@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"net/http"
+	"time"
+)
+
+type clientConfig struct {
+	httpClient *http.Client
+	timeout    time.Duration
+	apiKey     string
+	headers    map[string]string
+	retry      RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*clientConfig)
+
+// WithHTTPClient uses the given HTTP client, e.g. to plug in a custom transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithTimeout limits the duration of every single HTTP attempt.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithAPIKey authenticates every request with the given key.
+func WithAPIKey(apiKey string) Option {
+	return func(cfg *clientConfig) {
+		cfg.apiKey = apiKey
+	}
+}
+
+// WithActorID identifies the person on whose behalf requests are made, for the approval trail of
+// transactions and the notes of disputes. It is sent in the X-Actor-ID header.
+func WithActorID(actorID string) Option {
+	return WithHeader("X-Actor-ID", actorID)
+}
+
+// WithHeader adds a header to every request.
+func WithHeader(key, value string) Option {
+	return func(cfg *clientConfig) {
+		if cfg.headers == nil {
+			cfg.headers = map[string]string{}
+		}
+
+		cfg.headers[key] = value
+	}
+}
+
+// WithRetry retries idempotent requests up to maxRetries times on transport errors and on
+// 429/502/503/504 responses, waiting an exponentially growing, jittered delay between
+// minBackoff and maxBackoff. A Retry-After header from the server takes precedence.
+func WithRetry(maxRetries int, minBackoff, maxBackoff time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.retry = RetryPolicy{
+			MaxRetries: maxRetries,
+			MinBackoff: minBackoff,
+			MaxBackoff: maxBackoff,
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryPolicy controls how idempotent requests are retried. The zero value disables retries.
+type RetryPolicy struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) backoff(attempt int, resp *resty.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return min(wait, p.MaxBackoff)
+	}
+
+	wait := p.MinBackoff << attempt
+	if wait <= 0 || wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	// Full jitter keeps clients that failed together from retrying together.
+	if wait > 0 {
+		wait = time.Duration(rand.Int64N(int64(wait))) + p.MinBackoff/2 //nolint:gosec
+	}
+
+	return min(wait, p.MaxBackoff)
+}
+
+func retryAfter(resp *resty.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(resp.Header().Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
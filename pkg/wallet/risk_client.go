@@ -0,0 +1,27 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetRiskDecisions returns the decisions of the risk rules run before transactions were created, along
+// with the rules hit.
+func (cl *Client) GetRiskDecisions(ctx context.Context, query ListRiskDecisionsRequest) (
+	RiskDecisionsResponse, error) {
+	var decisions RiskDecisionsResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       "/risk/decisions",
+		query:      query,
+		result:     &decisions,
+		idempotent: true,
+	})
+	if err != nil {
+		return RiskDecisionsResponse{}, fmt.Errorf("failed to get risk decisions: %w", err)
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,18 @@
+package wallet
+
+import (
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+//nolint:lll
+type ListRiskDecisionsRequest struct {
+	// Wallet IDs to filter.
+	WalletIDs []string `binding:"omitempty" form:"wallet_ids,omitempty" json:"wallet_ids,omitempty" url:"wallet_ids,omitempty"`
+	// Transaction IDs to filter. Denied transactions were never created, so they have no ID.
+	TransactionIDs []string `binding:"omitempty" form:"transaction_ids,omitempty" json:"transaction_ids,omitempty" url:"transaction_ids,omitempty"`
+	// Decisions to filter.
+	Decisions types.RiskDecisions `binding:"omitempty,riskDecisionsEnum" form:"decisions,omitempty" json:"decisions,omitempty" url:"decisions,omitempty"`
+
+	pagination.Paginator
+}
@@ -0,0 +1,34 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+)
+
+// RiskDecision is the outcome of the risk rules run before a transaction was created.
+type RiskDecision struct {
+	ID       string `json:"id"`
+	WalletID string `json:"wallet_id"`
+	// TransactionID is the transaction created, which denied transactions have none of.
+	TransactionID  *string               `json:"transaction_id,omitempty"`
+	IdempotencyKey string                `json:"idempotency_key"`
+	Type           types.TransactionType `json:"type"`
+	Amount         int                   `json:"amount"`
+	Decision       types.RiskDecision    `json:"decision"`
+	// Hits are the rules that did not allow the transaction.
+	Hits      []RiskRuleHit `json:"hits"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// RiskRuleHit is the verdict of a rule that did not allow a transaction.
+type RiskRuleHit struct {
+	Rule     string             `json:"rule"`
+	Decision types.RiskDecision `json:"decision"`
+	Reason   string             `json:"reason"`
+}
+
+type RiskDecisionsResponse struct {
+	RiskDecisions []RiskDecision `json:"risk_decisions"`
+	Metadata      Metadata       `json:"metadata"`
+}
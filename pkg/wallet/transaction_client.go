@@ -3,18 +3,19 @@ package wallet
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 )
 
 func (cl *Client) GetTransactionByID(ctx context.Context, id string) (TransactionResponse, error) {
 	var transaction TransactionResponse
 
-	url := cl.buildUrl(fmt.Sprintf("/transactions/%s", id), nil)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetResult(&transaction).
-		Get(url)
-
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/transactions/%s", url.PathEscape(id)),
+		result:     &transaction,
+		idempotent: true,
+	})
 	if err != nil {
 		return TransactionResponse{}, fmt.Errorf("failed to get transaction by ID: %w", err)
 	}
@@ -25,13 +26,17 @@ func (cl *Client) GetTransactionByID(ctx context.Context, id string) (Transactio
 func (cl *Client) GetTransactions(ctx context.Context, query ListTransactionsRequest) (TransactionsResponse, error) {
 	var transactions TransactionsResponse
 
-	url := cl.buildUrl("/transactions", query)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetResult(&transactions).
-		Get(url)
+	if err := query.Validate(); err != nil {
+		return TransactionsResponse{}, fmt.Errorf("invalid transactions query: %w", err)
+	}
 
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       "/transactions",
+		query:      query,
+		result:     &transactions,
+		idempotent: true,
+	})
 	if err != nil {
 		return TransactionsResponse{}, fmt.Errorf("failed to get transactions: %w", err)
 	}
@@ -39,17 +44,39 @@ func (cl *Client) GetTransactions(ctx context.Context, query ListTransactionsReq
 	return transactions, nil
 }
 
+// QuoteTransaction previews the fees a transaction would be charged without creating it.
+func (cl *Client) QuoteTransaction(ctx context.Context, req QuoteTransactionRequest) (TransactionQuoteResponse, error) {
+	var quote TransactionQuoteResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/transactions/quote",
+		body:       req,
+		result:     &quote,
+		idempotent: true,
+	})
+	if err != nil {
+		return TransactionQuoteResponse{}, fmt.Errorf("failed to quote transaction: %w", err)
+	}
+
+	return quote, nil
+}
+
+// CreateTransaction is retried safely because the server deduplicates on req.IdempotencyKey.
 func (cl *Client) CreateTransaction(ctx context.Context, req CreateTransactionRequest) (TransactionResponse, error) {
 	var transaction TransactionResponse
 
-	url := cl.buildUrl("/transactions", nil)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetBody(req).
-		SetResult(&transaction).
-		Post(url)
+	if err := req.Validate(); err != nil {
+		return TransactionResponse{}, fmt.Errorf("invalid transaction: %w", err)
+	}
 
+	err := cl.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/transactions",
+		body:       req,
+		result:     &transaction,
+		idempotent: req.IdempotencyKey != "",
+	})
 	if err != nil {
 		return TransactionResponse{}, fmt.Errorf("failed to create transaction: %w", err)
 	}
@@ -57,21 +84,78 @@ func (cl *Client) CreateTransaction(ctx context.Context, req CreateTransactionRe
 	return transaction, nil
 }
 
+// UpdateTransactionStatus moves the transaction to a new status. It is retried unless req.Version
+// is set, in which case a retry could be rejected by its own earlier success.
 func (cl *Client) UpdateTransactionStatus(ctx context.Context, id string, req UpdateTransactionStatusRequest) (
 	TransactionResponse, error) {
 	var transaction TransactionResponse
 
-	url := cl.buildUrl(fmt.Sprintf("/transactions/%s/status", id), nil)
+	err := cl.do(ctx, request{
+		method:     http.MethodPatch,
+		path:       fmt.Sprintf("/transactions/%s/status", url.PathEscape(id)),
+		body:       req,
+		result:     &transaction,
+		ifMatch:    req.Version,
+		idempotent: req.Version == nil,
+	})
+	if err != nil {
+		return TransactionResponse{}, fmt.Errorf("failed to update transaction status: %w", err)
+	}
 
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetBody(req).
-		SetResult(&transaction).
-		Patch(url)
+	return transaction, nil
+}
 
+// ApproveTransaction approves a transaction awaiting approval on behalf of the actor set with WithActorID.
+// It is not retried, as a retry would be rejected by its own earlier success.
+func (cl *Client) ApproveTransaction(ctx context.Context, id string, req ReviewTransactionRequest) (
+	TransactionResponse, error) {
+	var transaction TransactionResponse
+
+	err := cl.do(ctx, request{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/transactions/%s/approve", url.PathEscape(id)),
+		body:   req,
+		result: &transaction,
+	})
 	if err != nil {
-		return TransactionResponse{}, fmt.Errorf("failed to update transaction status: %w", err)
+		return TransactionResponse{}, fmt.Errorf("failed to approve transaction: %w", err)
 	}
 
 	return transaction, nil
 }
+
+// RejectTransaction rejects a transaction awaiting approval on behalf of the actor set with WithActorID.
+// It is not retried, as a retry would be rejected by its own earlier success.
+func (cl *Client) RejectTransaction(ctx context.Context, id string, req ReviewTransactionRequest) (
+	TransactionResponse, error) {
+	var transaction TransactionResponse
+
+	err := cl.do(ctx, request{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/transactions/%s/reject", url.PathEscape(id)),
+		body:   req,
+		result: &transaction,
+	})
+	if err != nil {
+		return TransactionResponse{}, fmt.Errorf("failed to reject transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// ListTransactionApprovals returns the approval trail of a transaction, oldest entry first.
+func (cl *Client) ListTransactionApprovals(ctx context.Context, id string) (TransactionApprovalsResponse, error) {
+	var approvals TransactionApprovalsResponse
+
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/transactions/%s/approvals", url.PathEscape(id)),
+		result:     &approvals,
+		idempotent: true,
+	})
+	if err != nil {
+		return TransactionApprovalsResponse{}, fmt.Errorf("failed to list transaction approvals: %w", err)
+	}
+
+	return approvals, nil
+}
@@ -1,13 +1,16 @@
 package wallet
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/pagination"
 	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
 )
 
-//nolint:godox
+//nolint:godox,lll
 type CreateTransactionRequest struct {
 	// Unique identifier for the wallet.
 	WalletID string `binding:"required" form:"wallet_id" json:"wallet_id" url:"wallet_id"`
@@ -19,6 +22,22 @@ type CreateTransactionRequest struct {
 	Type types.TransactionType `binding:"required,transactionTypeEnum" form:"type" json:"type" url:"type"`
 	// Idempotency key for the transaction.
 	IdempotencyKey string `binding:"required" form:"idempotency_key" json:"idempotency_key" url:"idempotency_key"`
+	// Adjustment marks a manual correction made by an operator. Adjustments always await approval.
+	Adjustment bool `binding:"omitempty" form:"adjustment,omitempty" json:"adjustment,omitempty" url:"adjustment,omitempty"`
+	// Bucket is the balance bucket a credit funds. Defaults to cash.
+	Bucket *types.BalanceBucket `binding:"omitempty,balanceBucketEnum" form:"bucket,omitempty" json:"bucket,omitempty" url:"bucket,omitempty"`
+	// ExpiresAt is when what is left of a credit is written off. Promotional credits get the configured
+	// lifetime when it is not set.
+	ExpiresAt *time.Time `binding:"omitempty" form:"expires_at,omitempty" json:"expires_at,omitempty" url:"expires_at,omitempty"`
+}
+
+// Validate checks the constraints between fields that binding tags cannot express.
+func (r CreateTransactionRequest) Validate() error {
+	if r.Type != types.TransactionTypeCredit && (r.Bucket != nil || r.ExpiresAt != nil) {
+		return errors.New("bucket and expires_at only apply to credits")
+	}
+
+	return nil
 }
 
 //nolint:lll
@@ -35,10 +54,58 @@ type ListTransactionsRequest struct {
 	CreatedAtFrom *time.Time `binding:"omitempty" form:"created_at_from,omitempty" json:"created_at_from,omitempty" url:"created_at_from,omitempty"`
 	// CreatedAtTo is the end date for filtering transactions.
 	CreatedAtTo *time.Time `binding:"omitempty" form:"created_at_to,omitempty" json:"created_at_to,omitempty" url:"created_at_to,omitempty"`
+	// UpdatedAtFrom is the start date for filtering transactions by last update.
+	UpdatedAtFrom *time.Time `binding:"omitempty" form:"updated_at_from,omitempty" json:"updated_at_from,omitempty" url:"updated_at_from,omitempty"`
+	// UpdatedAtTo is the end date for filtering transactions by last update.
+	UpdatedAtTo *time.Time `binding:"omitempty" form:"updated_at_to,omitempty" json:"updated_at_to,omitempty" url:"updated_at_to,omitempty"`
+	// AmountMin is the smallest transaction amount to include.
+	AmountMin *int `binding:"omitempty,gte=0" form:"amount_min,omitempty" json:"amount_min,omitempty" url:"amount_min,omitempty"`
+	// AmountMax is the largest transaction amount to include.
+	AmountMax *int `binding:"omitempty,gte=0" form:"amount_max,omitempty" json:"amount_max,omitempty" url:"amount_max,omitempty"`
+	// Note is a case-insensitive substring the transaction note must contain.
+	Note *string `binding:"omitempty,min=1,max=255" form:"note,omitempty" json:"note,omitempty" url:"note,omitempty"`
+	// Sort orders the results by amount, created_at or updated_at. Prefix with "-" for descending order.
+	Sort *types.TransactionSort `binding:"omitempty,transactionSortEnum" form:"sort,omitempty" json:"sort,omitempty" url:"sort,omitempty"`
 
 	pagination.Paginator
 }
 
+// Validate checks the constraints between fields that binding tags cannot express.
+func (r ListTransactionsRequest) Validate() error {
+	if r.AmountMin != nil && r.AmountMax != nil && *r.AmountMin > *r.AmountMax {
+		return errors.New("amount_min must not be greater than amount_max")
+	}
+
+	if r.CreatedAtFrom != nil && r.CreatedAtTo != nil && r.CreatedAtFrom.After(*r.CreatedAtTo) {
+		return errors.New("created_at_from must not be after created_at_to")
+	}
+
+	if r.UpdatedAtFrom != nil && r.UpdatedAtTo != nil && r.UpdatedAtFrom.After(*r.UpdatedAtTo) {
+		return errors.New("updated_at_from must not be after updated_at_to")
+	}
+
+	if r.Sort != nil && !slices.Contains(types.GetTransactionSorts(), *r.Sort) {
+		return fmt.Errorf("sort must be one of %v", types.GetTransactionSorts())
+	}
+
+	return nil
+}
+
+// QuoteTransactionRequest previews the fees of a transaction without creating it.
+type QuoteTransactionRequest struct {
+	WalletID string                `binding:"required" form:"wallet_id" json:"wallet_id" url:"wallet_id"`
+	Amount   int                   `binding:"required,gt=0" form:"amount" json:"amount" url:"amount"`
+	Type     types.TransactionType `binding:"required,transactionTypeEnum" form:"type" json:"type" url:"type"`
+}
+
+// ReviewTransactionRequest approves or rejects a transaction awaiting approval.
+type ReviewTransactionRequest struct {
+	// Reason for the decision, kept in the approval trail.
+	Reason string `binding:"required,max=500" form:"reason" json:"reason" url:"reason"`
+}
+
 type UpdateTransactionStatusRequest struct {
 	Status types.TransactionStatus `binding:"required,transactionStatusEnum" form:"status" json:"status" url:"status"`
+	// Version, when set, is sent as If-Match so the update only applies to that transaction version.
+	Version *int `form:"-" json:"-" url:"-"`
 }
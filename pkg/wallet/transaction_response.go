@@ -7,14 +7,23 @@ import (
 )
 
 type Transaction struct {
-	ID        string                  `json:"id"`
-	WalletID  string                  `json:"wallet_id"`
-	Amount    int                     `json:"amount"`
-	Note      *string                 `json:"note,omitempty"`
-	Type      types.TransactionType   `json:"type"`
-	Status    types.TransactionStatus `json:"status"`
-	CreatedAt time.Time               `json:"created_at"`
-	UpdatedAt time.Time               `json:"updated_at"`
+	ID       string `json:"id"`
+	WalletID string `json:"wallet_id"`
+	// ParentID is the transaction a fee transaction was charged on.
+	ParentID *string                 `json:"parent_id,omitempty"`
+	Amount   int                     `json:"amount"`
+	Note     *string                 `json:"note,omitempty"`
+	Type     types.TransactionType   `json:"type"`
+	Status   types.TransactionStatus `json:"status"`
+	// Bucket is the balance bucket a credit funds.
+	Bucket types.BalanceBucket `json:"bucket,omitempty"`
+	// ExpiresAt is when what is left of a credit is written off.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	// Fees are the fee transactions charged on the transaction, returned when it is created.
+	Fees []Transaction `json:"fees,omitempty"`
 }
 
 type TransactionResponse struct {
@@ -25,3 +34,38 @@ type TransactionsResponse struct {
 	Transactions []Transaction `json:"transactions"`
 	Metadata     Metadata      `json:"metadata"`
 }
+
+// TransactionApproval is an entry of the approval trail of a transaction.
+type TransactionApproval struct {
+	ID            string               `json:"id"`
+	TransactionID string               `json:"transaction_id"`
+	Action        types.ApprovalAction `json:"action"`
+	ActorID       string               `json:"actor_id"`
+	Reason        string               `json:"reason"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+type TransactionApprovalsResponse struct {
+	Approvals []TransactionApproval `json:"approvals"`
+}
+
+// Fee is a fee charged on a transaction by a rule of the fee schedule.
+type Fee struct {
+	Rule   string `json:"rule"`
+	Amount int    `json:"amount"`
+}
+
+type TransactionQuote struct {
+	WalletID  string                `json:"wallet_id"`
+	Currency  types.Currency        `json:"currency"`
+	Type      types.TransactionType `json:"type"`
+	Amount    int                   `json:"amount"`
+	Fees      []Fee                 `json:"fees"`
+	TotalFees int                   `json:"total_fees"`
+	// BalanceChange is how the wallet balance changes once the transaction and its fees complete.
+	BalanceChange int `json:"balance_change"`
+}
+
+type TransactionQuoteResponse struct {
+	Quote TransactionQuote `json:"quote"`
+}
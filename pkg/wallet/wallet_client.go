@@ -3,18 +3,19 @@ package wallet
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 )
 
 func (cl *Client) GetWalletByID(ctx context.Context, id string) (WalletResponse, error) {
 	var wallet WalletResponse
 
-	url := cl.buildUrl(fmt.Sprintf("/wallets/%s", id), nil)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetResult(&wallet).
-		Get(url)
-
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/wallets/%s", url.PathEscape(id)),
+		result:     &wallet,
+		idempotent: true,
+	})
 	if err != nil {
 		return WalletResponse{}, fmt.Errorf("failed to get wallet by ID: %w", err)
 	}
@@ -22,36 +23,37 @@ func (cl *Client) GetWalletByID(ctx context.Context, id string) (WalletResponse,
 	return wallet, nil
 }
 
+// UpdateWalletStatus sets the wallet status. Setting a status is idempotent, so the call is retried
+// unless req.Version is set, in which case a retry could be rejected by its own earlier success.
 func (cl *Client) UpdateWalletStatus(ctx context.Context, id string, req UpdateWalletStatusRequest) (
 	WalletResponse, error) {
 	var wallet WalletResponse
 
-	url := cl.buildUrl(fmt.Sprintf("/wallets/%s/status", id), nil)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetBody(req).
-		SetResult(&wallet).
-		Patch(url)
-
+	err := cl.do(ctx, request{
+		method:     http.MethodPatch,
+		path:       fmt.Sprintf("/wallets/%s/status", url.PathEscape(id)),
+		body:       req,
+		result:     &wallet,
+		ifMatch:    req.Version,
+		idempotent: req.Version == nil,
+	})
 	if err != nil {
-		return WalletResponse{}, err
+		return WalletResponse{}, fmt.Errorf("failed to update wallet status: %w", err)
 	}
 
 	return wallet, nil
 }
 
+// CreateWallet is never retried: a retry after a lost response would fail as a duplicate wallet.
 func (cl *Client) CreateWallet(ctx context.Context, req CreateWalletRequest) (WalletResponse, error) {
 	var wallet WalletResponse
 
-	url := cl.buildUrl("/wallets", nil)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetBody(req).
-		SetResult(&wallet).
-		Post(url)
-
+	err := cl.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/wallets",
+		body:   req,
+		result: &wallet,
+	})
 	if err != nil {
 		return WalletResponse{}, fmt.Errorf("failed to create wallet: %w", err)
 	}
@@ -62,13 +64,13 @@ func (cl *Client) CreateWallet(ctx context.Context, req CreateWalletRequest) (Wa
 func (cl *Client) ListWallets(ctx context.Context, query ListWalletsRequest) (WalletsResponse, error) {
 	var wallets WalletsResponse
 
-	url := cl.buildUrl("/wallets", query)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetResult(&wallets).
-		Get(url)
-
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       "/wallets",
+		query:      query,
+		result:     &wallets,
+		idempotent: true,
+	})
 	if err != nil {
 		return WalletsResponse{}, fmt.Errorf("failed to list wallets: %w", err)
 	}
@@ -79,13 +81,12 @@ func (cl *Client) ListWallets(ctx context.Context, query ListWalletsRequest) (Wa
 func (cl *Client) GetWalletWithBalance(ctx context.Context, id string) (WalletResponse, error) {
 	var wallet WalletResponse
 
-	url := cl.buildUrl(fmt.Sprintf("/wallets/%s/balance", id), nil)
-
-	_, err := cl.httpClient.R().
-		SetContext(ctx).
-		SetResult(&wallet).
-		Get(url)
-
+	err := cl.do(ctx, request{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/wallets/%s/balance", url.PathEscape(id)),
+		result:     &wallet,
+		idempotent: true,
+	})
 	if err != nil {
 		return WalletResponse{}, fmt.Errorf("failed to get wallet with balance: %w", err)
 	}
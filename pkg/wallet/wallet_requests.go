@@ -10,6 +10,8 @@ type CreateWalletRequest struct {
 	OwnerID string `binding:"required" form:"owner_id" json:"owner_id" url:"owner_id"`
 	// Currency of the wallet.
 	Currency types.Currency `binding:"required,currencyEnum" form:"currency" json:"currency" url:"currency"`
+	// Product of the wallet, such as savings. An owner may hold a wallet of each product in a currency.
+	Product string `binding:"omitempty,max=32" form:"product,omitempty" json:"product,omitempty" url:"product,omitempty"`
 }
 
 //nolint:lll
@@ -20,10 +22,14 @@ type ListWalletsRequest struct {
 	OwnerIDs []string `binding:"omitempty" form:"owner_ids,omitempty" json:"owner_ids,omitempty" url:"owner_ids,omitempty"`
 	// Currencies to filter.
 	Currencies types.Currencies `binding:"omitempty,currenciesEnum" form:"currencies,omitempty" json:"currencies,omitempty" url:"currencies,omitempty"`
+	// Products to filter.
+	Products []string `binding:"omitempty" form:"products,omitempty" json:"products,omitempty" url:"products,omitempty"`
 
 	pagination.Paginator
 }
 
 type UpdateWalletStatusRequest struct {
 	Status types.WalletStatus `binding:"required,walletStatusEnum" form:"status" json:"status" url:"status"`
+	// Version, when set, is sent as If-Match so the update only applies to that wallet version.
+	Version *int `form:"-" json:"-" url:"-"`
 }
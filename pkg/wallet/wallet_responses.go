@@ -7,13 +7,33 @@ import (
 )
 
 type Wallet struct {
-	ID        string             `json:"id"`
-	OwnerID   string             `json:"owner_id"`
-	Currency  types.Currency     `json:"currency"`
-	Status    types.WalletStatus `json:"status"`
-	Balance   *int               `json:"balance,omitempty"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at"`
+	ID       string             `json:"id"`
+	OwnerID  string             `json:"owner_id"`
+	Currency types.Currency     `json:"currency"`
+	Product  string             `json:"product,omitempty"`
+	Status   types.WalletStatus `json:"status"`
+	Balance  *int               `json:"balance,omitempty"`
+	// Buckets split the balance by the buckets of the credits funding it.
+	Buckets   []BalanceBucket `json:"buckets,omitempty"`
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// BalanceBucket is the part of a wallet balance funded by the credits of one bucket.
+type BalanceBucket struct {
+	Bucket  types.BalanceBucket `json:"bucket"`
+	Balance int                 `json:"balance"`
+	// Available leaves out the credits that expired but were not yet written off.
+	Available int `json:"available"`
+	// Expiring are the credits of the bucket that expire, soonest first, with what is left of them.
+	Expiring []ExpiringCredit `json:"expiring,omitempty"`
+}
+
+type ExpiringCredit struct {
+	CreditID  string    `json:"credit_id"`
+	Amount    int       `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type WalletResponse struct {
@@ -0,0 +1,284 @@
+package wallettest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunConformance checks that a wallet service behaves as the client expects. It runs against the fake
+// as well as the real service, which keeps the two from drifting apart. newClient is called once per
+// subtest; every subtest creates its own wallets, so the service does not need to start empty.
+func RunConformance(t *testing.T, newClient func(t *testing.T) *wallet.Client) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, cl *wallet.Client)
+	}{
+		{name: "create and get wallet", run: testCreateAndGetWallet},
+		{name: "duplicate wallet", run: testDuplicateWallet},
+		{name: "validation error", run: testValidationError},
+		{name: "wallet not found", run: testWalletNotFound},
+		{name: "pending credit does not count towards balance", run: testPendingCredit},
+		{name: "insufficient funds", run: testInsufficientFunds},
+		{name: "failed debit is refunded", run: testFailedDebit},
+		{name: "idempotent transaction", run: testIdempotentTransaction},
+		{name: "invalid status transition", run: testInvalidTransition},
+		{name: "inactive wallet", run: testInactiveWallet},
+		{name: "version conflict", run: testVersionConflict},
+		{name: "cursor pagination", run: testCursorPagination},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newClient(t))
+		})
+	}
+}
+
+func testCreateAndGetWallet(t *testing.T, cl *wallet.Client) {
+	created := createWallet(t, cl)
+	assert.Equal(t, types.WalletStatusActive, created.Status)
+	assert.Equal(t, 1, created.Version)
+
+	got, err := cl.GetWalletByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, got.ID)
+	assert.Equal(t, created.OwnerID, got.OwnerID)
+	assert.Equal(t, types.CurrencyUSD, got.Currency)
+}
+
+func testDuplicateWallet(t *testing.T, cl *wallet.Client) {
+	created := createWallet(t, cl)
+
+	_, err := cl.CreateWallet(context.Background(), wallet.CreateWalletRequest{
+		OwnerID:  created.OwnerID,
+		Currency: created.Currency,
+	})
+	assertAPIError(t, err, http.StatusConflict, types.ErrorCodeDuplicateWallet)
+}
+
+func testValidationError(t *testing.T, cl *wallet.Client) {
+	_, err := cl.CreateWallet(context.Background(), wallet.CreateWalletRequest{OwnerID: uniqueID()})
+
+	apiErr := assertAPIError(t, err, http.StatusUnprocessableEntity, types.ErrorCodeValidation)
+	assert.NotEmpty(t, apiErr.Errors)
+}
+
+func testWalletNotFound(t *testing.T, cl *wallet.Client) {
+	_, err := cl.GetWalletByID(context.Background(), uniqueID())
+	assertAPIError(t, err, http.StatusNotFound, types.ErrorCodeWalletNotFound)
+}
+
+func testPendingCredit(t *testing.T, cl *wallet.Client) {
+	w := createWallet(t, cl)
+
+	credit := createTransaction(t, cl, w.ID, types.TransactionTypeCredit, 500)
+	assert.Equal(t, types.TransactionStatusPending, credit.Status)
+	assertBalance(t, cl, w.ID, 0)
+
+	updateTransactionStatus(t, cl, credit.ID, types.TransactionStatusCompleted)
+	assertBalance(t, cl, w.ID, 500)
+}
+
+func testInsufficientFunds(t *testing.T, cl *wallet.Client) {
+	w := fundedWallet(t, cl, 100)
+
+	_, err := cl.CreateTransaction(context.Background(), wallet.CreateTransactionRequest{
+		WalletID:       w.ID,
+		Amount:         101,
+		Type:           types.TransactionTypeDebit,
+		IdempotencyKey: uniqueID(),
+	})
+	assertAPIError(t, err, http.StatusUnprocessableEntity, types.ErrorCodeInsufficientFunds)
+	assertBalance(t, cl, w.ID, 100)
+}
+
+func testFailedDebit(t *testing.T, cl *wallet.Client) {
+	w := fundedWallet(t, cl, 100)
+
+	debit := createTransaction(t, cl, w.ID, types.TransactionTypeDebit, 40)
+	assertBalance(t, cl, w.ID, 60)
+
+	updateTransactionStatus(t, cl, debit.ID, types.TransactionStatusFailed)
+	assertBalance(t, cl, w.ID, 100)
+}
+
+func testIdempotentTransaction(t *testing.T, cl *wallet.Client) {
+	w := fundedWallet(t, cl, 100)
+	req := wallet.CreateTransactionRequest{
+		WalletID:       w.ID,
+		Amount:         30,
+		Type:           types.TransactionTypeDebit,
+		IdempotencyKey: uniqueID(),
+	}
+
+	first, err := cl.CreateTransaction(context.Background(), req)
+	require.NoError(t, err)
+
+	second, err := cl.CreateTransaction(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+	assertBalance(t, cl, w.ID, 70)
+}
+
+func testInvalidTransition(t *testing.T, cl *wallet.Client) {
+	w := fundedWallet(t, cl, 100)
+	debit := createTransaction(t, cl, w.ID, types.TransactionTypeDebit, 10)
+	updateTransactionStatus(t, cl, debit.ID, types.TransactionStatusCompleted)
+
+	_, err := cl.UpdateTransactionStatus(context.Background(), debit.ID, wallet.UpdateTransactionStatusRequest{
+		Status: types.TransactionStatusFailed,
+	})
+	assertAPIError(t, err, http.StatusConflict, types.ErrorCodeInvalidTransition)
+}
+
+func testInactiveWallet(t *testing.T, cl *wallet.Client) {
+	w := createWallet(t, cl)
+
+	_, err := cl.UpdateWalletStatus(context.Background(), w.ID, wallet.UpdateWalletStatusRequest{
+		Status: types.WalletStatusInactive,
+	})
+	require.NoError(t, err)
+
+	_, err = cl.CreateTransaction(context.Background(), wallet.CreateTransactionRequest{
+		WalletID:       w.ID,
+		Amount:         10,
+		Type:           types.TransactionTypeCredit,
+		IdempotencyKey: uniqueID(),
+	})
+	assertAPIError(t, err, http.StatusUnprocessableEntity, types.ErrorCodeWalletNotActive)
+}
+
+func testVersionConflict(t *testing.T, cl *wallet.Client) {
+	w := createWallet(t, cl)
+	staleVersion := w.Version
+
+	updated, err := cl.UpdateWalletStatus(context.Background(), w.ID, wallet.UpdateWalletStatusRequest{
+		Status:  types.WalletStatusFrozen,
+		Version: &staleVersion,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, staleVersion+1, updated.Version)
+
+	_, err = cl.UpdateWalletStatus(context.Background(), w.ID, wallet.UpdateWalletStatusRequest{
+		Status:  types.WalletStatusActive,
+		Version: &staleVersion,
+	})
+	assertAPIError(t, err, http.StatusConflict, types.ErrorCodeVersionConflict)
+
+	var conflictErr *wallet.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+}
+
+func testCursorPagination(t *testing.T, cl *wallet.Client) {
+	w := fundedWallet(t, cl, 1000)
+
+	var created []string
+	for range 4 {
+		created = append(created, createTransaction(t, cl, w.ID, types.TransactionTypeDebit, 1).ID)
+	}
+
+	limit := 2
+	query := wallet.ListTransactionsRequest{
+		WalletIDs: []string{w.ID},
+		Types:     types.TransactionTypes{types.TransactionTypeDebit},
+	}
+	query.Limit = &limit
+
+	page, err := cl.GetTransactions(context.Background(), query)
+	require.NoError(t, err)
+	require.Len(t, page.Transactions, 2)
+	require.NotNil(t, page.Metadata.Pagination.NextCursor)
+	assert.Nil(t, page.Metadata.Pagination.PrevCursor)
+
+	var listed []string
+	for transaction, err := range cl.AllTransactions(context.Background(), query) {
+		require.NoError(t, err)
+
+		listed = append(listed, transaction.ID)
+	}
+
+	// Newest first by default.
+	assert.Equal(t, []string{created[3], created[2], created[1], created[0]}, listed)
+}
+
+func createWallet(t *testing.T, cl *wallet.Client) wallet.Wallet {
+	t.Helper()
+
+	res, err := cl.CreateWallet(context.Background(), wallet.CreateWalletRequest{
+		OwnerID:  uniqueID(),
+		Currency: types.CurrencyUSD,
+	})
+	require.NoError(t, err)
+
+	return res.Wallet
+}
+
+func fundedWallet(t *testing.T, cl *wallet.Client, amount int) wallet.Wallet {
+	t.Helper()
+
+	w := createWallet(t, cl)
+	credit := createTransaction(t, cl, w.ID, types.TransactionTypeCredit, amount)
+	updateTransactionStatus(t, cl, credit.ID, types.TransactionStatusCompleted)
+
+	return w
+}
+
+func createTransaction(t *testing.T, cl *wallet.Client, walletID string, transactionType types.TransactionType,
+	amount int) wallet.Transaction {
+	t.Helper()
+
+	res, err := cl.CreateTransaction(context.Background(), wallet.CreateTransactionRequest{
+		WalletID:       walletID,
+		Amount:         amount,
+		Type:           transactionType,
+		IdempotencyKey: uniqueID(),
+	})
+	require.NoError(t, err)
+
+	return res.Transaction
+}
+
+func updateTransactionStatus(t *testing.T, cl *wallet.Client, id string, status types.TransactionStatus) {
+	t.Helper()
+
+	_, err := cl.UpdateTransactionStatus(context.Background(), id, wallet.UpdateTransactionStatusRequest{
+		Status: status,
+	})
+	require.NoError(t, err)
+}
+
+func assertBalance(t *testing.T, cl *wallet.Client, walletID string, expected int) {
+	t.Helper()
+
+	res, err := cl.GetWalletWithBalance(context.Background(), walletID)
+	require.NoError(t, err)
+	require.NotNil(t, res.Balance)
+	assert.Equal(t, expected, *res.Balance)
+}
+
+func assertAPIError(t *testing.T, err error, status int, code types.ErrorCode) *wallet.APIError {
+	t.Helper()
+
+	var apiErr *wallet.APIError
+	require.True(t, errors.As(err, &apiErr), "expected *wallet.APIError, got %v", err)
+	assert.Equal(t, status, apiErr.StatusCode)
+	assert.Equal(t, code, apiErr.Code)
+
+	return apiErr
+}
+
+func uniqueID() string {
+	return ulid.GenerateID(time.Now())
+}
@@ -0,0 +1,145 @@
+// Package wallettest provides an in-process fake of the wallet service for testing code that uses
+// the wallet client. The fake runs the real controllers and services on in-memory storage, so it
+// enforces the same validation, status transitions, idempotency and balance rules as the service.
+package wallettest
+
+import (
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/router"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/storage/memory"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/apierror"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/http/middleware"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/gin-gonic/gin"
+)
+
+// Server is a fake wallet service listening on a local address.
+type Server struct {
+	*httptest.Server
+
+	tb       testing.TB
+	store    *memory.Store
+	mu       sync.Mutex
+	failures []*Failure
+}
+
+// NewServer starts a fake wallet service with empty storage. It is closed when the test finishes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	if err := types.RegisterValidations(); err != nil {
+		tb.Fatalf("wallettest: failed to register validations: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	s := &Server{
+		tb:    tb,
+		store: memory.New(time.Now),
+	}
+
+	engine := gin.New()
+	// Like the server, so the services see the caller set by middleware.Actor.
+	engine.ContextWithFallback = true
+	engine.Use(gin.Recovery(), middleware.Actor, s.injectFailures)
+
+	router.Register(engine.Group("api/v1"), router.NewServices(router.Dependencies{
+		Wallets:      s.store.Wallets(),
+		Transactions: s.store.Transactions(),
+		Approvals:    s.store.Approvals(),
+		Interest:     s.store.Interest(),
+		Disputes:     s.store.Disputes(),
+		Risk:         s.store.Risk(),
+		Cache:        s.store.Cache(),
+		Locker:       s.store.Locker(),
+		Now:          time.Now,
+	}))
+
+	s.Server = httptest.NewServer(engine)
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// BaseURL is the URL to pass to wallet.NewClient.
+func (s *Server) BaseURL() string {
+	return s.URL + "/api"
+}
+
+// Client returns a wallet client talking to the fake.
+func (s *Server) Client(opts ...wallet.Option) *wallet.Client {
+	return wallet.NewClient(s.BaseURL(), opts...)
+}
+
+// Failure makes matching requests fail with the given error response before they reach the service.
+type Failure struct {
+	// Method matches the HTTP method, e.g. http.MethodPost. Empty matches every method.
+	Method string
+	// Route matches the route pattern as documented, e.g. "/v1/transactions/:id". Empty matches every route.
+	Route string
+	// Status is the HTTP status to respond with.
+	Status int
+	// Code is the error code to respond with.
+	Code types.ErrorCode
+	// Times is how many matching requests fail. Zero means once.
+	Times int
+}
+
+// Fail injects a failure. Failures are matched in the order they were added.
+func (s *Server) Fail(failure Failure) {
+	if failure.Times < 1 {
+		failure.Times = 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure)
+}
+
+// SetStorageFailure makes every storage call fail with err, as if the database and cache were down.
+// The service answers such requests the way it answers real outages. Passing nil restores storage.
+func (s *Server) SetStorageFailure(err error) {
+	s.store.SetFailure(err)
+}
+
+func (s *Server) injectFailures(c *gin.Context) {
+	failure := s.takeFailure(c.Request.Method, strings.TrimPrefix(c.FullPath(), "/api"))
+	if failure == nil {
+		c.Next()
+
+		return
+	}
+
+	c.AbortWithStatusJSON(failure.Status, apierror.Error{
+		HttpCode: failure.Status,
+		Code:     failure.Code,
+		Message:  "failure injected by wallettest",
+	})
+}
+
+func (s *Server) takeFailure(method, route string) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, failure := range s.failures {
+		if (failure.Method != "" && failure.Method != method) || (failure.Route != "" && failure.Route != route) {
+			continue
+		}
+
+		failure.Times--
+		if failure.Times == 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+
+		return failure
+	}
+
+	return nil
+}
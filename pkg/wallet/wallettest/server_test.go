@@ -0,0 +1,97 @@
+package wallettest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet/wallettest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	wallettest.RunConformance(t, func(t *testing.T) *wallet.Client {
+		return wallettest.NewServer(t).Client()
+	})
+}
+
+func TestServer_SeedAndInspect(t *testing.T) {
+	server := wallettest.NewServer(t)
+	seeded := server.SeedWallet(wallettest.SeedWallet{Balance: 250})
+
+	res, err := server.Client().GetWalletWithBalance(context.Background(), seeded.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 250, *res.Balance)
+
+	_, err = server.Client().CreateTransaction(context.Background(), wallet.CreateTransactionRequest{
+		WalletID:       seeded.ID,
+		Amount:         50,
+		Type:           types.TransactionTypeDebit,
+		IdempotencyKey: "debit-1",
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, server.Transactions(seeded.ID), 2)
+	assert.Equal(t, 200, server.Balance(seeded.ID))
+
+	stored, found := server.Wallet(seeded.ID)
+	require.True(t, found)
+	assert.Equal(t, types.WalletStatusActive, stored.Status)
+}
+
+func TestServer_Failures(t *testing.T) {
+	tests := []struct {
+		name             string
+		failure          *wallettest.Failure
+		storageFailure   error
+		expectedStatus   int
+		expectedCode     types.ErrorCode
+		expectedRecovers bool
+	}{
+		{
+			name: "injected failure applies once",
+			failure: &wallettest.Failure{
+				Method: http.MethodGet,
+				Route:  "/v1/wallets/:id",
+				Status: http.StatusTooManyRequests,
+				Code:   types.ErrorCodeUnavailable,
+			},
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedCode:     types.ErrorCodeUnavailable,
+			expectedRecovers: true,
+		},
+		{
+			name:           "storage failure is reported as unavailable",
+			storageFailure: errors.New("connection refused"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   types.ErrorCodeUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := wallettest.NewServer(t)
+			seeded := server.SeedWallet(wallettest.SeedWallet{})
+
+			if tt.failure != nil {
+				server.Fail(*tt.failure)
+			}
+
+			server.SetStorageFailure(tt.storageFailure)
+
+			_, err := server.Client().GetWalletByID(context.Background(), seeded.ID)
+
+			var apiErr *wallet.APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.expectedStatus, apiErr.StatusCode)
+			assert.Equal(t, tt.expectedCode, apiErr.Code)
+
+			_, err = server.Client().GetWalletByID(context.Background(), seeded.ID)
+			assert.Equal(t, tt.expectedRecovers, err == nil)
+		})
+	}
+}
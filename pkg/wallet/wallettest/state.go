@@ -0,0 +1,113 @@
+package wallettest
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/app/models"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/internal/util/ulid"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/types"
+	"github.com/Shaheen-AlQaraghuli/wallet-go/pkg/wallet"
+)
+
+// SeedWallet describes a wallet to put into the fake's storage. Zero fields get sensible defaults.
+type SeedWallet struct {
+	// OwnerID defaults to a unique ID.
+	OwnerID string
+	// Currency defaults to USD.
+	Currency types.Currency
+	// Status defaults to active.
+	Status types.WalletStatus
+	// Balance, when positive, is added as a completed credit transaction.
+	Balance int
+}
+
+// SeedWallet stores a wallet directly, bypassing the API, and returns it with its balance.
+func (s *Server) SeedWallet(seed SeedWallet) wallet.Wallet {
+	s.tb.Helper()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	w := models.Wallet{
+		ID:       ulid.GenerateID(now),
+		OwnerID:  seed.OwnerID,
+		Currency: seed.Currency.String(),
+		Status:   seed.Status.String(),
+		Version:  1,
+	}
+
+	if w.OwnerID == "" {
+		w.OwnerID = "owner-" + w.ID
+	}
+
+	if w.Currency == "" {
+		w.Currency = types.CurrencyUSD.String()
+	}
+
+	if w.Status == "" {
+		w.Status = types.WalletStatusActive.String()
+	}
+
+	w, err := s.store.Wallets().Create(ctx, w)
+	if err != nil {
+		s.tb.Fatalf("wallettest: failed to seed wallet: %v", err)
+	}
+
+	if seed.Balance > 0 {
+		_, err := s.store.Transactions().Create(ctx, models.Transaction{
+			ID:       ulid.GenerateID(now),
+			WalletID: w.ID,
+			Amount:   seed.Balance,
+			Type:     types.TransactionTypeCredit.String(),
+			Status:   types.TransactionStatusCompleted.String(),
+			Version:  1,
+		})
+		if err != nil {
+			s.tb.Fatalf("wallettest: failed to seed balance: %v", err)
+		}
+	}
+
+	balance := seed.Balance
+	w.Balance = &balance
+
+	return w.ToResponse()
+}
+
+// Wallet returns the stored wallet, without balance, and whether it exists.
+func (s *Server) Wallet(id string) (wallet.Wallet, bool) {
+	for _, w := range s.store.Snapshot().Wallets {
+		if w.ID == id {
+			return w.ToResponse(), true
+		}
+	}
+
+	return wallet.Wallet{}, false
+}
+
+// Wallets returns all stored wallets ordered by creation.
+func (s *Server) Wallets() []wallet.Wallet {
+	return models.Wallets(s.store.Snapshot().Wallets).ToResponse()
+}
+
+// Transactions returns the stored transactions of a wallet ordered by creation.
+func (s *Server) Transactions(walletID string) []wallet.Transaction {
+	return s.ledger(walletID).ToResponse()
+}
+
+// Balance computes the wallet balance from its stored transactions, the same way the service does.
+func (s *Server) Balance(walletID string) int {
+	return s.ledger(walletID).Balance()
+}
+
+func (s *Server) ledger(walletID string) models.Transactions {
+	var transactions models.Transactions
+
+	for _, transaction := range s.store.Snapshot().Transactions {
+		if transaction.WalletID == walletID {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	return transactions
+}